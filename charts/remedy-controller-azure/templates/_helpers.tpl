@@ -5,3 +5,14 @@
   {{- printf "%s:%s" .Values.image.repository .Values.image.tag }}
   {{- end }}
 {{- end }}
+
+{{- define "namespaces" -}}
+  {{- $namespaces := list .Release.Namespace }}
+  {{- range .Values.config.azure.targets }}
+  {{- $namespaces = append $namespaces (required "namespace of each target is required" .namespace) }}
+  {{- if .kubeconfigSecretRef }}
+  {{- $namespaces = append $namespaces (required "kubeconfigSecretRef.namespace of each target is required" .kubeconfigSecretRef.namespace) }}
+  {{- end }}
+  {{- end }}
+  {{- $namespaces | uniq | join "," }}
+{{- end }}
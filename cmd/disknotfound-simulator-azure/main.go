@@ -7,10 +7,10 @@ import (
 	"os"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/adal"
-	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -48,24 +48,34 @@ func simulate(vmName string) error {
 		return err
 	}
 
-	// Create OAuth config
-	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, credentials.TenantID)
+	// Determine Azure cloud configuration
+	cloudConfig, err := credentials.CloudConfiguration()
 	if err != nil {
 		return err
 	}
 
-	// Create service principal token
-	servicePrincipalToken, err := adal.NewServicePrincipalToken(*oauthConfig, credentials.ClientID, credentials.ClientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+	// Determine Azure API profile
+	profile, err := credentials.Profile()
+	if err != nil {
+		return err
+	}
+
+	// Create credential
+	credential, err := azclient.NewCredential(credentials, cloudConfig)
 	if err != nil {
 		return err
 	}
-	authorizer := autorest.NewBearerAuthorizer(servicePrincipalToken)
 
 	// Create clients
-	vmClient := compute.NewVirtualMachinesClient(credentials.SubscriptionID)
-	vmClient.Authorizer = authorizer
-	diskClient := compute.NewDisksClient(credentials.SubscriptionID)
-	diskClient.Authorizer = authorizer
+	options := &arm.ClientOptions{ClientOptions: azcore.ClientOptions{Cloud: *cloudConfig}}
+	vmClient, err := armcompute.NewVirtualMachinesClient(credentials.SubscriptionID, credential, profile.ComputeClientOptions(options))
+	if err != nil {
+		return err
+	}
+	diskClient, err := armcompute.NewDisksClient(credentials.SubscriptionID, credential, options)
+	if err != nil {
+		return err
+	}
 
 	// Get Kubernetes config
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
@@ -167,54 +177,49 @@ func simulate(vmName string) error {
 
 	// Get VM
 	fmt.Printf("Getting VM %s\n", vmName)
-	vm, err := vmClient.Get(ctx, credentials.ResourceGroup, vmName, compute.InstanceView)
+	getOptions := &armcompute.VirtualMachinesClientGetOptions{Expand: to.Ptr(armcompute.InstanceViewTypesInstanceView)}
+	resp, err := vmClient.Get(ctx, credentials.ResourceGroup, vmName, getOptions)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Disks: %+v\n", vm.StorageProfile.DataDisks)
+	vm := resp.VirtualMachine
+	fmt.Printf("Disks: %+v\n", vm.Properties.StorageProfile.DataDisks)
 
 	// Detach disk
 	fmt.Printf("Detaching disks\n")
-	disk := (*vm.StorageProfile.DataDisks)[0]
-	vm.StorageProfile.DataDisks = &[]compute.DataDisk{}
-	future, err := vmClient.CreateOrUpdate(ctx, credentials.ResourceGroup, vmName, vm)
+	disk := vm.Properties.StorageProfile.DataDisks[0]
+	vm.Properties.StorageProfile.DataDisks = []*armcompute.DataDisk{}
+	poller, err := vmClient.BeginCreateOrUpdate(ctx, credentials.ResourceGroup, vmName, vm, nil)
 	if err != nil {
 		return err
 	}
-	err = future.WaitForCompletionRef(ctx, vmClient.Client)
-	if err != nil {
-		return err
-	}
-	_, err = future.Result(vmClient)
+	_, err = poller.PollUntilDone(ctx, nil)
 	if err != nil {
 		return err
 	}
 
 	// Get VM
 	fmt.Printf("Getting VM %s\n", vmName)
-	vm, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, compute.InstanceView)
+	resp, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, getOptions)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Disks: %+v\n", vm.StorageProfile.DataDisks)
+	vm = resp.VirtualMachine
+	fmt.Printf("Disks: %+v\n", vm.Properties.StorageProfile.DataDisks)
 
 	errs, ctxx := errgroup.WithContext(ctx)
 
 	errs.Go(func() error {
 		// Reattach disk
 		fmt.Printf("Reattaching disk\n")
-		vm.StorageProfile.DataDisks = &[]compute.DataDisk{
+		vm.Properties.StorageProfile.DataDisks = []*armcompute.DataDisk{
 			disk,
 		}
-		future1, err1 := vmClient.CreateOrUpdate(ctxx, credentials.ResourceGroup, vmName, vm)
+		poller1, err1 := vmClient.BeginCreateOrUpdate(ctxx, credentials.ResourceGroup, vmName, vm, nil)
 		if err1 != nil {
 			return err1
 		}
-		err1 = future1.WaitForCompletionRef(ctxx, vmClient.Client)
-		if err1 != nil {
-			return err1
-		}
-		_, err1 = future1.Result(vmClient)
+		_, err1 = poller1.PollUntilDone(ctxx, nil)
 		if err1 != nil {
 			return err1
 		}
@@ -230,15 +235,11 @@ func simulate(vmName string) error {
 
 		// Delete disk
 		fmt.Printf("Deleting disk\n")
-		future2, err2 := diskClient.Delete(ctxx, credentials.ResourceGroup, *disk.Name)
-		if err2 != nil {
-			return err2
-		}
-		err2 = future2.WaitForCompletionRef(ctxx, diskClient.Client)
+		poller2, err2 := diskClient.BeginDelete(ctxx, credentials.ResourceGroup, *disk.Name, nil)
 		if err2 != nil {
 			return err2
 		}
-		_, err2 = future2.Result(diskClient)
+		_, err2 = poller2.PollUntilDone(ctxx, nil)
 		if err2 != nil {
 			return err2
 		}
@@ -253,11 +254,12 @@ func simulate(vmName string) error {
 
 	// Get VM
 	fmt.Printf("Getting VM %s\n", vmName)
-	vm, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, compute.InstanceView)
+	resp, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, getOptions)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Disks: %+v\n", vm.StorageProfile.DataDisks)
+	vm = resp.VirtualMachine
+	fmt.Printf("Disks: %+v\n", vm.Properties.StorageProfile.DataDisks)
 
 	return nil
 }
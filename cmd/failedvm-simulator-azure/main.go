@@ -6,11 +6,10 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
-	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/adal"
-	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 
 	azclient "github.com/gardener/remedy-controller/pkg/client/azure"
 )
@@ -32,38 +31,47 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create OAuth config
-	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, credentials.TenantID)
+	// Determine Azure cloud configuration
+	cloudConfig, err := credentials.CloudConfiguration()
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
-	// Create service principal token
-	servicePrincipalToken, err := adal.NewServicePrincipalToken(*oauthConfig, credentials.ClientID, credentials.ClientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+	// Determine Azure API profile
+	profile, err := credentials.Profile()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	// Create credential
+	credential, err := azclient.NewCredential(credentials, cloudConfig)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
-	authorizer := autorest.NewBearerAuthorizer(servicePrincipalToken)
 
 	// Create clients
-	vmClient := compute.NewVirtualMachinesClient(credentials.SubscriptionID)
-	vmClient.Authorizer = authorizer
-	nicClient := network.NewInterfacesClient(credentials.SubscriptionID)
-	nicClient.Authorizer = authorizer
+	options := &arm.ClientOptions{ClientOptions: azcore.ClientOptions{Cloud: *cloudConfig}}
+	vmClient, err := armcompute.NewVirtualMachinesClient(credentials.SubscriptionID, credential, profile.ComputeClientOptions(options))
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 
 	// Create context
 	ctx := context.TODO()
 
 	// Get VM
 	fmt.Printf("Getting VM %s...\n", vmName)
-	vm, err := vmClient.Get(ctx, credentials.ResourceGroup, vmName, compute.InstanceView)
+	getOptions := &armcompute.VirtualMachinesClientGetOptions{Expand: to.Ptr(armcompute.InstanceViewTypesInstanceView)}
+	resp, err := vmClient.Get(ctx, credentials.ResourceGroup, vmName, getOptions)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
-	vmData, err := json.Marshal(vm)
+	vmData, err := json.Marshal(resp.VirtualMachine)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
@@ -72,13 +80,13 @@ func main() {
 
 	// Delete VM and wait for it to be deleted
 	fmt.Printf("Deleting VM %s...\n", vmName)
-	future2, err := vmClient.Delete(ctx, credentials.ResourceGroup, vmName)
+	poller2, err := vmClient.BeginDelete(ctx, credentials.ResourceGroup, vmName, nil)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 	fmt.Printf("Waiting for VM %s to be deleted...\n", vmName)
-	if err = future2.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+	if _, err = poller2.PollUntilDone(ctx, nil); err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
@@ -86,16 +94,17 @@ func main() {
 	// Create or update the VM
 	// Change a few VM properties so that the CreateOrUpdate call could pass without an error
 	fmt.Printf("Creating or updating VM %s...\n", vmName)
-	vm.VMID = nil
-	vm.StorageProfile.OsDisk.ManagedDisk.ID = nil
-	vm.OsProfile.RequireGuestProvisionSignal = nil
-	future3, err := vmClient.CreateOrUpdate(ctx, credentials.ResourceGroup, vmName, vm)
+	vm := resp.VirtualMachine
+	vm.Properties.VMID = nil
+	vm.Properties.StorageProfile.OSDisk.ManagedDisk.ID = nil
+	vm.Properties.OSProfile.RequireGuestProvisionSignal = nil
+	poller3, err := vmClient.BeginCreateOrUpdate(ctx, credentials.ResourceGroup, vmName, vm, nil)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 	fmt.Printf("Waiting for VM %s to be created or updated...\n", vmName)
-	if err = future3.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+	if _, err = poller3.PollUntilDone(ctx, nil); err != nil {
 		fmt.Println(err.Error())
 		// os.Exit(1)
 	}
@@ -103,12 +112,12 @@ func main() {
 	// Get VM again
 	// Here, the VM should be in a failed state
 	fmt.Printf("Getting VM %s...\n", vmName)
-	vm, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, compute.InstanceView)
+	resp, err = vmClient.Get(ctx, credentials.ResourceGroup, vmName, getOptions)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
-	vmData, err = json.Marshal(vm)
+	vmData, err = json.Marshal(resp.VirtualMachine)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
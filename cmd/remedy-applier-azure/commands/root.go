@@ -22,12 +22,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	azclient "github.com/gardener/remedy-controller/pkg/client/azure"
 	k8sclient "github.com/gardener/remedy-controller/pkg/client/k8s"
 	"github.com/gardener/remedy-controller/pkg/remedies/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
 	utilsazure "github.com/gardener/remedy-controller/pkg/utils/azure"
 )
 
@@ -35,6 +37,9 @@ import (
 func GetRootCommand() *cobra.Command {
 	var (
 		kubeconfigPath, azureConfigPath, logLevel string
+		maxOrphanRatio                            float64
+		minOrphans                                int
+		disableAnomalyGuard                       bool
 		cmd                                       = &cobra.Command{
 			Use:  "azure-remedy-applier",
 			Long: "TODO",
@@ -52,21 +57,25 @@ func GetRootCommand() *cobra.Command {
 					os.Exit(1)
 				}
 
-				credentials, err := azclient.ReadConfig(azureConfigPath)
-				if err != nil {
-					fmt.Println(err.Error())
-					os.Exit(1)
-				}
-
-				clients, err := azclient.NewClients(credentials)
+				// Rebuild the Azure clients whenever the credentials are rotated
+				credentialsWatcher, err := azclient.NewCredentialsWatcher(azureConfigPath, azclient.NewClients, funcr.New(func(prefix, args string) {
+					log.Info(prefix, " ", args)
+				}, funcr.Options{}))
 				if err != nil {
 					fmt.Println(err.Error())
 					os.Exit(1)
 				}
+				go func() {
+					if err := credentialsWatcher.Start(ctx); err != nil {
+						log.Error(err.Error())
+					}
+				}()
+				credentials := credentialsWatcher.Credentials()
 
 				go azure.CleanPublicIps(ctx, k8sClientSet,
-					utilsazure.NewPublicIPAddressUtils(clients, credentials.ResourceGroup, utilsazure.ReadRequestsCounter, utilsazure.WriteRequestsCounter),
-					credentials.ResourceGroup)
+					utilsazure.NewPublicIPAddressUtils(credentialsWatcher, credentials.ResourceGroup, utilsazure.ReadRequestsCounter, utilsazure.WriteRequestsCounter,
+						utilsazure.NewRateLimiter(nil, utilsazure.ReadRequestsWaitSecondsCounter, utilsazure.WriteRequestsWaitSecondsCounter), false),
+					credentials.ResourceGroup, utils.AnomalyGuard{Disabled: disableAnomalyGuard, MaxOrphanRatio: maxOrphanRatio, MinOrphans: minOrphans})
 
 				<-interuptCh
 				signal.Stop(interuptCh)
@@ -80,6 +89,9 @@ func GetRootCommand() *cobra.Command {
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "path to kubeconfig to target whatever")
 	cmd.Flags().StringVar(&azureConfigPath, "infrastructure-config", "", "path to infrastructure config")
 	cmd.Flags().StringVar(&logLevel, "log-level", "info", "log level: error|info|debug")
+	cmd.Flags().BoolVar(&disableAnomalyGuard, "disable-anomaly-guard", false, "never refuse cleaning orphan public IPs as anomalous")
+	cmd.Flags().Float64Var(&maxOrphanRatio, "max-orphan-ratio", utils.DefaultMaxOrphanRatio, "max ratio of orphan to known public IPs, cleaning is refused if exceeded")
+	cmd.Flags().IntVar(&minOrphans, "min-orphans", utils.DefaultMinOrphans, "min number of orphan public IPs for which the max orphan ratio is checked")
 
 	_ = cmd.MarkFlagRequired("kubeconfig")
 	_ = cmd.MarkFlagRequired("infrastructure-config")
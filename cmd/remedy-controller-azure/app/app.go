@@ -22,16 +22,29 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener/extensions/pkg/controller/cmd"
 	"github.com/gardener/gardener/extensions/pkg/util"
+	gardenerhealthz "github.com/gardener/gardener/pkg/healthz"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	azureinstall "github.com/gardener/remedy-controller/pkg/apis/azure/install"
+	"github.com/gardener/remedy-controller/pkg/client/azure"
 	"github.com/gardener/remedy-controller/pkg/cmd"
+	remedycontroller "github.com/gardener/remedy-controller/pkg/controller"
+	controllerazure "github.com/gardener/remedy-controller/pkg/controller/azure"
 	azurenode "github.com/gardener/remedy-controller/pkg/controller/azure/node"
 	azurepublicipaddress "github.com/gardener/remedy-controller/pkg/controller/azure/publicipaddress"
+	azureremedyaction "github.com/gardener/remedy-controller/pkg/controller/azure/remedyaction"
 	azureservice "github.com/gardener/remedy-controller/pkg/controller/azure/service"
 	azurevirtualmachine "github.com/gardener/remedy-controller/pkg/controller/azure/virtualmachine"
+	"github.com/gardener/remedy-controller/pkg/utils"
+	utilsazure "github.com/gardener/remedy-controller/pkg/utils/azure"
 	"github.com/gardener/remedy-controller/pkg/version"
 )
 
@@ -76,6 +89,11 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			MaxConcurrentReconciles: 5,
 		}
 
+		// options for the remedyaction controller
+		remedyActionCtrlOpts = &controllercmd.ControllerOptions{
+			MaxConcurrentReconciles: 5,
+		}
+
 		configFileOpts           = &cmd.ConfigOptions{}
 		controllerSwitches       = cmd.ControllerSwitchOptions()
 		targetControllerSwitches = cmd.TargetControllerSwitchOptions()
@@ -90,6 +108,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			controllercmd.PrefixOption("virtualmachine-", virtualMachineCtrlOpts),
 			controllercmd.PrefixOption("service-", serviceCtrlOpts),
 			controllercmd.PrefixOption("node-", nodeCtrlOpts),
+			controllercmd.PrefixOption("remedyaction-", remedyActionCtrlOpts),
 			configFileOpts,
 			controllerSwitches,
 			controllercmd.PrefixOption("target-", targetControllerSwitches),
@@ -112,14 +131,55 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			util.ApplyClientConnectionConfigurationToRESTConfig(configFileOpts.Completed().Config.ClientConnection, restOpts.Completed().Config)
 			util.ApplyClientConnectionConfigurationToRESTConfig(configFileOpts.Completed().Config.ClientConnection, targetRestOpts.Completed().Config)
 
+			targetCfgs := configFileOpts.Completed().AzureTargets()
+
 			logger.Info("Creating managers")
-			mgr, err := manager.New(restOpts.Completed().Config, mgrOpts.Completed().Options())
+			mgrOptions := mgrOpts.Completed().Options()
+			if len(targetCfgs) > 0 {
+				// Watch custom resources in the namespaces of all target clusters
+				mgrOptions.Cache.DefaultNamespaces = make(map[string]cache.Config, len(targetCfgs))
+				for _, targetCfg := range targetCfgs {
+					mgrOptions.Cache.DefaultNamespaces[targetCfg.Namespace] = cache.Config{}
+				}
+			}
+			mgr, err := manager.New(restOpts.Completed().Config, mgrOptions)
 			if err != nil {
 				logErrAndExit(err, "Could not create manager")
 			}
-			targetMgr, err := manager.New(targetRestOpts.Completed().Config, targetMgrOpts.Completed().Options())
-			if err != nil {
-				logErrAndExit(err, "Could not create target cluster manager")
+			targetMgrOptions := targetMgrOpts.Completed().Options()
+			// Health probes for all managers are served by the control cluster manager
+			targetMgrOptions.HealthProbeBindAddress = ""
+			var targets []*target
+			if len(targetCfgs) == 0 {
+				targetMgr, err := manager.New(targetRestOpts.Completed().Config, targetMgrOptions)
+				if err != nil {
+					logErrAndExit(err, "Could not create target cluster manager")
+				}
+				targets = append(targets, &target{
+					namespace:       mgrOpts.Completed().Namespace,
+					infraConfigPath: reconcilerOpts.Completed().InfraConfigPath,
+					mgr:             targetMgr,
+				})
+			} else {
+				// Metrics of all managers are served by the control cluster manager, since they share the same registry
+				targetMgrOptions.Metrics.BindAddress = "0"
+				for _, targetCfg := range targetCfgs {
+					targetRestConfig, err := getTargetRESTConfig(ctx, targetCfg, mgr.GetAPIReader())
+					if err != nil {
+						logErrAndExit(err, "Could not get target cluster REST config", "target", targetCfg.Name)
+					}
+					util.ApplyClientConnectionConfigurationToRESTConfig(configFileOpts.Completed().Config.ClientConnection, targetRestConfig)
+					targetMgr, err := manager.New(targetRestConfig, targetMgrOptions)
+					if err != nil {
+						logErrAndExit(err, "Could not create target cluster manager", "target", targetCfg.Name)
+					}
+					targets = append(targets, &target{
+						name:            targetCfg.Name,
+						namespace:       targetCfg.Namespace,
+						infraConfigPath: targetCfg.InfrastructureConfig,
+						mgr:             targetMgr,
+					})
+				}
 			}
 
 			logger.Info("Updating manager schemes")
@@ -130,11 +190,28 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			if err := azureinstall.AddToScheme(scheme); err != nil {
 				logErrAndExit(err, "Could not update manager scheme")
 			}
-			targetScheme := targetMgr.GetScheme()
-			if err := controller.AddToScheme(targetScheme); err != nil {
-				logErrAndExit(err, "Could not update target cluster manager scheme")
+			for _, t := range targets {
+				if err := controller.AddToScheme(t.mgr.GetScheme()); err != nil {
+					logErrAndExit(err, "Could not update target cluster manager scheme")
+				}
+			}
+
+			logger.Info("Adding infrastructure config file watchers to manager")
+			for _, t := range targets {
+				credentialsWatcher, err := azure.NewCredentialsWatcher(t.infraConfigPath, azure.NewClients, log.Log.WithName("credentials-watcher").WithValues("target", t.name))
+				if err != nil {
+					logErrAndExit(err, "Could not create infrastructure config file watcher", "target", t.name)
+				}
+				if err := mgr.Add(credentialsWatcher); err != nil {
+					logErrAndExit(err, "Could not add infrastructure config file watcher to manager", "target", t.name)
+				}
+				t.credentialsWatcher = credentialsWatcher
 			}
 
+			// Keep the default remedy configurations, so that they can be applied again when the config file is reloaded
+			defaultPublicIPAddressConfig := azurepublicipaddress.DefaultAddOptions.Config
+			defaultVirtualMachineConfig := azurevirtualmachine.DefaultAddOptions.Config
+
 			publicIPAddressCtrlOpts.Completed().Apply(&azurepublicipaddress.DefaultAddOptions.Controller)
 			configFileOpts.Completed().ApplyAzureOrphanedPublicIPRemedy(&azurepublicipaddress.DefaultAddOptions.Config)
 			configFileOpts.Completed().ApplyAzureOrphanedPublicIPRemedy(&azureservice.DefaultAddOptions.Config)
@@ -143,48 +220,115 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyAzureFailedVMRemedy(&azurenode.DefaultAddOptions.Config)
 			serviceCtrlOpts.Completed().Apply(&azureservice.DefaultAddOptions.Controller)
 			nodeCtrlOpts.Completed().Apply(&azurenode.DefaultAddOptions.Controller)
-			reconcilerOpts.Completed().Apply(&azurepublicipaddress.DefaultAddOptions.InfraConfigPath)
-			reconcilerOpts.Completed().Apply(&azurevirtualmachine.DefaultAddOptions.InfraConfigPath)
-			azureservice.DefaultAddOptions.Client = mgr.GetClient()
-			azureservice.DefaultAddOptions.Namespace = mgrOpts.Completed().Namespace
+			remedyActionCtrlOpts.Completed().Apply(&azureremedyaction.DefaultAddOptions.Controller)
+			configFileOpts.Completed().ApplyAzureRemedyActions(&azureremedyaction.DefaultAddOptions.Config)
 			azureservice.DefaultAddOptions.Manager = mgr
-			azurenode.DefaultAddOptions.Client = mgr.GetClient()
-			azurenode.DefaultAddOptions.Namespace = mgrOpts.Completed().Namespace
 			azurenode.DefaultAddOptions.Manager = mgr
+			azureRateLimiter := utilsazure.NewRateLimiter(configFileOpts.Completed().AzureRateLimiter(), utilsazure.ReadRequestsWaitSecondsCounter, utilsazure.WriteRequestsWaitSecondsCounter)
+			azurepublicipaddress.DefaultAddOptions.RateLimiter = azureRateLimiter
+			azurevirtualmachine.DefaultAddOptions.RateLimiter = azureRateLimiter
+			// Notifications are sent in the background, so that an unavailable webhook doesn't delay remedies
+			notifier := remedycontroller.NewAsyncNotifier(remedycontroller.NewNotifier(configFileOpts.Completed().Notifications(), log.Log.WithName("notifier")),
+				remedycontroller.DefaultNotificationQueueSize, log.Log.WithName("notifier"))
+			if err := mgr.Add(notifier); err != nil {
+				logErrAndExit(err, "Could not add notifier to manager")
+			}
+			azurepublicipaddress.DefaultAddOptions.Notifier = notifier
+			azurevirtualmachine.DefaultAddOptions.Notifier = notifier
+			publicIPAddressConfigSource := remedycontroller.NewConfigSource(azurepublicipaddress.DefaultAddOptions.Config)
+			azurepublicipaddress.DefaultAddOptions.ConfigSource = publicIPAddressConfigSource
+			virtualMachineConfigSource := remedycontroller.NewConfigSource(azurevirtualmachine.DefaultAddOptions.Config)
+			azurevirtualmachine.DefaultAddOptions.ConfigSource = virtualMachineConfigSource
+			for _, t := range targets {
+				// Each target cluster has its own budget, shared by the public IP address and virtual machine controllers
+				t.budget = controllerazure.NewDestructiveActionBudget(configFileOpts.Completed().AzureDestructiveActionBudget(), utils.TimestamperFunc(metav1.Now),
+					controllerazure.BudgetExhaustedGaugeVec.MustCurryWith(prometheus.Labels{"namespace": t.namespace}))
+				azurepublicipaddress.DefaultAddOptions.Targets = append(azurepublicipaddress.DefaultAddOptions.Targets, t.azureTarget())
+				azurevirtualmachine.DefaultAddOptions.Targets = append(azurevirtualmachine.DefaultAddOptions.Targets, t.azureTarget())
+			}
+
+			logger.Info("Adding config file watcher to manager")
+			configWatcher := cmd.NewConfigWatcher(configFileOpts.ConfigFilePath, configFileOpts.Completed(), cmd.ConfigHashGauge, cmd.ConfigLastReloadSuccessfulGauge, log.Log.WithName("config-watcher"))
+			configWatcher.Subscribe(func(config *cmd.Config) error {
+				// Only the remedy configurations can be changed at runtime, all other changes require a restart
+				publicIPAddressConfig := defaultPublicIPAddressConfig
+				config.ApplyAzureOrphanedPublicIPRemedy(&publicIPAddressConfig)
+				virtualMachineConfig := defaultVirtualMachineConfig
+				config.ApplyAzureFailedVMRemedy(&virtualMachineConfig)
+				return utilerrors.NewAggregate([]error{
+					errors.Wrap(publicIPAddressConfigSource.Set(publicIPAddressConfig), "could not apply orphaned public IP remedy configuration"),
+					errors.Wrap(virtualMachineConfigSource.Set(virtualMachineConfig), "could not apply failed VM remedy configuration"),
+				})
+			})
+			if err := mgr.Add(configWatcher); err != nil {
+				logErrAndExit(err, "Could not add config file watcher to manager")
+			}
+
+			logger.Info("Adding health checks to manager")
+			if err := addHealthChecks(mgr, targets); err != nil {
+				logErrAndExit(err, "Could not add health checks to manager")
+			}
 
 			logger.Info("Adding controllers to managers")
 			if err := controllerSwitches.Completed().AddToManager(ctx, mgr); err != nil {
 				logErrAndExit(err, "Could not add controllers to manager")
 			}
-			if err := targetControllerSwitches.Completed().AddToManager(ctx, targetMgr); err != nil {
-				logErrAndExit(err, "Could not add controllers to target cluster manager")
+			for _, t := range targets {
+				if err := t.addControllers(targetControllerSwitches, mgr.GetClient()); err != nil {
+					logErrAndExit(err, "Could not add controllers to target cluster manager", "target", t.name)
+				}
 			}
 
 			logger.Info("Starting managers")
 			var wg sync.WaitGroup
-			wg.Add(2)
+			wg.Add(1 + len(targets))
 			go func() {
 				defer wg.Done()
 				if err := mgr.Start(ctx); err != nil {
 					logErrAndExit(err, "Error starting manager")
 				}
 			}()
-			go func() {
-				defer wg.Done()
-				if err := targetMgr.Start(ctx); err != nil {
-					logErrAndExit(err, "Error starting target cluster manager")
-				}
-			}()
+			for _, t := range targets {
+				go func() {
+					defer wg.Done()
+					if err := t.mgr.Start(ctx); err != nil {
+						logErrAndExit(err, "Error starting target cluster manager", "target", t.name)
+					}
+				}()
+			}
 			wg.Wait()
 		},
 	}
 
 	aggOption.AddFlags(cmd.Flags())
+	cmd.AddCommand(NewValidateConfigCommand())
 
 	return cmd
 }
 
-func logErrAndExit(err error, msg string) {
-	log.Log.Error(err, msg)
+func addHealthChecks(mgr manager.Manager, targets []*target) error {
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		return errors.Wrap(err, "could not add ping healthz check")
+	}
+	if err := mgr.AddReadyzCheck("informer-sync", gardenerhealthz.NewCacheSyncHealthz(mgr.GetCache())); err != nil {
+		return errors.Wrap(err, "could not add informer-sync readyz check")
+	}
+
+	for _, t := range targets {
+		connectivityChecker := utilsazure.NewConnectivityChecker(t.credentialsWatcher, t.credentialsWatcher.Credentials().ResourceGroup, utilsazure.ReadRequestsCounter,
+			utils.TimestamperFunc(metav1.Now), utilsazure.DefaultConnectivityCheckCacheTTL, utilsazure.DefaultConnectivityCheckTimeout)
+
+		if err := mgr.AddReadyzCheck(t.withName("target-informer-sync"), gardenerhealthz.NewCacheSyncHealthz(t.mgr.GetCache())); err != nil {
+			return errors.Wrap(err, "could not add target-informer-sync readyz check")
+		}
+		if err := mgr.AddReadyzCheck(t.withName("azure"), connectivityChecker.Check); err != nil {
+			return errors.Wrap(err, "could not add azure readyz check")
+		}
+	}
+	return nil
+}
+
+func logErrAndExit(err error, msg string, keysAndValues ...any) {
+	log.Log.Error(err, msg, keysAndValues...)
 	os.Exit(1)
 }
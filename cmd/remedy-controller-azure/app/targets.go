@@ -0,0 +1,121 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"slices"
+
+	controllercmd "github.com/gardener/gardener/extensions/pkg/controller/cmd"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	"github.com/gardener/remedy-controller/pkg/client/azure"
+	controllerazure "github.com/gardener/remedy-controller/pkg/controller/azure"
+	azurenode "github.com/gardener/remedy-controller/pkg/controller/azure/node"
+	azureservice "github.com/gardener/remedy-controller/pkg/controller/azure/service"
+)
+
+const (
+	// kubeconfigSecretKey is the data key of the kubeconfig in a secret referenced by a target cluster configuration.
+	kubeconfigSecretKey = "kubeconfig"
+)
+
+// target is a target cluster managed by the controller, with its own manager.
+type target struct {
+	// name is the name of the target cluster, or empty if there is a single target cluster.
+	name string
+	// namespace is the namespace for custom resources of the target cluster in the control cluster.
+	namespace string
+	// infraConfigPath is the path to the infrastructure configuration file containing the Azure credentials.
+	infraConfigPath string
+	// mgr is the manager for the target cluster.
+	mgr manager.Manager
+	// credentialsWatcher provides the Azure credentials and clients for the target cluster.
+	credentialsWatcher azure.CredentialsWatcher
+	// budget is the budget for destructive actions in the target cluster.
+	budget controllerazure.DestructiveActionBudget
+}
+
+// withName appends the name of this target to the given name, if there are multiple target clusters.
+func (t *target) withName(name string) string {
+	if t.name == "" {
+		return name
+	}
+	return name + "-" + t.name
+}
+
+// azureTarget returns the controllerazure.Target for this target.
+func (t *target) azureTarget() controllerazure.Target {
+	return controllerazure.Target{
+		Namespace:          t.namespace,
+		InfraConfigPath:    t.infraConfigPath,
+		CredentialsWatcher: t.credentialsWatcher,
+		Recorder:           t.mgr.GetEventRecorderFor(Name),
+		Budget:             t.budget,
+	}
+}
+
+// addControllers adds the enabled target cluster controllers for this target to its manager.
+func (t *target) addControllers(switches *controllercmd.SwitchOptions, client client.Client) error {
+	if isControllerEnabled(switches, azureservice.ControllerName) {
+		options := azureservice.DefaultAddOptions
+		options.Client = client
+		options.Namespace = t.namespace
+		options.TargetName = t.name
+		if err := azureservice.AddToManagerWithOptions(t.mgr, options); err != nil {
+			return err
+		}
+	}
+	if isControllerEnabled(switches, azurenode.ControllerName) {
+		options := azurenode.DefaultAddOptions
+		options.Client = client
+		options.Namespace = t.namespace
+		options.TargetName = t.name
+		if err := azurenode.AddToManagerWithOptions(t.mgr, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTargetRESTConfig returns the REST config for the given target cluster configuration,
+// either from a kubeconfig file or from a secret in the control cluster read with the given reader.
+func getTargetRESTConfig(ctx context.Context, cfg config.AzureTargetConfiguration, reader client.Reader) (*rest.Config, error) {
+	if cfg.KubeconfigSecretRef == nil {
+		restConfig, err := clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
+		return restConfig, errors.Wrapf(err, "could not read kubeconfig file %s", cfg.Kubeconfig)
+	}
+
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: cfg.KubeconfigSecretRef.Namespace, Name: cfg.KubeconfigSecretRef.Name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get kubeconfig secret %s/%s", cfg.KubeconfigSecretRef.Namespace, cfg.KubeconfigSecretRef.Name)
+	}
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, errors.Errorf("kubeconfig secret %s/%s has no data key %s", cfg.KubeconfigSecretRef.Namespace, cfg.KubeconfigSecretRef.Name, kubeconfigSecretKey)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	return restConfig, errors.Wrapf(err, "could not read kubeconfig from secret %s/%s", cfg.KubeconfigSecretRef.Namespace, cfg.KubeconfigSecretRef.Name)
+}
+
+func isControllerEnabled(switches *controllercmd.SwitchOptions, name string) bool {
+	return slices.Contains(switches.Enabled, name) && !slices.Contains(switches.Disabled, name)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	confighelper "github.com/gardener/remedy-controller/pkg/apis/config/helper"
+	"github.com/gardener/remedy-controller/pkg/apis/config/validation"
+	"github.com/gardener/remedy-controller/pkg/cmd"
+)
+
+// NewValidateConfigCommand creates a new command for validating a remedy controller configuration file.
+func NewValidateConfigCommand() *cobra.Command {
+	configFileOpts := &cmd.ConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "validate-config",
+		Short:        "Validate a remedy controller configuration file",
+		Long:         "Validate a remedy controller configuration file, applying defaults as on startup, and report all invalid fields.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+
+		RunE: func(c *cobra.Command, _ []string) error {
+			if len(configFileOpts.ConfigFilePath) == 0 {
+				return errors.New("config file path not set")
+			}
+			config, err := confighelper.LoadFromFile(configFileOpts.ConfigFilePath)
+			if err != nil {
+				return errors.Wrapf(err, "could not load configuration file %s", configFileOpts.ConfigFilePath)
+			}
+
+			if errs := validation.ValidateControllerConfiguration(config); len(errs) > 0 {
+				for _, err := range errs {
+					fmt.Fprintln(c.ErrOrStderr(), err.Error())
+				}
+				return errors.Errorf("configuration file %s is invalid", configFileOpts.ConfigFilePath)
+			}
+			fmt.Fprintf(c.OutOrStdout(), "Configuration file %s is valid\n", configFileOpts.ConfigFilePath)
+			return nil
+		},
+	}
+
+	configFileOpts.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -27,6 +27,15 @@ const (
 	OperationTypeReapplyVirtualMachine OperationType = "ReapplyVirtualMachine"
 )
 
+// FailureReason is a string alias.
+type FailureReason string
+
+// Failure reasons
+const (
+	FailureReasonTransient FailureReason = "Transient"
+	FailureReasonPermanent FailureReason = "Permanent"
+)
+
 // FailedOperation describes a failed Azure operation that has been attempted a certain number of times.
 type FailedOperation struct {
 	// Type is the operation type.
@@ -35,6 +44,12 @@ type FailedOperation struct {
 	Attempts int
 	// ErrorMessage is a the error message from the last operation failure.
 	ErrorMessage string
+	// Reason is the reason of the last operation failure, either Transient or Permanent.
+	Reason FailureReason
+	// Code is the Azure error code of the last operation failure, if known.
+	Code string
+	// CorrelationID is the Azure correlation request ID of the last operation failure, if known.
+	CorrelationID string
 	// Timestamp is the timestamp of the last operation failure.
 	Timestamp metav1.Time
 }
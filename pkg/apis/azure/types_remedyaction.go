@@ -0,0 +1,82 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemedyActionType is a string alias.
+type RemedyActionType string
+
+// Remedy action types
+const (
+	RemedyActionTypeRemovePublicIPAddressFromLoadBalancer RemedyActionType = "RemovePublicIPAddressFromLoadBalancer"
+	RemedyActionTypeDeletePublicIPAddress                 RemedyActionType = "DeletePublicIPAddress"
+	RemedyActionTypeReapplyVirtualMachine                 RemedyActionType = "ReapplyVirtualMachine"
+)
+
+// RemedyActionOutcome is a string alias.
+type RemedyActionOutcome string
+
+// Remedy action outcomes
+const (
+	RemedyActionOutcomeSucceeded RemedyActionOutcome = "Succeeded"
+	RemedyActionOutcomeFailed    RemedyActionOutcome = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemedyAction records a destructive action performed by a remedy on an Azure resource.
+type RemedyAction struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   RemedyActionSpec
+	Status RemedyActionStatus
+}
+
+// RemedyActionSpec represents the spec of a remedy action.
+type RemedyActionSpec struct {
+	// Type is the type of the action.
+	Type RemedyActionType
+	// AzureResourceID is the id of the Azure resource the action is performed on.
+	AzureResourceID string
+	// TriggeredBy is a reference to the Kubernetes object that triggered the action.
+	TriggeredBy corev1.ObjectReference
+}
+
+// RemedyActionStatus represents the status of a remedy action.
+type RemedyActionStatus struct {
+	// Outcome is the outcome of the action, either Succeeded or Failed. It is empty while the action is in progress.
+	Outcome RemedyActionOutcome
+	// ErrorMessage is the error message if the action failed.
+	ErrorMessage string
+	// StartTime is the time the action was started.
+	StartTime *metav1.Time
+	// CompletionTime is the time the action was completed.
+	CompletionTime *metav1.Time
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemedyActionList contains a list of RemedyAction.
+type RemedyActionList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []RemedyAction
+}
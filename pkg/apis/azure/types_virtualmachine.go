@@ -51,6 +51,8 @@ type VirtualMachineStatus struct {
 	ProvisioningState *string
 	// FailedOperations is a list of all failed operations on the virtual machine resource in Azure.
 	FailedOperations []FailedOperation
+	// Conditions represents the latest available observations of the virtual machine resource in Azure and its remedies.
+	Conditions []metav1.Condition
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -49,6 +49,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PublicIPAddressList{},
 		&VirtualMachine{},
 		&VirtualMachineList{},
+		&RemedyAction{},
+		&RemedyActionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
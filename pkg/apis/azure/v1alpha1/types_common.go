@@ -14,7 +14,10 @@
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // OperationType is a string alias.
 // +kubebuilder:validation:Enum=GetPublicIPAddress;CleanPublicIPAddress;GetVirtualMachine;ReapplyVirtualMachine
@@ -28,6 +31,57 @@ const (
 	OperationTypeReapplyVirtualMachine OperationType = "ReapplyVirtualMachine"
 )
 
+// Condition types
+const (
+	// ConditionTypeTracked indicates whether the resource exists in Azure and is being tracked.
+	ConditionTypeTracked = "Tracked"
+	// ConditionTypeAzureReachable indicates whether the resource could be retrieved from Azure.
+	ConditionTypeAzureReachable = "AzureReachable"
+	// ConditionTypeRemedied indicates whether the resource is healthy, either because no remedy was needed or because it was applied successfully.
+	ConditionTypeRemedied = "Remedied"
+	// ConditionTypeRemedyExhausted indicates whether an operation on the resource has reached its maximum number of attempts.
+	ConditionTypeRemedyExhausted = "RemedyExhausted"
+	// ConditionTypePendingApproval indicates whether a destructive remedy is waiting for manual approval.
+	ConditionTypePendingApproval = "PendingApproval"
+)
+
+// Condition reasons
+const (
+	ConditionReasonFound                    = "Found"
+	ConditionReasonNotFound                 = "NotFound"
+	ConditionReasonRequestSucceeded         = "RequestSucceeded"
+	ConditionReasonRequestFailed            = "RequestFailed"
+	ConditionReasonNotNeeded                = "NotNeeded"
+	ConditionReasonGracePeriodPending       = "GracePeriodPending"
+	ConditionReasonCleaningSkipped          = "CleaningSkipped"
+	ConditionReasonCleaned                  = "Cleaned"
+	ConditionReasonCleanFailed              = "CleanFailed"
+	ConditionReasonReapplied                = "Reapplied"
+	ConditionReasonReapplyFailed            = "ReapplyFailed"
+	ConditionReasonProvisioningFailed       = "ProvisioningFailed"
+	ConditionReasonMaxAttemptsReached       = "MaxAttemptsReached"
+	ConditionReasonAttemptsRemaining        = "AttemptsRemaining"
+	ConditionReasonDryRun                   = "DryRun"
+	ConditionReasonPermanentFailure         = "PermanentFailure"
+	ConditionReasonBudgetExhausted          = "BudgetExhausted"
+	ConditionReasonAnomalyDetected          = "AnomalyDetected"
+	ConditionReasonApprovalRequired         = "ApprovalRequired"
+	ConditionReasonApproved                 = "Approved"
+	ConditionReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
+)
+
+// FailureReason is a string alias.
+// +kubebuilder:validation:Enum=Transient;Permanent
+type FailureReason string
+
+// Failure reasons
+const (
+	// FailureReasonTransient indicates that the operation may succeed if retried.
+	FailureReasonTransient FailureReason = "Transient"
+	// FailureReasonPermanent indicates that the operation will never succeed if retried, e.g. because of missing permissions.
+	FailureReasonPermanent FailureReason = "Permanent"
+)
+
 // FailedOperation describes a failed Azure operation that has been attempted a certain number of times.
 type FailedOperation struct {
 	// Type is the operation type.
@@ -36,30 +90,31 @@ type FailedOperation struct {
 	Attempts int `json:"attempts"`
 	// ErrorMessage is a the error message from the last operation failure.
 	ErrorMessage string `json:"errorMessage"`
+	// Reason is the reason of the last operation failure, either Transient or Permanent.
+	// +optional
+	Reason FailureReason `json:"reason,omitempty"`
+	// Code is the Azure error code of the last operation failure, if known.
+	// +optional
+	Code string `json:"code,omitempty"`
+	// CorrelationID is the Azure correlation request ID of the last operation failure, if known.
+	// +optional
+	CorrelationID string `json:"correlationID,omitempty"`
 	// Timestamp is the timestamp of the last operation failure.
 	Timestamp metav1.Time `json:"timestamp"`
 }
 
-// AddOrUpdateFailedOperation adds a new or updates an existing FailedOperation of the given type in the given slice.
-func AddOrUpdateFailedOperation(failedOperations *[]FailedOperation, opType OperationType, errorMessage string, timestamp metav1.Time) *FailedOperation {
-	for i, op := range *failedOperations {
-		if op.Type == opType {
-			op = FailedOperation{
-				Type:         opType,
-				Attempts:     op.Attempts + 1,
-				ErrorMessage: errorMessage,
-				Timestamp:    timestamp,
-			}
+// AddOrUpdateFailedOperation adds a new or updates an existing FailedOperation of the same type as the given one in the given slice.
+// The attempts are set to 1 for a new FailedOperation, or incremented for an existing one.
+func AddOrUpdateFailedOperation(failedOperations *[]FailedOperation, failedOperation FailedOperation) *FailedOperation {
+	op := failedOperation
+	for i := range *failedOperations {
+		if (*failedOperations)[i].Type == op.Type {
+			op.Attempts = (*failedOperations)[i].Attempts + 1
 			(*failedOperations)[i] = op
 			return &op
 		}
 	}
-	op := FailedOperation{
-		Type:         opType,
-		Attempts:     1,
-		ErrorMessage: errorMessage,
-		Timestamp:    timestamp,
-	}
+	op.Attempts = 1
 	*failedOperations = append(*failedOperations, op)
 	return &op
 }
@@ -73,3 +128,26 @@ func DeleteFailedOperation(failedOperations *[]FailedOperation, opType Operation
 		}
 	}
 }
+
+// GetFailedOperation returns the FailedOperation of the given type from the given slice, or nil if not found.
+func GetFailedOperation(failedOperations []FailedOperation, opType OperationType) *FailedOperation {
+	for i := range failedOperations {
+		if failedOperations[i].Type == opType {
+			return &failedOperations[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds a new or updates an existing condition of the given type in the given slice.
+// The last transition time is only changed if the status of the condition changes.
+func SetCondition(conditions *[]metav1.Condition, condType string, status metav1.ConditionStatus, reason, message string, observedGeneration int64, timestamp metav1.Time) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: observedGeneration,
+		LastTransitionTime: timestamp,
+	})
+}
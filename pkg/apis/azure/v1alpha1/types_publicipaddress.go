@@ -23,6 +23,12 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=pubip
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="IP",type=string,JSONPath=`.spec.ipAddress`
+// +kubebuilder:printcolumn:name="Exists",type=boolean,JSONPath=`.status.exists`
+// +kubebuilder:printcolumn:name="Tracked",type=string,JSONPath=`.status.conditions[?(@.type=="Tracked")].status`
+// +kubebuilder:printcolumn:name="Remedied",type=string,JSONPath=`.status.conditions[?(@.type=="Remedied")].status`
+// +kubebuilder:printcolumn:name="Exhausted",type=string,JSONPath=`.status.conditions[?(@.type=="RemedyExhausted")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PublicIPAddress represents an Azure public IP address.
 type PublicIPAddress struct {
@@ -51,6 +57,13 @@ type PublicIPAddressStatus struct {
 	ProvisioningState *string `json:"provisioningState,omitempty"`
 	// FailedOperations is a list of all failed operations on the virtual machine resource in Azure.
 	FailedOperations []FailedOperation `json:"failedOperations,omitempty"`
+	// Conditions represents the latest available observations of the public IP address resource in Azure and its remedies.
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -63,3 +76,13 @@ type PublicIPAddressList struct {
 
 	Items []PublicIPAddress `json:"items"`
 }
+
+// GetConditions returns the conditions in the PublicIPAddress status.
+func (o *PublicIPAddress) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+// SetConditions sets the conditions in the PublicIPAddress status.
+func (o *PublicIPAddress) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
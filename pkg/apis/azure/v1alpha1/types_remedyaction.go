@@ -0,0 +1,96 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemedyActionType is a string alias.
+// +kubebuilder:validation:Enum=RemovePublicIPAddressFromLoadBalancer;DeletePublicIPAddress;ReapplyVirtualMachine
+type RemedyActionType string
+
+// Remedy action types
+const (
+	RemedyActionTypeRemovePublicIPAddressFromLoadBalancer RemedyActionType = "RemovePublicIPAddressFromLoadBalancer"
+	RemedyActionTypeDeletePublicIPAddress                 RemedyActionType = "DeletePublicIPAddress"
+	RemedyActionTypeReapplyVirtualMachine                 RemedyActionType = "ReapplyVirtualMachine"
+)
+
+// RemedyActionOutcome is a string alias.
+// +kubebuilder:validation:Enum=Succeeded;Failed
+type RemedyActionOutcome string
+
+// Remedy action outcomes
+const (
+	RemedyActionOutcomeSucceeded RemedyActionOutcome = "Succeeded"
+	RemedyActionOutcomeFailed    RemedyActionOutcome = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Triggered By",type=string,JSONPath=`.spec.triggeredBy.name`
+// +kubebuilder:printcolumn:name="Outcome",type=string,JSONPath=`.status.outcome`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RemedyAction records a destructive action performed by a remedy on an Azure resource.
+type RemedyAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemedyActionSpec   `json:"spec,omitempty"`
+	Status RemedyActionStatus `json:"status,omitempty"`
+}
+
+// RemedyActionSpec represents the spec of a remedy action.
+type RemedyActionSpec struct {
+	// Type is the type of the action.
+	Type RemedyActionType `json:"type"`
+	// AzureResourceID is the id of the Azure resource the action is performed on.
+	AzureResourceID string `json:"azureResourceID"`
+	// TriggeredBy is a reference to the Kubernetes object that triggered the action.
+	TriggeredBy corev1.ObjectReference `json:"triggeredBy"`
+}
+
+// RemedyActionStatus represents the status of a remedy action.
+type RemedyActionStatus struct {
+	// Outcome is the outcome of the action, either Succeeded or Failed. It is empty while the action is in progress.
+	// +optional
+	Outcome RemedyActionOutcome `json:"outcome,omitempty"`
+	// ErrorMessage is the error message if the action failed.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// StartTime is the time the action was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the action was completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// RemedyActionList contains a list of RemedyAction.
+type RemedyActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RemedyAction `json:"items"`
+}
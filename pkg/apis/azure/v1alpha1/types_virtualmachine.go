@@ -23,6 +23,12 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=vm
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Hostname",type=string,JSONPath=`.spec.hostname`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.provisioningState`
+// +kubebuilder:printcolumn:name="Tracked",type=string,JSONPath=`.status.conditions[?(@.type=="Tracked")].status`
+// +kubebuilder:printcolumn:name="Remedied",type=string,JSONPath=`.status.conditions[?(@.type=="Remedied")].status`
+// +kubebuilder:printcolumn:name="Exhausted",type=string,JSONPath=`.status.conditions[?(@.type=="RemedyExhausted")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // VirtualMachine represents an Azure virtual machine.
 type VirtualMachine struct {
@@ -55,6 +61,13 @@ type VirtualMachineStatus struct {
 	ProvisioningState *string `json:"provisioningState,omitempty"`
 	// FailedOperations is a list of all failed operations on the virtual machine resource in Azure.
 	FailedOperations []FailedOperation `json:"failedOperations,omitempty"`
+	// Conditions represents the latest available observations of the virtual machine resource in Azure and its remedies.
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -67,3 +80,13 @@ type VirtualMachineList struct {
 
 	Items []VirtualMachine `json:"items"`
 }
+
+// GetConditions returns the conditions in the VirtualMachine status.
+func (o *VirtualMachine) GetConditions() []metav1.Condition {
+	return o.Status.Conditions
+}
+
+// SetConditions sets the conditions in the VirtualMachine status.
+func (o *VirtualMachine) SetConditions(conditions []metav1.Condition) {
+	o.Status.Conditions = conditions
+}
@@ -13,6 +13,7 @@ import (
 	unsafe "unsafe"
 
 	azure "github.com/gardener/remedy-controller/pkg/apis/azure"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -74,6 +75,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RemedyAction)(nil), (*azure.RemedyAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemedyAction_To_azure_RemedyAction(a.(*RemedyAction), b.(*azure.RemedyAction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RemedyAction)(nil), (*RemedyAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RemedyAction_To_v1alpha1_RemedyAction(a.(*azure.RemedyAction), b.(*RemedyAction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemedyActionList)(nil), (*azure.RemedyActionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemedyActionList_To_azure_RemedyActionList(a.(*RemedyActionList), b.(*azure.RemedyActionList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RemedyActionList)(nil), (*RemedyActionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RemedyActionList_To_v1alpha1_RemedyActionList(a.(*azure.RemedyActionList), b.(*RemedyActionList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemedyActionSpec)(nil), (*azure.RemedyActionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec(a.(*RemedyActionSpec), b.(*azure.RemedyActionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RemedyActionSpec)(nil), (*RemedyActionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec(a.(*azure.RemedyActionSpec), b.(*RemedyActionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemedyActionStatus)(nil), (*azure.RemedyActionStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus(a.(*RemedyActionStatus), b.(*azure.RemedyActionStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RemedyActionStatus)(nil), (*RemedyActionStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus(a.(*azure.RemedyActionStatus), b.(*RemedyActionStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachine)(nil), (*azure.VirtualMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachine_To_azure_VirtualMachine(a.(*VirtualMachine), b.(*azure.VirtualMachine), scope)
 	}); err != nil {
@@ -121,6 +162,9 @@ func autoConvert_v1alpha1_FailedOperation_To_azure_FailedOperation(in *FailedOpe
 	out.Type = azure.OperationType(in.Type)
 	out.Attempts = in.Attempts
 	out.ErrorMessage = in.ErrorMessage
+	out.Reason = azure.FailureReason(in.Reason)
+	out.Code = in.Code
+	out.CorrelationID = in.CorrelationID
 	out.Timestamp = in.Timestamp
 	return nil
 }
@@ -134,6 +178,9 @@ func autoConvert_azure_FailedOperation_To_v1alpha1_FailedOperation(in *azure.Fai
 	out.Type = OperationType(in.Type)
 	out.Attempts = in.Attempts
 	out.ErrorMessage = in.ErrorMessage
+	out.Reason = FailureReason(in.Reason)
+	out.Code = in.Code
+	out.CorrelationID = in.CorrelationID
 	out.Timestamp = in.Timestamp
 	return nil
 }
@@ -223,6 +270,7 @@ func autoConvert_v1alpha1_PublicIPAddressStatus_To_azure_PublicIPAddressStatus(i
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ProvisioningState = (*string)(unsafe.Pointer(in.ProvisioningState))
 	out.FailedOperations = *(*[]azure.FailedOperation)(unsafe.Pointer(&in.FailedOperations))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -237,6 +285,7 @@ func autoConvert_azure_PublicIPAddressStatus_To_v1alpha1_PublicIPAddressStatus(i
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ProvisioningState = (*string)(unsafe.Pointer(in.ProvisioningState))
 	out.FailedOperations = *(*[]FailedOperation)(unsafe.Pointer(&in.FailedOperations))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -245,6 +294,110 @@ func Convert_azure_PublicIPAddressStatus_To_v1alpha1_PublicIPAddressStatus(in *a
 	return autoConvert_azure_PublicIPAddressStatus_To_v1alpha1_PublicIPAddressStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_RemedyAction_To_azure_RemedyAction(in *RemedyAction, out *azure.RemedyAction, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RemedyAction_To_azure_RemedyAction is an autogenerated conversion function.
+func Convert_v1alpha1_RemedyAction_To_azure_RemedyAction(in *RemedyAction, out *azure.RemedyAction, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemedyAction_To_azure_RemedyAction(in, out, s)
+}
+
+func autoConvert_azure_RemedyAction_To_v1alpha1_RemedyAction(in *azure.RemedyAction, out *RemedyAction, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_azure_RemedyAction_To_v1alpha1_RemedyAction is an autogenerated conversion function.
+func Convert_azure_RemedyAction_To_v1alpha1_RemedyAction(in *azure.RemedyAction, out *RemedyAction, s conversion.Scope) error {
+	return autoConvert_azure_RemedyAction_To_v1alpha1_RemedyAction(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemedyActionList_To_azure_RemedyActionList(in *RemedyActionList, out *azure.RemedyActionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]azure.RemedyAction)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_RemedyActionList_To_azure_RemedyActionList is an autogenerated conversion function.
+func Convert_v1alpha1_RemedyActionList_To_azure_RemedyActionList(in *RemedyActionList, out *azure.RemedyActionList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemedyActionList_To_azure_RemedyActionList(in, out, s)
+}
+
+func autoConvert_azure_RemedyActionList_To_v1alpha1_RemedyActionList(in *azure.RemedyActionList, out *RemedyActionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]RemedyAction)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_azure_RemedyActionList_To_v1alpha1_RemedyActionList is an autogenerated conversion function.
+func Convert_azure_RemedyActionList_To_v1alpha1_RemedyActionList(in *azure.RemedyActionList, out *RemedyActionList, s conversion.Scope) error {
+	return autoConvert_azure_RemedyActionList_To_v1alpha1_RemedyActionList(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec(in *RemedyActionSpec, out *azure.RemedyActionSpec, s conversion.Scope) error {
+	out.Type = azure.RemedyActionType(in.Type)
+	out.AzureResourceID = in.AzureResourceID
+	out.TriggeredBy = in.TriggeredBy
+	return nil
+}
+
+// Convert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec(in *RemedyActionSpec, out *azure.RemedyActionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemedyActionSpec_To_azure_RemedyActionSpec(in, out, s)
+}
+
+func autoConvert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec(in *azure.RemedyActionSpec, out *RemedyActionSpec, s conversion.Scope) error {
+	out.Type = RemedyActionType(in.Type)
+	out.AzureResourceID = in.AzureResourceID
+	out.TriggeredBy = in.TriggeredBy
+	return nil
+}
+
+// Convert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec is an autogenerated conversion function.
+func Convert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec(in *azure.RemedyActionSpec, out *RemedyActionSpec, s conversion.Scope) error {
+	return autoConvert_azure_RemedyActionSpec_To_v1alpha1_RemedyActionSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus(in *RemedyActionStatus, out *azure.RemedyActionStatus, s conversion.Scope) error {
+	out.Outcome = azure.RemedyActionOutcome(in.Outcome)
+	out.ErrorMessage = in.ErrorMessage
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus(in *RemedyActionStatus, out *azure.RemedyActionStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemedyActionStatus_To_azure_RemedyActionStatus(in, out, s)
+}
+
+func autoConvert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus(in *azure.RemedyActionStatus, out *RemedyActionStatus, s conversion.Scope) error {
+	out.Outcome = RemedyActionOutcome(in.Outcome)
+	out.ErrorMessage = in.ErrorMessage
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus is an autogenerated conversion function.
+func Convert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus(in *azure.RemedyActionStatus, out *RemedyActionStatus, s conversion.Scope) error {
+	return autoConvert_azure_RemedyActionStatus_To_v1alpha1_RemedyActionStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_VirtualMachine_To_azure_VirtualMachine(in *VirtualMachine, out *azure.VirtualMachine, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_VirtualMachineSpec_To_azure_VirtualMachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -329,6 +482,7 @@ func autoConvert_v1alpha1_VirtualMachineStatus_To_azure_VirtualMachineStatus(in
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ProvisioningState = (*string)(unsafe.Pointer(in.ProvisioningState))
 	out.FailedOperations = *(*[]azure.FailedOperation)(unsafe.Pointer(&in.FailedOperations))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -343,6 +497,7 @@ func autoConvert_azure_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(in
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ProvisioningState = (*string)(unsafe.Pointer(in.ProvisioningState))
 	out.FailedOperations = *(*[]FailedOperation)(unsafe.Pointer(&in.FailedOperations))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -15,6 +15,7 @@
 package config
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -31,12 +32,81 @@ type ControllerConfiguration struct {
 
 	// Azure specifies the configuration for all Azure remedies.
 	Azure *AzureConfiguration
+
+	// DryRun specifies that all remedies should only record what they would have done,
+	// without issuing any write requests to the cloud provider.
+	DryRun bool
+
+	// Notifications specifies the configuration for notifications about remedy outcomes.
+	Notifications *NotificationsConfiguration
 }
 
 // AzureConfiguration defines the configuration for the Azure remedy controller.
 type AzureConfiguration struct {
 	OrphanedPublicIPRemedy *AzureOrphanedPublicIPRemedyConfiguration
 	FailedVMRemedy         *AzureFailedVMRemedyConfiguration
+	// RateLimiter is the configuration of the client-side rate limiter for Azure API requests,
+	// shared by all Azure remedies.
+	RateLimiter *AzureRateLimiterConfiguration
+	// RemedyActions is the configuration for RemedyAction resources recording destructive actions of Azure remedies.
+	RemedyActions *AzureRemedyActionsConfiguration
+	// DestructiveActionBudget is the configuration of the budget for destructive actions of Azure remedies,
+	// shared by all Azure remedies.
+	DestructiveActionBudget *AzureDestructiveActionBudgetConfiguration
+	// Targets specifies multiple target clusters to be managed by a single controller process.
+	// If not specified, a single target cluster is managed as specified via command line options.
+	Targets []AzureTargetConfiguration
+}
+
+// AzureTargetConfiguration defines a target cluster managed by the Azure remedy controller.
+type AzureTargetConfiguration struct {
+	// Name is the unique name of the target cluster. It is appended to the names of the controllers for this target cluster.
+	Name string
+	// Kubeconfig is the path to a kubeconfig file for the target cluster.
+	// Either Kubeconfig or KubeconfigSecretRef must be specified.
+	Kubeconfig string
+	// KubeconfigSecretRef is a reference to a secret in the control cluster that contains a kubeconfig
+	// for the target cluster in the data key "kubeconfig".
+	// Either Kubeconfig or KubeconfigSecretRef must be specified.
+	KubeconfigSecretRef *corev1.SecretReference
+	// InfrastructureConfig is the path to the infrastructure configuration file containing the Azure credentials
+	// for the target cluster.
+	InfrastructureConfig string
+	// Namespace is the namespace for custom resources of the target cluster in the control cluster.
+	// It must be unique across all target clusters.
+	Namespace string
+}
+
+// AzureDestructiveActionBudgetConfiguration defines the configuration of the budget for destructive actions of Azure remedies.
+type AzureDestructiveActionBudgetConfiguration struct {
+	// Window specifies the time window in which the max number of destructive actions is enforced.
+	Window metav1.Duration
+	// MaxPublicIPAddressDeletions specifies the max number of Azure public IP address deletions within the window.
+	// If nil, public IP address deletions are not limited.
+	MaxPublicIPAddressDeletions *int
+	// MaxVirtualMachineReapplies specifies the max number of Azure virtual machine reapplies within the window.
+	// If nil, virtual machine reapplies are not limited.
+	MaxVirtualMachineReapplies *int
+}
+
+// AzureRemedyActionsConfiguration defines the configuration for RemedyAction resources.
+type AzureRemedyActionsConfiguration struct {
+	// RetentionPeriod specifies the period after the completion of an action after which its RemedyAction is deleted.
+	// If zero, RemedyActions are deleted as soon as their actions are completed.
+	// RemedyActions of actions that have not been completed are never deleted.
+	RetentionPeriod metav1.Duration
+}
+
+// AzureRateLimiterConfiguration defines the configuration of the client-side rate limiter for Azure API requests.
+type AzureRateLimiterConfiguration struct {
+	// ReadQPS specifies the max rate of Azure read requests per second. If zero, read requests are not rate limited.
+	ReadQPS float64
+	// ReadBurst specifies the max burst of Azure read requests.
+	ReadBurst int
+	// WriteQPS specifies the max rate of Azure write requests per second. If zero, write requests are not rate limited.
+	WriteQPS float64
+	// WriteBurst specifies the max burst of Azure write requests.
+	WriteBurst int
 }
 
 // AzureOrphanedPublicIPRemedyConfiguration defines the configuration for the Azure orphaned public IP remedy.
@@ -48,13 +118,31 @@ type AzureOrphanedPublicIPRemedyConfiguration struct {
 	SyncPeriod metav1.Duration
 	// ServiceSyncPeriod determines the minimum frequency at which Service resources will be reconciled.
 	ServiceSyncPeriod metav1.Duration
+	// ServiceGarbageCollectionPeriod determines the frequency at which PublicIPAddress resources are checked
+	// for Service resources that no longer exist. If zero, garbage collection is disabled.
+	ServiceGarbageCollectionPeriod metav1.Duration
 	// DeletionGracePeriod specifies the period after which a public ip address will be
-	// deleted by the controller if it still exists.
+	// deleted by the controller if it still exists. If zero, it is deleted immediately.
 	DeletionGracePeriod metav1.Duration
 	// MaxGetAttempts specifies the max attempts to get an Azure public ip address.
 	MaxGetAttempts int
 	// MaxCleanAttempts specifies the max attempts to clean an Azure public ip address.
 	MaxCleanAttempts int
+	// DryRun specifies that Azure public ip addresses should not actually be cleaned, but only
+	// recorded as if they would have been cleaned.
+	DryRun bool
+	// GetRetryPolicy specifies how getting an Azure public ip address is retried.
+	GetRetryPolicy *RetryPolicyConfiguration
+	// CleanRetryPolicy specifies how cleaning an Azure public ip address is retried.
+	CleanRetryPolicy *RetryPolicyConfiguration
+	// AnomalyGuard specifies when cleaning Azure public ip addresses should be refused as anomalous.
+	AnomalyGuard *AnomalyGuardConfiguration
+	// RequireApproval specifies that orphaned Azure public ip addresses should only be cleaned after the deletion
+	// grace period if the PublicIPAddress is annotated with azure.remedy.gardener.cloud/approved=true.
+	RequireApproval bool
+	// MaintenanceWindow specifies when orphaned Azure public ip addresses may be cleaned.
+	// If not specified, they may be cleaned at any time.
+	MaintenanceWindow *MaintenanceWindowConfiguration
 }
 
 // AzureFailedVMRemedyConfiguration defines the configuration for the Azure failed VM remedy.
@@ -66,8 +154,96 @@ type AzureFailedVMRemedyConfiguration struct {
 	SyncPeriod metav1.Duration
 	// NodeSyncPeriod determines the minimum frequency at which Node resources will be reconciled.
 	NodeSyncPeriod metav1.Duration
+	// NodeGarbageCollectionPeriod determines the frequency at which VirtualMachine resources are checked
+	// for Node resources that no longer exist. If zero, garbage collection is disabled.
+	NodeGarbageCollectionPeriod metav1.Duration
 	// MaxGetAttempts specifies the max attempts to get an Azure VM.
 	MaxGetAttempts int
 	// MaxReapplyAttempts specifies the max attempts to reapply an Azure VM.
 	MaxReapplyAttempts int
+	// DryRun specifies that Azure VMs should not actually be reapplied, but only
+	// recorded as if they would have been reapplied.
+	DryRun bool
+	// GetRetryPolicy specifies how getting an Azure VM is retried.
+	GetRetryPolicy *RetryPolicyConfiguration
+	// ReapplyRetryPolicy specifies how reapplying an Azure VM is retried.
+	ReapplyRetryPolicy *RetryPolicyConfiguration
+	// RequireApproval specifies that failed Azure VMs should only be reapplied
+	// if the VirtualMachine is annotated with azure.remedy.gardener.cloud/approved=true.
+	RequireApproval bool
+	// MaintenanceWindow specifies when failed Azure VMs may be reapplied.
+	// If not specified, they may be reapplied at any time.
+	MaintenanceWindow *MaintenanceWindowConfiguration
+}
+
+// AnomalyGuardConfiguration defines when cleaning orphaned resources should be refused as anomalous,
+// e.g. because the target cluster appears to be empty or the wrong cluster.
+type AnomalyGuardConfiguration struct {
+	// Disabled specifies that cleaning is never refused as anomalous.
+	Disabled bool
+	// MaxOrphanRatio specifies the max ratio of orphaned to known resources. If the ratio is exceeded, or there are
+	// no known resources at all, cleaning is refused. If not specified, 1.0 is used.
+	MaxOrphanRatio *float64
+	// MinOrphans specifies the min number of orphaned resources for which the ratio and the known resources are checked,
+	// so that cleaning a few orphaned resources is never refused, e.g. when the last service of a cluster is deleted.
+	// If not specified, 3 is used.
+	MinOrphans *int
+}
+
+// MaintenanceWindowConfiguration defines when destructive actions of a remedy may be performed.
+type MaintenanceWindowConfiguration struct {
+	// TimeZone specifies the IANA time zone of the time ranges, e.g. Europe/Berlin. If not specified, UTC is used.
+	TimeZone string
+	// TimeRanges specifies the time ranges in which destructive actions may be performed.
+	TimeRanges []MaintenanceTimeRange
+}
+
+// MaintenanceTimeRange defines a daily time range on certain weekdays.
+type MaintenanceTimeRange struct {
+	// Weekdays specifies the weekdays on which the time range begins, e.g. Saturday. If not specified, it begins every day.
+	Weekdays []string
+	// Begin specifies the begin of the time range in the format HH:MM.
+	Begin string
+	// End specifies the end of the time range in the format HH:MM. If it's not after the begin,
+	// the time range ends on the next day.
+	End string
+}
+
+// NotificationsConfiguration defines the configuration for notifications about remedy outcomes.
+type NotificationsConfiguration struct {
+	// ClusterName specifies the name of the cluster that is included in notifications.
+	// If not specified, the namespace of the remedied resource is used.
+	ClusterName string
+	// Webhook specifies the configuration for sending notifications to an HTTP webhook.
+	Webhook *WebhookNotifierConfiguration
+}
+
+// WebhookNotifierConfiguration defines the configuration for sending notifications to an HTTP webhook.
+type WebhookNotifierConfiguration struct {
+	// URL specifies the URL to which notifications are posted as JSON.
+	URL string
+	// Timeout specifies the timeout of a single request. If not specified, 10s is used.
+	Timeout *metav1.Duration
+	// MaxAttempts specifies the max attempts to send a notification. If not specified, 3 is used.
+	MaxAttempts *int
+	// RetryInterval specifies the interval between attempts to send a notification. If not specified, 1s is used.
+	RetryInterval *metav1.Duration
+}
+
+// RetryPolicyConfiguration defines how a failed operation is retried.
+type RetryPolicyConfiguration struct {
+	// BaseDelay specifies the delay before the first retry. Each subsequent retry is delayed twice as long as the previous one.
+	// If not specified, the RequeueInterval of the remedy is used.
+	BaseDelay *metav1.Duration
+	// MaxDelay specifies the max delay between retries. If not specified, the delay is not capped.
+	MaxDelay *metav1.Duration
+	// Jitter specifies the max fraction of the delay that is randomly added to it, e.g. 0.1 for up to 10%.
+	// If not specified, no jitter is added.
+	Jitter *float64
+	// MaxAttempts specifies the max attempts of the operation.
+	// If not specified, the max attempts configured for the operation in the remedy are used.
+	MaxAttempts *int
+	// CoolDown specifies the period after the last failure of the operation after which its attempts are reset.
+	// If not specified, the attempts are never reset.
+	CoolDown *metav1.Duration
 }
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDefaults_ControllerConfiguration sets defaults for the controller configuration.
+func SetDefaults_ControllerConfiguration(obj *ControllerConfiguration) {
+	if obj.Azure == nil {
+		obj.Azure = &AzureConfiguration{}
+	}
+}
+
+// SetDefaults_AzureConfiguration sets defaults for the Azure remedy controller configuration.
+func SetDefaults_AzureConfiguration(obj *AzureConfiguration) {
+	if obj.OrphanedPublicIPRemedy == nil {
+		obj.OrphanedPublicIPRemedy = &AzureOrphanedPublicIPRemedyConfiguration{}
+	}
+	if obj.FailedVMRemedy == nil {
+		obj.FailedVMRemedy = &AzureFailedVMRemedyConfiguration{}
+	}
+	if obj.RemedyActions == nil {
+		obj.RemedyActions = &AzureRemedyActionsConfiguration{}
+	}
+}
+
+// SetDefaults_AzureOrphanedPublicIPRemedyConfiguration sets defaults for the Azure orphaned public IP remedy configuration.
+func SetDefaults_AzureOrphanedPublicIPRemedyConfiguration(obj *AzureOrphanedPublicIPRemedyConfiguration) {
+	if obj.RequeueInterval.Duration == 0 {
+		obj.RequeueInterval = metav1.Duration{Duration: 1 * time.Minute}
+	}
+	if obj.SyncPeriod.Duration == 0 {
+		obj.SyncPeriod = metav1.Duration{Duration: 10 * time.Hour}
+	}
+	if obj.ServiceSyncPeriod.Duration == 0 {
+		obj.ServiceSyncPeriod = metav1.Duration{Duration: 4 * time.Hour}
+	}
+	if obj.DeletionGracePeriod == nil {
+		obj.DeletionGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+	if obj.MaxGetAttempts == 0 {
+		obj.MaxGetAttempts = 5
+	}
+	if obj.MaxCleanAttempts == 0 {
+		obj.MaxCleanAttempts = 5
+	}
+}
+
+// SetDefaults_AzureFailedVMRemedyConfiguration sets defaults for the Azure failed VM remedy configuration.
+func SetDefaults_AzureFailedVMRemedyConfiguration(obj *AzureFailedVMRemedyConfiguration) {
+	if obj.RequeueInterval.Duration == 0 {
+		obj.RequeueInterval = metav1.Duration{Duration: 1 * time.Minute}
+	}
+	if obj.SyncPeriod.Duration == 0 {
+		obj.SyncPeriod = metav1.Duration{Duration: 2 * time.Hour}
+	}
+	if obj.NodeSyncPeriod.Duration == 0 {
+		obj.NodeSyncPeriod = metav1.Duration{Duration: 4 * time.Hour}
+	}
+	if obj.MaxGetAttempts == 0 {
+		obj.MaxGetAttempts = 5
+	}
+	if obj.MaxReapplyAttempts == 0 {
+		obj.MaxReapplyAttempts = 5
+	}
+}
+
+// SetDefaults_AzureRemedyActionsConfiguration sets defaults for the RemedyAction resources configuration.
+func SetDefaults_AzureRemedyActionsConfiguration(obj *AzureRemedyActionsConfiguration) {
+	if obj.RetentionPeriod == nil {
+		obj.RetentionPeriod = &metav1.Duration{Duration: 30 * 24 * time.Hour}
+	}
+}
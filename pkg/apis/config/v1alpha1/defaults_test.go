@@ -0,0 +1,93 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/gardener/remedy-controller/pkg/apis/config/v1alpha1"
+)
+
+var _ = Describe("Defaults", func() {
+	Describe("#SetObjectDefaults_ControllerConfiguration", func() {
+		It("should default all remedy configurations if not specified", func() {
+			obj := &ControllerConfiguration{}
+
+			SetObjectDefaults_ControllerConfiguration(obj)
+
+			Expect(obj.Azure).To(Equal(&AzureConfiguration{
+				OrphanedPublicIPRemedy: &AzureOrphanedPublicIPRemedyConfiguration{
+					RequeueInterval:     metav1.Duration{Duration: 1 * time.Minute},
+					SyncPeriod:          metav1.Duration{Duration: 10 * time.Hour},
+					ServiceSyncPeriod:   metav1.Duration{Duration: 4 * time.Hour},
+					DeletionGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+					MaxGetAttempts:      5,
+					MaxCleanAttempts:    5,
+				},
+				FailedVMRemedy: &AzureFailedVMRemedyConfiguration{
+					RequeueInterval:    metav1.Duration{Duration: 1 * time.Minute},
+					SyncPeriod:         metav1.Duration{Duration: 2 * time.Hour},
+					NodeSyncPeriod:     metav1.Duration{Duration: 4 * time.Hour},
+					MaxGetAttempts:     5,
+					MaxReapplyAttempts: 5,
+				},
+				RemedyActions: &AzureRemedyActionsConfiguration{
+					RetentionPeriod: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				},
+			}))
+		})
+
+		It("should not overwrite specified values", func() {
+			obj := &ControllerConfiguration{
+				Azure: &AzureConfiguration{
+					OrphanedPublicIPRemedy: &AzureOrphanedPublicIPRemedyConfiguration{
+						DeletionGracePeriod:            &metav1.Duration{Duration: 10 * time.Minute},
+						ServiceGarbageCollectionPeriod: &metav1.Duration{},
+						MaxCleanAttempts:               3,
+					},
+				},
+			}
+
+			SetObjectDefaults_ControllerConfiguration(obj)
+
+			Expect(obj.Azure.OrphanedPublicIPRemedy.DeletionGracePeriod).To(Equal(&metav1.Duration{Duration: 10 * time.Minute}))
+			Expect(obj.Azure.OrphanedPublicIPRemedy.ServiceGarbageCollectionPeriod).To(Equal(&metav1.Duration{}))
+			Expect(obj.Azure.OrphanedPublicIPRemedy.MaxCleanAttempts).To(Equal(3))
+			Expect(obj.Azure.OrphanedPublicIPRemedy.MaxGetAttempts).To(Equal(5))
+		})
+
+		It("should not overwrite periods explicitly set to zero", func() {
+			obj := &ControllerConfiguration{
+				Azure: &AzureConfiguration{
+					OrphanedPublicIPRemedy: &AzureOrphanedPublicIPRemedyConfiguration{
+						DeletionGracePeriod: &metav1.Duration{},
+					},
+					RemedyActions: &AzureRemedyActionsConfiguration{
+						RetentionPeriod: &metav1.Duration{},
+					},
+				},
+			}
+
+			SetObjectDefaults_ControllerConfiguration(obj)
+
+			Expect(obj.Azure.OrphanedPublicIPRemedy.DeletionGracePeriod).To(Equal(&metav1.Duration{}))
+			Expect(obj.Azure.RemedyActions.RetentionPeriod).To(Equal(&metav1.Duration{}))
+		})
+	})
+})
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -34,6 +35,15 @@ type ControllerConfiguration struct {
 	// Azure specifies the configuration for all Azure remedies.
 	// +optional
 	Azure *AzureConfiguration `json:"azure,omitempty"`
+
+	// DryRun specifies that all remedies should only record what they would have done,
+	// without issuing any write requests to the cloud provider.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Notifications specifies the configuration for notifications about remedy outcomes.
+	// +optional
+	Notifications *NotificationsConfiguration `json:"notifications,omitempty"`
 }
 
 // AzureConfiguration defines the configuration for the Azure remedy controller.
@@ -42,6 +52,82 @@ type AzureConfiguration struct {
 	OrphanedPublicIPRemedy *AzureOrphanedPublicIPRemedyConfiguration `json:"orphanedPublicIPRemedy,omitempty"`
 	// +optional
 	FailedVMRemedy *AzureFailedVMRemedyConfiguration `json:"failedVMRemedy,omitempty"`
+	// RateLimiter is the configuration of the client-side rate limiter for Azure API requests,
+	// shared by all Azure remedies.
+	// +optional
+	RateLimiter *AzureRateLimiterConfiguration `json:"rateLimiter,omitempty"`
+	// RemedyActions is the configuration for RemedyAction resources recording destructive actions of Azure remedies.
+	// +optional
+	RemedyActions *AzureRemedyActionsConfiguration `json:"remedyActions,omitempty"`
+	// DestructiveActionBudget is the configuration of the budget for destructive actions of Azure remedies,
+	// shared by all Azure remedies.
+	// +optional
+	DestructiveActionBudget *AzureDestructiveActionBudgetConfiguration `json:"destructiveActionBudget,omitempty"`
+	// Targets specifies multiple target clusters to be managed by a single controller process.
+	// If not specified, a single target cluster is managed as specified via command line options.
+	// +optional
+	Targets []AzureTargetConfiguration `json:"targets,omitempty"`
+}
+
+// AzureTargetConfiguration defines a target cluster managed by the Azure remedy controller.
+type AzureTargetConfiguration struct {
+	// Name is the unique name of the target cluster. It is appended to the names of the controllers for this target cluster.
+	Name string `json:"name"`
+	// Kubeconfig is the path to a kubeconfig file for the target cluster.
+	// Either Kubeconfig or KubeconfigSecretRef must be specified.
+	// +optional
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// KubeconfigSecretRef is a reference to a secret in the control cluster that contains a kubeconfig
+	// for the target cluster in the data key "kubeconfig".
+	// Either Kubeconfig or KubeconfigSecretRef must be specified.
+	// +optional
+	KubeconfigSecretRef *corev1.SecretReference `json:"kubeconfigSecretRef,omitempty"`
+	// InfrastructureConfig is the path to the infrastructure configuration file containing the Azure credentials
+	// for the target cluster.
+	InfrastructureConfig string `json:"infrastructureConfig"`
+	// Namespace is the namespace for custom resources of the target cluster in the control cluster.
+	// It must be unique across all target clusters.
+	Namespace string `json:"namespace"`
+}
+
+// AzureDestructiveActionBudgetConfiguration defines the configuration of the budget for destructive actions of Azure remedies.
+type AzureDestructiveActionBudgetConfiguration struct {
+	// Window specifies the time window in which the max number of destructive actions is enforced.
+	Window metav1.Duration `json:"window"`
+	// MaxPublicIPAddressDeletions specifies the max number of Azure public IP address deletions within the window.
+	// If nil, public IP address deletions are not limited.
+	// +optional
+	MaxPublicIPAddressDeletions *int `json:"maxPublicIPAddressDeletions,omitempty"`
+	// MaxVirtualMachineReapplies specifies the max number of Azure virtual machine reapplies within the window.
+	// If nil, virtual machine reapplies are not limited.
+	// +optional
+	MaxVirtualMachineReapplies *int `json:"maxVirtualMachineReapplies,omitempty"`
+}
+
+// AzureRemedyActionsConfiguration defines the configuration for RemedyAction resources.
+type AzureRemedyActionsConfiguration struct {
+	// RetentionPeriod specifies the period after the completion of an action after which its RemedyAction is deleted.
+	// If zero, RemedyActions are deleted as soon as their actions are completed.
+	// RemedyActions of actions that have not been completed are never deleted.
+	// If not specified, 720h (30 days) is used.
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+}
+
+// AzureRateLimiterConfiguration defines the configuration of the client-side rate limiter for Azure API requests.
+type AzureRateLimiterConfiguration struct {
+	// ReadQPS specifies the max rate of Azure read requests per second. If zero, read requests are not rate limited.
+	// +optional
+	ReadQPS float64 `json:"readQPS,omitempty"`
+	// ReadBurst specifies the max burst of Azure read requests.
+	// +optional
+	ReadBurst int `json:"readBurst,omitempty"`
+	// WriteQPS specifies the max rate of Azure write requests per second. If zero, write requests are not rate limited.
+	// +optional
+	WriteQPS float64 `json:"writeQPS,omitempty"`
+	// WriteBurst specifies the max burst of Azure write requests.
+	// +optional
+	WriteBurst int `json:"writeBurst,omitempty"`
 }
 
 // AzureOrphanedPublicIPRemedyConfiguration defines the configuration for the Azure orphaned public IP remedy.
@@ -49,23 +135,55 @@ type AzureOrphanedPublicIPRemedyConfiguration struct {
 	// RequeueInterval specifies the time after which PublicIPAddress reconciliation requests will be
 	// requeued in case of an error or a transient state.
 	// +optional
+	// If not specified, 1m is used.
 	RequeueInterval metav1.Duration `json:"requeueInterval,omitempty"`
 	// SyncPeriod determines the minimum frequency at which PublicIPAddress resources will be reconciled.
 	// +optional
+	// If not specified, 10h is used.
 	SyncPeriod metav1.Duration `json:"syncPeriod,omitempty"`
 	// ServiceSyncPeriod determines the minimum frequency at which Service resources will be reconciled.
 	// +optional
+	// If not specified, 4h is used.
 	ServiceSyncPeriod metav1.Duration `json:"serviceSyncPeriod,omitempty"`
+	// ServiceGarbageCollectionPeriod determines the frequency at which PublicIPAddress resources are checked
+	// for Service resources that no longer exist. If zero or not specified,
+	// garbage collection is disabled.
+	// +optional
+	ServiceGarbageCollectionPeriod *metav1.Duration `json:"serviceGarbageCollectionPeriod,omitempty"`
 	// DeletionGracePeriod specifies the period after which a public ip address will be
-	// deleted by the controller if it still exists.
+	// deleted by the controller if it still exists. If zero, it is deleted immediately.
+	// If not specified, 5m is used.
 	// +optional
-	DeletionGracePeriod metav1.Duration `json:"deletionGracePeriod,omitempty"`
+	DeletionGracePeriod *metav1.Duration `json:"deletionGracePeriod,omitempty"`
 	// MaxGetAttempts specifies the max attempts to get an Azure public ip address.
 	// +optional
+	// If not specified, 5 is used.
 	MaxGetAttempts int `json:"maxGetAttempts,omitempty"`
 	// MaxCleanAttempts specifies the max attempts to clean an Azure public ip address.
 	// +optional
+	// If not specified, 5 is used.
 	MaxCleanAttempts int `json:"maxCleanAttempts,omitempty"`
+	// DryRun specifies that Azure public ip addresses should not actually be cleaned, but only
+	// recorded as if they would have been cleaned.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// GetRetryPolicy specifies how getting an Azure public ip address is retried.
+	// +optional
+	GetRetryPolicy *RetryPolicyConfiguration `json:"getRetryPolicy,omitempty"`
+	// CleanRetryPolicy specifies how cleaning an Azure public ip address is retried.
+	// +optional
+	CleanRetryPolicy *RetryPolicyConfiguration `json:"cleanRetryPolicy,omitempty"`
+	// AnomalyGuard specifies when cleaning Azure public ip addresses should be refused as anomalous.
+	// +optional
+	AnomalyGuard *AnomalyGuardConfiguration `json:"anomalyGuard,omitempty"`
+	// RequireApproval specifies that orphaned Azure public ip addresses should only be cleaned after the deletion
+	// grace period if the PublicIPAddress is annotated with azure.remedy.gardener.cloud/approved=true.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+	// MaintenanceWindow specifies when orphaned Azure public ip addresses may be cleaned.
+	// If not specified, they may be cleaned at any time.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowConfiguration `json:"maintenanceWindow,omitempty"`
 }
 
 // AzureFailedVMRemedyConfiguration defines the configuration for the Azure failed VM remedy.
@@ -73,17 +191,132 @@ type AzureFailedVMRemedyConfiguration struct {
 	// RequeueInterval specifies the time after which VirtualMachine reconciliation requests will be
 	// requeued in case of an error or a transient state.
 	// +optional
+	// If not specified, 1m is used.
 	RequeueInterval metav1.Duration `json:"requeueInterval,omitempty"`
 	// SyncPeriod determines the minimum frequency at which VirtualMachine resources will be reconciled.
 	// +optional
+	// If not specified, 2h is used.
 	SyncPeriod metav1.Duration `json:"syncPeriod,omitempty"`
 	// NodeSyncPeriod determines the minimum frequency at which Node resources will be reconciled.
 	// +optional
+	// If not specified, 4h is used.
 	NodeSyncPeriod metav1.Duration `json:"nodeSyncPeriod,omitempty"`
+	// NodeGarbageCollectionPeriod determines the frequency at which VirtualMachine resources are checked
+	// for Node resources that no longer exist. If zero or not specified,
+	// garbage collection is disabled.
+	// +optional
+	NodeGarbageCollectionPeriod *metav1.Duration `json:"nodeGarbageCollectionPeriod,omitempty"`
 	// MaxGetAttempts specifies the max attempts to get an Azure VM.
 	// +optional
+	// If not specified, 5 is used.
 	MaxGetAttempts int `json:"maxGetAttempts,omitempty"`
 	// MaxReapplyAttempts specifies the max attempts to reapply an Azure VM.
 	// +optional
+	// If not specified, 5 is used.
 	MaxReapplyAttempts int `json:"maxReapplyAttempts,omitempty"`
+	// DryRun specifies that Azure VMs should not actually be reapplied, but only
+	// recorded as if they would have been reapplied.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// GetRetryPolicy specifies how getting an Azure VM is retried.
+	// +optional
+	GetRetryPolicy *RetryPolicyConfiguration `json:"getRetryPolicy,omitempty"`
+	// ReapplyRetryPolicy specifies how reapplying an Azure VM is retried.
+	// +optional
+	ReapplyRetryPolicy *RetryPolicyConfiguration `json:"reapplyRetryPolicy,omitempty"`
+	// RequireApproval specifies that failed Azure VMs should only be reapplied
+	// if the VirtualMachine is annotated with azure.remedy.gardener.cloud/approved=true.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+	// MaintenanceWindow specifies when failed Azure VMs may be reapplied.
+	// If not specified, they may be reapplied at any time.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowConfiguration `json:"maintenanceWindow,omitempty"`
+}
+
+// AnomalyGuardConfiguration defines when cleaning orphaned resources should be refused as anomalous,
+// e.g. because the target cluster appears to be empty or the wrong cluster.
+type AnomalyGuardConfiguration struct {
+	// Disabled specifies that cleaning is never refused as anomalous.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// MaxOrphanRatio specifies the max ratio of orphaned to known resources. If the ratio is exceeded, or there are
+	// no known resources at all, cleaning is refused. If not specified, 1.0 is used.
+	// +optional
+	MaxOrphanRatio *float64 `json:"maxOrphanRatio,omitempty"`
+	// MinOrphans specifies the min number of orphaned resources for which the ratio and the known resources are checked,
+	// so that cleaning a few orphaned resources is never refused, e.g. when the last service of a cluster is deleted.
+	// If not specified, 3 is used.
+	// +optional
+	MinOrphans *int `json:"minOrphans,omitempty"`
+}
+
+// MaintenanceWindowConfiguration defines when destructive actions of a remedy may be performed.
+type MaintenanceWindowConfiguration struct {
+	// TimeZone specifies the IANA time zone of the time ranges, e.g. Europe/Berlin. If not specified, UTC is used.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// TimeRanges specifies the time ranges in which destructive actions may be performed.
+	TimeRanges []MaintenanceTimeRange `json:"timeRanges"`
+}
+
+// MaintenanceTimeRange defines a daily time range on certain weekdays.
+type MaintenanceTimeRange struct {
+	// Weekdays specifies the weekdays on which the time range begins, e.g. Saturday. If not specified, it begins every day.
+	// +optional
+	Weekdays []string `json:"weekdays,omitempty"`
+	// Begin specifies the begin of the time range in the format HH:MM.
+	Begin string `json:"begin"`
+	// End specifies the end of the time range in the format HH:MM. If it's not after the begin,
+	// the time range ends on the next day.
+	End string `json:"end"`
+}
+
+// NotificationsConfiguration defines the configuration for notifications about remedy outcomes.
+type NotificationsConfiguration struct {
+	// ClusterName specifies the name of the cluster that is included in notifications.
+	// If not specified, the namespace of the remedied resource is used.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// Webhook specifies the configuration for sending notifications to an HTTP webhook.
+	// +optional
+	Webhook *WebhookNotifierConfiguration `json:"webhook,omitempty"`
+}
+
+// WebhookNotifierConfiguration defines the configuration for sending notifications to an HTTP webhook.
+type WebhookNotifierConfiguration struct {
+	// URL specifies the URL to which notifications are posted as JSON.
+	URL string `json:"url"`
+	// Timeout specifies the timeout of a single request. If not specified, 10s is used.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxAttempts specifies the max attempts to send a notification. If not specified, 3 is used.
+	// +optional
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// RetryInterval specifies the interval between attempts to send a notification. If not specified, 1s is used.
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+}
+
+// RetryPolicyConfiguration defines how a failed operation is retried.
+type RetryPolicyConfiguration struct {
+	// BaseDelay specifies the delay before the first retry. Each subsequent retry is delayed twice as long as the previous one.
+	// If not specified, the RequeueInterval of the remedy is used.
+	// +optional
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay specifies the max delay between retries. If not specified, the delay is not capped.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+	// Jitter specifies the max fraction of the delay that is randomly added to it, e.g. 0.1 for up to 10%.
+	// If not specified, no jitter is added.
+	// +optional
+	Jitter *float64 `json:"jitter,omitempty"`
+	// MaxAttempts specifies the max attempts of the operation.
+	// If not specified, the max attempts configured for the operation in the remedy are used.
+	// +optional
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// CoolDown specifies the period after the last failure of the operation after which its attempts are reset.
+	// If not specified, the attempts are never reset.
+	// +optional
+	CoolDown *metav1.Duration `json:"coolDown,omitempty"`
 }
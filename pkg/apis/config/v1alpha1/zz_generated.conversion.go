@@ -13,6 +13,8 @@ import (
 	unsafe "unsafe"
 
 	config "github.com/gardener/remedy-controller/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -25,6 +27,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AnomalyGuardConfiguration)(nil), (*config.AnomalyGuardConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AnomalyGuardConfiguration_To_config_AnomalyGuardConfiguration(a.(*AnomalyGuardConfiguration), b.(*config.AnomalyGuardConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AnomalyGuardConfiguration)(nil), (*AnomalyGuardConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AnomalyGuardConfiguration_To_v1alpha1_AnomalyGuardConfiguration(a.(*config.AnomalyGuardConfiguration), b.(*AnomalyGuardConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureConfiguration)(nil), (*config.AzureConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_AzureConfiguration_To_config_AzureConfiguration(a.(*AzureConfiguration), b.(*config.AzureConfiguration), scope)
 	}); err != nil {
@@ -35,6 +47,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureDestructiveActionBudgetConfiguration)(nil), (*config.AzureDestructiveActionBudgetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AzureDestructiveActionBudgetConfiguration_To_config_AzureDestructiveActionBudgetConfiguration(a.(*AzureDestructiveActionBudgetConfiguration), b.(*config.AzureDestructiveActionBudgetConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AzureDestructiveActionBudgetConfiguration)(nil), (*AzureDestructiveActionBudgetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AzureDestructiveActionBudgetConfiguration_To_v1alpha1_AzureDestructiveActionBudgetConfiguration(a.(*config.AzureDestructiveActionBudgetConfiguration), b.(*AzureDestructiveActionBudgetConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureFailedVMRemedyConfiguration)(nil), (*config.AzureFailedVMRemedyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_AzureFailedVMRemedyConfiguration_To_config_AzureFailedVMRemedyConfiguration(a.(*AzureFailedVMRemedyConfiguration), b.(*config.AzureFailedVMRemedyConfiguration), scope)
 	}); err != nil {
@@ -55,6 +77,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureRateLimiterConfiguration)(nil), (*config.AzureRateLimiterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AzureRateLimiterConfiguration_To_config_AzureRateLimiterConfiguration(a.(*AzureRateLimiterConfiguration), b.(*config.AzureRateLimiterConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AzureRateLimiterConfiguration)(nil), (*AzureRateLimiterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AzureRateLimiterConfiguration_To_v1alpha1_AzureRateLimiterConfiguration(a.(*config.AzureRateLimiterConfiguration), b.(*AzureRateLimiterConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AzureRemedyActionsConfiguration)(nil), (*config.AzureRemedyActionsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration(a.(*AzureRemedyActionsConfiguration), b.(*config.AzureRemedyActionsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AzureRemedyActionsConfiguration)(nil), (*AzureRemedyActionsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration(a.(*config.AzureRemedyActionsConfiguration), b.(*AzureRemedyActionsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AzureTargetConfiguration)(nil), (*config.AzureTargetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AzureTargetConfiguration_To_config_AzureTargetConfiguration(a.(*AzureTargetConfiguration), b.(*config.AzureTargetConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AzureTargetConfiguration)(nil), (*AzureTargetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AzureTargetConfiguration_To_v1alpha1_AzureTargetConfiguration(a.(*config.AzureTargetConfiguration), b.(*AzureTargetConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -65,12 +117,114 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceTimeRange)(nil), (*config.MaintenanceTimeRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MaintenanceTimeRange_To_config_MaintenanceTimeRange(a.(*MaintenanceTimeRange), b.(*config.MaintenanceTimeRange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MaintenanceTimeRange)(nil), (*MaintenanceTimeRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MaintenanceTimeRange_To_v1alpha1_MaintenanceTimeRange(a.(*config.MaintenanceTimeRange), b.(*MaintenanceTimeRange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindowConfiguration)(nil), (*config.MaintenanceWindowConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MaintenanceWindowConfiguration_To_config_MaintenanceWindowConfiguration(a.(*MaintenanceWindowConfiguration), b.(*config.MaintenanceWindowConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MaintenanceWindowConfiguration)(nil), (*MaintenanceWindowConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MaintenanceWindowConfiguration_To_v1alpha1_MaintenanceWindowConfiguration(a.(*config.MaintenanceWindowConfiguration), b.(*MaintenanceWindowConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NotificationsConfiguration)(nil), (*config.NotificationsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NotificationsConfiguration_To_config_NotificationsConfiguration(a.(*NotificationsConfiguration), b.(*config.NotificationsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NotificationsConfiguration)(nil), (*NotificationsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NotificationsConfiguration_To_v1alpha1_NotificationsConfiguration(a.(*config.NotificationsConfiguration), b.(*NotificationsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RetryPolicyConfiguration)(nil), (*config.RetryPolicyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RetryPolicyConfiguration_To_config_RetryPolicyConfiguration(a.(*RetryPolicyConfiguration), b.(*config.RetryPolicyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RetryPolicyConfiguration)(nil), (*RetryPolicyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RetryPolicyConfiguration_To_v1alpha1_RetryPolicyConfiguration(a.(*config.RetryPolicyConfiguration), b.(*RetryPolicyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WebhookNotifierConfiguration)(nil), (*config.WebhookNotifierConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WebhookNotifierConfiguration_To_config_WebhookNotifierConfiguration(a.(*WebhookNotifierConfiguration), b.(*config.WebhookNotifierConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.WebhookNotifierConfiguration)(nil), (*WebhookNotifierConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_WebhookNotifierConfiguration_To_v1alpha1_WebhookNotifierConfiguration(a.(*config.WebhookNotifierConfiguration), b.(*WebhookNotifierConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AnomalyGuardConfiguration_To_config_AnomalyGuardConfiguration(in *AnomalyGuardConfiguration, out *config.AnomalyGuardConfiguration, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.MaxOrphanRatio = (*float64)(unsafe.Pointer(in.MaxOrphanRatio))
+	out.MinOrphans = (*int)(unsafe.Pointer(in.MinOrphans))
 	return nil
 }
 
+// Convert_v1alpha1_AnomalyGuardConfiguration_To_config_AnomalyGuardConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AnomalyGuardConfiguration_To_config_AnomalyGuardConfiguration(in *AnomalyGuardConfiguration, out *config.AnomalyGuardConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AnomalyGuardConfiguration_To_config_AnomalyGuardConfiguration(in, out, s)
+}
+
+func autoConvert_config_AnomalyGuardConfiguration_To_v1alpha1_AnomalyGuardConfiguration(in *config.AnomalyGuardConfiguration, out *AnomalyGuardConfiguration, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.MaxOrphanRatio = (*float64)(unsafe.Pointer(in.MaxOrphanRatio))
+	out.MinOrphans = (*int)(unsafe.Pointer(in.MinOrphans))
+	return nil
+}
+
+// Convert_config_AnomalyGuardConfiguration_To_v1alpha1_AnomalyGuardConfiguration is an autogenerated conversion function.
+func Convert_config_AnomalyGuardConfiguration_To_v1alpha1_AnomalyGuardConfiguration(in *config.AnomalyGuardConfiguration, out *AnomalyGuardConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AnomalyGuardConfiguration_To_v1alpha1_AnomalyGuardConfiguration(in, out, s)
+}
+
 func autoConvert_v1alpha1_AzureConfiguration_To_config_AzureConfiguration(in *AzureConfiguration, out *config.AzureConfiguration, s conversion.Scope) error {
-	out.OrphanedPublicIPRemedy = (*config.AzureOrphanedPublicIPRemedyConfiguration)(unsafe.Pointer(in.OrphanedPublicIPRemedy))
-	out.FailedVMRemedy = (*config.AzureFailedVMRemedyConfiguration)(unsafe.Pointer(in.FailedVMRemedy))
+	if in.OrphanedPublicIPRemedy != nil {
+		in, out := &in.OrphanedPublicIPRemedy, &out.OrphanedPublicIPRemedy
+		*out = new(config.AzureOrphanedPublicIPRemedyConfiguration)
+		if err := Convert_v1alpha1_AzureOrphanedPublicIPRemedyConfiguration_To_config_AzureOrphanedPublicIPRemedyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OrphanedPublicIPRemedy = nil
+	}
+	if in.FailedVMRemedy != nil {
+		in, out := &in.FailedVMRemedy, &out.FailedVMRemedy
+		*out = new(config.AzureFailedVMRemedyConfiguration)
+		if err := Convert_v1alpha1_AzureFailedVMRemedyConfiguration_To_config_AzureFailedVMRemedyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FailedVMRemedy = nil
+	}
+	out.RateLimiter = (*config.AzureRateLimiterConfiguration)(unsafe.Pointer(in.RateLimiter))
+	if in.RemedyActions != nil {
+		in, out := &in.RemedyActions, &out.RemedyActions
+		*out = new(config.AzureRemedyActionsConfiguration)
+		if err := Convert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemedyActions = nil
+	}
+	out.DestructiveActionBudget = (*config.AzureDestructiveActionBudgetConfiguration)(unsafe.Pointer(in.DestructiveActionBudget))
+	out.Targets = *(*[]config.AzureTargetConfiguration)(unsafe.Pointer(&in.Targets))
 	return nil
 }
 
@@ -80,8 +234,36 @@ func Convert_v1alpha1_AzureConfiguration_To_config_AzureConfiguration(in *AzureC
 }
 
 func autoConvert_config_AzureConfiguration_To_v1alpha1_AzureConfiguration(in *config.AzureConfiguration, out *AzureConfiguration, s conversion.Scope) error {
-	out.OrphanedPublicIPRemedy = (*AzureOrphanedPublicIPRemedyConfiguration)(unsafe.Pointer(in.OrphanedPublicIPRemedy))
-	out.FailedVMRemedy = (*AzureFailedVMRemedyConfiguration)(unsafe.Pointer(in.FailedVMRemedy))
+	if in.OrphanedPublicIPRemedy != nil {
+		in, out := &in.OrphanedPublicIPRemedy, &out.OrphanedPublicIPRemedy
+		*out = new(AzureOrphanedPublicIPRemedyConfiguration)
+		if err := Convert_config_AzureOrphanedPublicIPRemedyConfiguration_To_v1alpha1_AzureOrphanedPublicIPRemedyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OrphanedPublicIPRemedy = nil
+	}
+	if in.FailedVMRemedy != nil {
+		in, out := &in.FailedVMRemedy, &out.FailedVMRemedy
+		*out = new(AzureFailedVMRemedyConfiguration)
+		if err := Convert_config_AzureFailedVMRemedyConfiguration_To_v1alpha1_AzureFailedVMRemedyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FailedVMRemedy = nil
+	}
+	out.RateLimiter = (*AzureRateLimiterConfiguration)(unsafe.Pointer(in.RateLimiter))
+	if in.RemedyActions != nil {
+		in, out := &in.RemedyActions, &out.RemedyActions
+		*out = new(AzureRemedyActionsConfiguration)
+		if err := Convert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemedyActions = nil
+	}
+	out.DestructiveActionBudget = (*AzureDestructiveActionBudgetConfiguration)(unsafe.Pointer(in.DestructiveActionBudget))
+	out.Targets = *(*[]AzureTargetConfiguration)(unsafe.Pointer(&in.Targets))
 	return nil
 }
 
@@ -90,12 +272,44 @@ func Convert_config_AzureConfiguration_To_v1alpha1_AzureConfiguration(in *config
 	return autoConvert_config_AzureConfiguration_To_v1alpha1_AzureConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_AzureDestructiveActionBudgetConfiguration_To_config_AzureDestructiveActionBudgetConfiguration(in *AzureDestructiveActionBudgetConfiguration, out *config.AzureDestructiveActionBudgetConfiguration, s conversion.Scope) error {
+	out.Window = in.Window
+	out.MaxPublicIPAddressDeletions = (*int)(unsafe.Pointer(in.MaxPublicIPAddressDeletions))
+	out.MaxVirtualMachineReapplies = (*int)(unsafe.Pointer(in.MaxVirtualMachineReapplies))
+	return nil
+}
+
+// Convert_v1alpha1_AzureDestructiveActionBudgetConfiguration_To_config_AzureDestructiveActionBudgetConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AzureDestructiveActionBudgetConfiguration_To_config_AzureDestructiveActionBudgetConfiguration(in *AzureDestructiveActionBudgetConfiguration, out *config.AzureDestructiveActionBudgetConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AzureDestructiveActionBudgetConfiguration_To_config_AzureDestructiveActionBudgetConfiguration(in, out, s)
+}
+
+func autoConvert_config_AzureDestructiveActionBudgetConfiguration_To_v1alpha1_AzureDestructiveActionBudgetConfiguration(in *config.AzureDestructiveActionBudgetConfiguration, out *AzureDestructiveActionBudgetConfiguration, s conversion.Scope) error {
+	out.Window = in.Window
+	out.MaxPublicIPAddressDeletions = (*int)(unsafe.Pointer(in.MaxPublicIPAddressDeletions))
+	out.MaxVirtualMachineReapplies = (*int)(unsafe.Pointer(in.MaxVirtualMachineReapplies))
+	return nil
+}
+
+// Convert_config_AzureDestructiveActionBudgetConfiguration_To_v1alpha1_AzureDestructiveActionBudgetConfiguration is an autogenerated conversion function.
+func Convert_config_AzureDestructiveActionBudgetConfiguration_To_v1alpha1_AzureDestructiveActionBudgetConfiguration(in *config.AzureDestructiveActionBudgetConfiguration, out *AzureDestructiveActionBudgetConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AzureDestructiveActionBudgetConfiguration_To_v1alpha1_AzureDestructiveActionBudgetConfiguration(in, out, s)
+}
+
 func autoConvert_v1alpha1_AzureFailedVMRemedyConfiguration_To_config_AzureFailedVMRemedyConfiguration(in *AzureFailedVMRemedyConfiguration, out *config.AzureFailedVMRemedyConfiguration, s conversion.Scope) error {
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.NodeSyncPeriod = in.NodeSyncPeriod
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.NodeGarbageCollectionPeriod, &out.NodeGarbageCollectionPeriod, s); err != nil {
+		return err
+	}
 	out.MaxGetAttempts = in.MaxGetAttempts
 	out.MaxReapplyAttempts = in.MaxReapplyAttempts
+	out.DryRun = in.DryRun
+	out.GetRetryPolicy = (*config.RetryPolicyConfiguration)(unsafe.Pointer(in.GetRetryPolicy))
+	out.ReapplyRetryPolicy = (*config.RetryPolicyConfiguration)(unsafe.Pointer(in.ReapplyRetryPolicy))
+	out.RequireApproval = in.RequireApproval
+	out.MaintenanceWindow = (*config.MaintenanceWindowConfiguration)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
@@ -108,8 +322,16 @@ func autoConvert_config_AzureFailedVMRemedyConfiguration_To_v1alpha1_AzureFailed
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.NodeSyncPeriod = in.NodeSyncPeriod
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.NodeGarbageCollectionPeriod, &out.NodeGarbageCollectionPeriod, s); err != nil {
+		return err
+	}
 	out.MaxGetAttempts = in.MaxGetAttempts
 	out.MaxReapplyAttempts = in.MaxReapplyAttempts
+	out.DryRun = in.DryRun
+	out.GetRetryPolicy = (*RetryPolicyConfiguration)(unsafe.Pointer(in.GetRetryPolicy))
+	out.ReapplyRetryPolicy = (*RetryPolicyConfiguration)(unsafe.Pointer(in.ReapplyRetryPolicy))
+	out.RequireApproval = in.RequireApproval
+	out.MaintenanceWindow = (*MaintenanceWindowConfiguration)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
@@ -122,9 +344,20 @@ func autoConvert_v1alpha1_AzureOrphanedPublicIPRemedyConfiguration_To_config_Azu
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.ServiceSyncPeriod = in.ServiceSyncPeriod
-	out.DeletionGracePeriod = in.DeletionGracePeriod
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ServiceGarbageCollectionPeriod, &out.ServiceGarbageCollectionPeriod, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.DeletionGracePeriod, &out.DeletionGracePeriod, s); err != nil {
+		return err
+	}
 	out.MaxGetAttempts = in.MaxGetAttempts
 	out.MaxCleanAttempts = in.MaxCleanAttempts
+	out.DryRun = in.DryRun
+	out.GetRetryPolicy = (*config.RetryPolicyConfiguration)(unsafe.Pointer(in.GetRetryPolicy))
+	out.CleanRetryPolicy = (*config.RetryPolicyConfiguration)(unsafe.Pointer(in.CleanRetryPolicy))
+	out.AnomalyGuard = (*config.AnomalyGuardConfiguration)(unsafe.Pointer(in.AnomalyGuard))
+	out.RequireApproval = in.RequireApproval
+	out.MaintenanceWindow = (*config.MaintenanceWindowConfiguration)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
@@ -137,9 +370,20 @@ func autoConvert_config_AzureOrphanedPublicIPRemedyConfiguration_To_v1alpha1_Azu
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.ServiceSyncPeriod = in.ServiceSyncPeriod
-	out.DeletionGracePeriod = in.DeletionGracePeriod
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ServiceGarbageCollectionPeriod, &out.ServiceGarbageCollectionPeriod, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.DeletionGracePeriod, &out.DeletionGracePeriod, s); err != nil {
+		return err
+	}
 	out.MaxGetAttempts = in.MaxGetAttempts
 	out.MaxCleanAttempts = in.MaxCleanAttempts
+	out.DryRun = in.DryRun
+	out.GetRetryPolicy = (*RetryPolicyConfiguration)(unsafe.Pointer(in.GetRetryPolicy))
+	out.CleanRetryPolicy = (*RetryPolicyConfiguration)(unsafe.Pointer(in.CleanRetryPolicy))
+	out.AnomalyGuard = (*AnomalyGuardConfiguration)(unsafe.Pointer(in.AnomalyGuard))
+	out.RequireApproval = in.RequireApproval
+	out.MaintenanceWindow = (*MaintenanceWindowConfiguration)(unsafe.Pointer(in.MaintenanceWindow))
 	return nil
 }
 
@@ -148,9 +392,97 @@ func Convert_config_AzureOrphanedPublicIPRemedyConfiguration_To_v1alpha1_AzureOr
 	return autoConvert_config_AzureOrphanedPublicIPRemedyConfiguration_To_v1alpha1_AzureOrphanedPublicIPRemedyConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_AzureRateLimiterConfiguration_To_config_AzureRateLimiterConfiguration(in *AzureRateLimiterConfiguration, out *config.AzureRateLimiterConfiguration, s conversion.Scope) error {
+	out.ReadQPS = in.ReadQPS
+	out.ReadBurst = in.ReadBurst
+	out.WriteQPS = in.WriteQPS
+	out.WriteBurst = in.WriteBurst
+	return nil
+}
+
+// Convert_v1alpha1_AzureRateLimiterConfiguration_To_config_AzureRateLimiterConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AzureRateLimiterConfiguration_To_config_AzureRateLimiterConfiguration(in *AzureRateLimiterConfiguration, out *config.AzureRateLimiterConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AzureRateLimiterConfiguration_To_config_AzureRateLimiterConfiguration(in, out, s)
+}
+
+func autoConvert_config_AzureRateLimiterConfiguration_To_v1alpha1_AzureRateLimiterConfiguration(in *config.AzureRateLimiterConfiguration, out *AzureRateLimiterConfiguration, s conversion.Scope) error {
+	out.ReadQPS = in.ReadQPS
+	out.ReadBurst = in.ReadBurst
+	out.WriteQPS = in.WriteQPS
+	out.WriteBurst = in.WriteBurst
+	return nil
+}
+
+// Convert_config_AzureRateLimiterConfiguration_To_v1alpha1_AzureRateLimiterConfiguration is an autogenerated conversion function.
+func Convert_config_AzureRateLimiterConfiguration_To_v1alpha1_AzureRateLimiterConfiguration(in *config.AzureRateLimiterConfiguration, out *AzureRateLimiterConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AzureRateLimiterConfiguration_To_v1alpha1_AzureRateLimiterConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration(in *AzureRemedyActionsConfiguration, out *config.AzureRemedyActionsConfiguration, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.RetentionPeriod, &out.RetentionPeriod, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration(in *AzureRemedyActionsConfiguration, out *config.AzureRemedyActionsConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AzureRemedyActionsConfiguration_To_config_AzureRemedyActionsConfiguration(in, out, s)
+}
+
+func autoConvert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration(in *config.AzureRemedyActionsConfiguration, out *AzureRemedyActionsConfiguration, s conversion.Scope) error {
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.RetentionPeriod, &out.RetentionPeriod, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration is an autogenerated conversion function.
+func Convert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration(in *config.AzureRemedyActionsConfiguration, out *AzureRemedyActionsConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AzureRemedyActionsConfiguration_To_v1alpha1_AzureRemedyActionsConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_AzureTargetConfiguration_To_config_AzureTargetConfiguration(in *AzureTargetConfiguration, out *config.AzureTargetConfiguration, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kubeconfig = in.Kubeconfig
+	out.KubeconfigSecretRef = (*corev1.SecretReference)(unsafe.Pointer(in.KubeconfigSecretRef))
+	out.InfrastructureConfig = in.InfrastructureConfig
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha1_AzureTargetConfiguration_To_config_AzureTargetConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_AzureTargetConfiguration_To_config_AzureTargetConfiguration(in *AzureTargetConfiguration, out *config.AzureTargetConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AzureTargetConfiguration_To_config_AzureTargetConfiguration(in, out, s)
+}
+
+func autoConvert_config_AzureTargetConfiguration_To_v1alpha1_AzureTargetConfiguration(in *config.AzureTargetConfiguration, out *AzureTargetConfiguration, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kubeconfig = in.Kubeconfig
+	out.KubeconfigSecretRef = (*corev1.SecretReference)(unsafe.Pointer(in.KubeconfigSecretRef))
+	out.InfrastructureConfig = in.InfrastructureConfig
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_config_AzureTargetConfiguration_To_v1alpha1_AzureTargetConfiguration is an autogenerated conversion function.
+func Convert_config_AzureTargetConfiguration_To_v1alpha1_AzureTargetConfiguration(in *config.AzureTargetConfiguration, out *AzureTargetConfiguration, s conversion.Scope) error {
+	return autoConvert_config_AzureTargetConfiguration_To_v1alpha1_AzureTargetConfiguration(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*configv1alpha1.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
-	out.Azure = (*config.AzureConfiguration)(unsafe.Pointer(in.Azure))
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(config.AzureConfiguration)
+		if err := Convert_v1alpha1_AzureConfiguration_To_config_AzureConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Azure = nil
+	}
+	out.DryRun = in.DryRun
+	out.Notifications = (*config.NotificationsConfiguration)(unsafe.Pointer(in.Notifications))
 	return nil
 }
 
@@ -161,7 +493,17 @@ func Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(
 
 func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *config.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*configv1alpha1.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
-	out.Azure = (*AzureConfiguration)(unsafe.Pointer(in.Azure))
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureConfiguration)
+		if err := Convert_config_AzureConfiguration_To_v1alpha1_AzureConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Azure = nil
+	}
+	out.DryRun = in.DryRun
+	out.Notifications = (*NotificationsConfiguration)(unsafe.Pointer(in.Notifications))
 	return nil
 }
 
@@ -169,3 +511,125 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *config.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
 	return autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in, out, s)
 }
+
+func autoConvert_v1alpha1_MaintenanceTimeRange_To_config_MaintenanceTimeRange(in *MaintenanceTimeRange, out *config.MaintenanceTimeRange, s conversion.Scope) error {
+	out.Weekdays = *(*[]string)(unsafe.Pointer(&in.Weekdays))
+	out.Begin = in.Begin
+	out.End = in.End
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceTimeRange_To_config_MaintenanceTimeRange is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceTimeRange_To_config_MaintenanceTimeRange(in *MaintenanceTimeRange, out *config.MaintenanceTimeRange, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceTimeRange_To_config_MaintenanceTimeRange(in, out, s)
+}
+
+func autoConvert_config_MaintenanceTimeRange_To_v1alpha1_MaintenanceTimeRange(in *config.MaintenanceTimeRange, out *MaintenanceTimeRange, s conversion.Scope) error {
+	out.Weekdays = *(*[]string)(unsafe.Pointer(&in.Weekdays))
+	out.Begin = in.Begin
+	out.End = in.End
+	return nil
+}
+
+// Convert_config_MaintenanceTimeRange_To_v1alpha1_MaintenanceTimeRange is an autogenerated conversion function.
+func Convert_config_MaintenanceTimeRange_To_v1alpha1_MaintenanceTimeRange(in *config.MaintenanceTimeRange, out *MaintenanceTimeRange, s conversion.Scope) error {
+	return autoConvert_config_MaintenanceTimeRange_To_v1alpha1_MaintenanceTimeRange(in, out, s)
+}
+
+func autoConvert_v1alpha1_MaintenanceWindowConfiguration_To_config_MaintenanceWindowConfiguration(in *MaintenanceWindowConfiguration, out *config.MaintenanceWindowConfiguration, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.TimeRanges = *(*[]config.MaintenanceTimeRange)(unsafe.Pointer(&in.TimeRanges))
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceWindowConfiguration_To_config_MaintenanceWindowConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceWindowConfiguration_To_config_MaintenanceWindowConfiguration(in *MaintenanceWindowConfiguration, out *config.MaintenanceWindowConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceWindowConfiguration_To_config_MaintenanceWindowConfiguration(in, out, s)
+}
+
+func autoConvert_config_MaintenanceWindowConfiguration_To_v1alpha1_MaintenanceWindowConfiguration(in *config.MaintenanceWindowConfiguration, out *MaintenanceWindowConfiguration, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.TimeRanges = *(*[]MaintenanceTimeRange)(unsafe.Pointer(&in.TimeRanges))
+	return nil
+}
+
+// Convert_config_MaintenanceWindowConfiguration_To_v1alpha1_MaintenanceWindowConfiguration is an autogenerated conversion function.
+func Convert_config_MaintenanceWindowConfiguration_To_v1alpha1_MaintenanceWindowConfiguration(in *config.MaintenanceWindowConfiguration, out *MaintenanceWindowConfiguration, s conversion.Scope) error {
+	return autoConvert_config_MaintenanceWindowConfiguration_To_v1alpha1_MaintenanceWindowConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_NotificationsConfiguration_To_config_NotificationsConfiguration(in *NotificationsConfiguration, out *config.NotificationsConfiguration, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.Webhook = (*config.WebhookNotifierConfiguration)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_v1alpha1_NotificationsConfiguration_To_config_NotificationsConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_NotificationsConfiguration_To_config_NotificationsConfiguration(in *NotificationsConfiguration, out *config.NotificationsConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NotificationsConfiguration_To_config_NotificationsConfiguration(in, out, s)
+}
+
+func autoConvert_config_NotificationsConfiguration_To_v1alpha1_NotificationsConfiguration(in *config.NotificationsConfiguration, out *NotificationsConfiguration, s conversion.Scope) error {
+	out.ClusterName = in.ClusterName
+	out.Webhook = (*WebhookNotifierConfiguration)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_config_NotificationsConfiguration_To_v1alpha1_NotificationsConfiguration is an autogenerated conversion function.
+func Convert_config_NotificationsConfiguration_To_v1alpha1_NotificationsConfiguration(in *config.NotificationsConfiguration, out *NotificationsConfiguration, s conversion.Scope) error {
+	return autoConvert_config_NotificationsConfiguration_To_v1alpha1_NotificationsConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_RetryPolicyConfiguration_To_config_RetryPolicyConfiguration(in *RetryPolicyConfiguration, out *config.RetryPolicyConfiguration, s conversion.Scope) error {
+	out.BaseDelay = (*v1.Duration)(unsafe.Pointer(in.BaseDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	out.MaxAttempts = (*int)(unsafe.Pointer(in.MaxAttempts))
+	out.CoolDown = (*v1.Duration)(unsafe.Pointer(in.CoolDown))
+	return nil
+}
+
+// Convert_v1alpha1_RetryPolicyConfiguration_To_config_RetryPolicyConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_RetryPolicyConfiguration_To_config_RetryPolicyConfiguration(in *RetryPolicyConfiguration, out *config.RetryPolicyConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RetryPolicyConfiguration_To_config_RetryPolicyConfiguration(in, out, s)
+}
+
+func autoConvert_config_RetryPolicyConfiguration_To_v1alpha1_RetryPolicyConfiguration(in *config.RetryPolicyConfiguration, out *RetryPolicyConfiguration, s conversion.Scope) error {
+	out.BaseDelay = (*v1.Duration)(unsafe.Pointer(in.BaseDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	out.MaxAttempts = (*int)(unsafe.Pointer(in.MaxAttempts))
+	out.CoolDown = (*v1.Duration)(unsafe.Pointer(in.CoolDown))
+	return nil
+}
+
+// Convert_config_RetryPolicyConfiguration_To_v1alpha1_RetryPolicyConfiguration is an autogenerated conversion function.
+func Convert_config_RetryPolicyConfiguration_To_v1alpha1_RetryPolicyConfiguration(in *config.RetryPolicyConfiguration, out *RetryPolicyConfiguration, s conversion.Scope) error {
+	return autoConvert_config_RetryPolicyConfiguration_To_v1alpha1_RetryPolicyConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_WebhookNotifierConfiguration_To_config_WebhookNotifierConfiguration(in *WebhookNotifierConfiguration, out *config.WebhookNotifierConfiguration, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.MaxAttempts = (*int)(unsafe.Pointer(in.MaxAttempts))
+	out.RetryInterval = (*v1.Duration)(unsafe.Pointer(in.RetryInterval))
+	return nil
+}
+
+// Convert_v1alpha1_WebhookNotifierConfiguration_To_config_WebhookNotifierConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_WebhookNotifierConfiguration_To_config_WebhookNotifierConfiguration(in *WebhookNotifierConfiguration, out *config.WebhookNotifierConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WebhookNotifierConfiguration_To_config_WebhookNotifierConfiguration(in, out, s)
+}
+
+func autoConvert_config_WebhookNotifierConfiguration_To_v1alpha1_WebhookNotifierConfiguration(in *config.WebhookNotifierConfiguration, out *WebhookNotifierConfiguration, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.MaxAttempts = (*int)(unsafe.Pointer(in.MaxAttempts))
+	out.RetryInterval = (*v1.Duration)(unsafe.Pointer(in.RetryInterval))
+	return nil
+}
+
+// Convert_config_WebhookNotifierConfiguration_To_v1alpha1_WebhookNotifierConfiguration is an autogenerated conversion function.
+func Convert_config_WebhookNotifierConfiguration_To_v1alpha1_WebhookNotifierConfiguration(in *config.WebhookNotifierConfiguration, out *WebhookNotifierConfiguration, s conversion.Scope) error {
+	return autoConvert_config_WebhookNotifierConfiguration_To_v1alpha1_WebhookNotifierConfiguration(in, out, s)
+}
@@ -17,5 +17,22 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ControllerConfiguration{}, func(obj interface{}) { SetObjectDefaults_ControllerConfiguration(obj.(*ControllerConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_ControllerConfiguration(in *ControllerConfiguration) {
+	SetDefaults_ControllerConfiguration(in)
+	if in.Azure != nil {
+		SetDefaults_AzureConfiguration(in.Azure)
+		if in.Azure.OrphanedPublicIPRemedy != nil {
+			SetDefaults_AzureOrphanedPublicIPRemedyConfiguration(in.Azure.OrphanedPublicIPRemedy)
+		}
+		if in.Azure.FailedVMRemedy != nil {
+			SetDefaults_AzureFailedVMRemedyConfiguration(in.Azure.FailedVMRemedy)
+		}
+		if in.Azure.RemedyActions != nil {
+			SetDefaults_AzureRemedyActionsConfiguration(in.Azure.RemedyActions)
+		}
+	}
+}
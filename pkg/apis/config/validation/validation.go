@@ -0,0 +1,342 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+// maxAttempts is the max number of attempts that can be configured, so that failing operations are not retried almost indefinitely.
+const maxAttempts = 100
+
+// ValidateControllerConfiguration validates the given controller configuration.
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.Azure != nil {
+		allErrs = append(allErrs, validateAzureConfiguration(cfg.Azure, field.NewPath("azure"))...)
+	}
+	if cfg.Notifications != nil {
+		allErrs = append(allErrs, validateNotificationsConfiguration(cfg.Notifications, field.NewPath("notifications"))...)
+	}
+
+	return allErrs
+}
+
+func validateAzureConfiguration(cfg *config.AzureConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.OrphanedPublicIPRemedy != nil {
+		allErrs = append(allErrs, validateAzureOrphanedPublicIPRemedyConfiguration(cfg.OrphanedPublicIPRemedy, fldPath.Child("orphanedPublicIPRemedy"))...)
+	}
+	if cfg.FailedVMRemedy != nil {
+		allErrs = append(allErrs, validateAzureFailedVMRemedyConfiguration(cfg.FailedVMRemedy, fldPath.Child("failedVMRemedy"))...)
+	}
+	if cfg.RateLimiter != nil {
+		allErrs = append(allErrs, validateAzureRateLimiterConfiguration(cfg.RateLimiter, fldPath.Child("rateLimiter"))...)
+	}
+	if cfg.RemedyActions != nil {
+		allErrs = append(allErrs, validateNonNegativeDuration(cfg.RemedyActions.RetentionPeriod, fldPath.Child("remedyActions", "retentionPeriod"))...)
+	}
+	if cfg.DestructiveActionBudget != nil {
+		allErrs = append(allErrs, validateAzureDestructiveActionBudgetConfiguration(cfg.DestructiveActionBudget, fldPath.Child("destructiveActionBudget"))...)
+	}
+	allErrs = append(allErrs, validateAzureTargetConfigurations(cfg.Targets, fldPath.Child("targets"))...)
+
+	return allErrs
+}
+
+func validateAzureOrphanedPublicIPRemedyConfiguration(cfg *config.AzureOrphanedPublicIPRemedyConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateRequeueIntervalAndSyncPeriod(cfg.RequeueInterval, cfg.SyncPeriod, fldPath)...)
+	allErrs = append(allErrs, validatePositiveDuration(cfg.ServiceSyncPeriod, fldPath.Child("serviceSyncPeriod"))...)
+	allErrs = append(allErrs, validateNonNegativeDuration(cfg.ServiceGarbageCollectionPeriod, fldPath.Child("serviceGarbageCollectionPeriod"))...)
+	allErrs = append(allErrs, validateNonNegativeDuration(cfg.DeletionGracePeriod, fldPath.Child("deletionGracePeriod"))...)
+	allErrs = append(allErrs, validateAttempts(cfg.MaxGetAttempts, fldPath.Child("maxGetAttempts"))...)
+	allErrs = append(allErrs, validateAttempts(cfg.MaxCleanAttempts, fldPath.Child("maxCleanAttempts"))...)
+	if cfg.GetRetryPolicy != nil {
+		allErrs = append(allErrs, validateRetryPolicyConfiguration(cfg.GetRetryPolicy, fldPath.Child("getRetryPolicy"))...)
+	}
+	if cfg.CleanRetryPolicy != nil {
+		allErrs = append(allErrs, validateRetryPolicyConfiguration(cfg.CleanRetryPolicy, fldPath.Child("cleanRetryPolicy"))...)
+	}
+	if cfg.AnomalyGuard != nil {
+		allErrs = append(allErrs, validateAnomalyGuardConfiguration(cfg.AnomalyGuard, fldPath.Child("anomalyGuard"))...)
+	}
+	if cfg.MaintenanceWindow != nil {
+		allErrs = append(allErrs, validateMaintenanceWindowConfiguration(cfg.MaintenanceWindow, fldPath.Child("maintenanceWindow"))...)
+	}
+
+	return allErrs
+}
+
+func validateAzureFailedVMRemedyConfiguration(cfg *config.AzureFailedVMRemedyConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateRequeueIntervalAndSyncPeriod(cfg.RequeueInterval, cfg.SyncPeriod, fldPath)...)
+	allErrs = append(allErrs, validatePositiveDuration(cfg.NodeSyncPeriod, fldPath.Child("nodeSyncPeriod"))...)
+	allErrs = append(allErrs, validateNonNegativeDuration(cfg.NodeGarbageCollectionPeriod, fldPath.Child("nodeGarbageCollectionPeriod"))...)
+	allErrs = append(allErrs, validateAttempts(cfg.MaxGetAttempts, fldPath.Child("maxGetAttempts"))...)
+	allErrs = append(allErrs, validateAttempts(cfg.MaxReapplyAttempts, fldPath.Child("maxReapplyAttempts"))...)
+	if cfg.GetRetryPolicy != nil {
+		allErrs = append(allErrs, validateRetryPolicyConfiguration(cfg.GetRetryPolicy, fldPath.Child("getRetryPolicy"))...)
+	}
+	if cfg.ReapplyRetryPolicy != nil {
+		allErrs = append(allErrs, validateRetryPolicyConfiguration(cfg.ReapplyRetryPolicy, fldPath.Child("reapplyRetryPolicy"))...)
+	}
+	if cfg.MaintenanceWindow != nil {
+		allErrs = append(allErrs, validateMaintenanceWindowConfiguration(cfg.MaintenanceWindow, fldPath.Child("maintenanceWindow"))...)
+	}
+
+	return allErrs
+}
+
+func validateRequeueIntervalAndSyncPeriod(requeueInterval, syncPeriod metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validatePositiveDuration(requeueInterval, fldPath.Child("requeueInterval"))...)
+	allErrs = append(allErrs, validatePositiveDuration(syncPeriod, fldPath.Child("syncPeriod"))...)
+	if syncPeriod.Duration > 0 && syncPeriod.Duration < requeueInterval.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("syncPeriod"), syncPeriod.Duration.String(), "must not be shorter than requeueInterval"))
+	}
+
+	return allErrs
+}
+
+func validateRetryPolicyConfiguration(cfg *config.RetryPolicyConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.BaseDelay != nil {
+		allErrs = append(allErrs, validatePositiveDuration(*cfg.BaseDelay, fldPath.Child("baseDelay"))...)
+	}
+	if cfg.MaxDelay != nil {
+		allErrs = append(allErrs, validatePositiveDuration(*cfg.MaxDelay, fldPath.Child("maxDelay"))...)
+		if cfg.BaseDelay != nil && cfg.MaxDelay.Duration < cfg.BaseDelay.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxDelay"), cfg.MaxDelay.Duration.String(), "must not be shorter than baseDelay"))
+		}
+	}
+	if cfg.Jitter != nil && (*cfg.Jitter < 0 || *cfg.Jitter > 1) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jitter"), *cfg.Jitter, "must be between 0 and 1"))
+	}
+	if cfg.MaxAttempts != nil {
+		allErrs = append(allErrs, validateAttempts(*cfg.MaxAttempts, fldPath.Child("maxAttempts"))...)
+	}
+	if cfg.CoolDown != nil {
+		allErrs = append(allErrs, validatePositiveDuration(*cfg.CoolDown, fldPath.Child("coolDown"))...)
+	}
+
+	return allErrs
+}
+
+func validateAnomalyGuardConfiguration(cfg *config.AnomalyGuardConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.MaxOrphanRatio != nil && *cfg.MaxOrphanRatio < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxOrphanRatio"), *cfg.MaxOrphanRatio, "must not be negative"))
+	}
+	if cfg.MinOrphans != nil && *cfg.MinOrphans < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minOrphans"), *cfg.MinOrphans, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateMaintenanceWindowConfiguration(cfg *config.MaintenanceWindowConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.TimeZone != "" {
+		if _, err := time.LoadLocation(cfg.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), cfg.TimeZone, err.Error()))
+		}
+	}
+	for i, tr := range cfg.TimeRanges {
+		idxPath := fldPath.Child("timeRanges").Index(i)
+		for j, weekday := range tr.Weekdays {
+			if !isValidWeekday(weekday) {
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("weekdays").Index(j), weekday, weekdayNames()))
+			}
+		}
+		allErrs = append(allErrs, validateTimeOfDay(tr.Begin, idxPath.Child("begin"))...)
+		allErrs = append(allErrs, validateTimeOfDay(tr.End, idxPath.Child("end"))...)
+	}
+
+	return allErrs
+}
+
+func validateTimeOfDay(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, err := time.Parse("15:04", value); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be a time of day in the format HH:MM"))
+	}
+
+	return allErrs
+}
+
+func isValidWeekday(name string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(name, d.String()) || strings.EqualFold(name, d.String()[:3]) {
+			return true
+		}
+	}
+	return false
+}
+
+func weekdayNames() []string {
+	var names []string
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		names = append(names, d.String())
+	}
+	return names
+}
+
+func validateAzureRateLimiterConfiguration(cfg *config.AzureRateLimiterConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.ReadQPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("readQPS"), cfg.ReadQPS, "must not be negative"))
+	}
+	if cfg.ReadBurst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("readBurst"), cfg.ReadBurst, "must not be negative"))
+	}
+	if cfg.WriteQPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("writeQPS"), cfg.WriteQPS, "must not be negative"))
+	}
+	if cfg.WriteBurst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("writeBurst"), cfg.WriteBurst, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateAzureDestructiveActionBudgetConfiguration(cfg *config.AzureDestructiveActionBudgetConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validatePositiveDuration(cfg.Window, fldPath.Child("window"))...)
+	if cfg.MaxPublicIPAddressDeletions != nil && *cfg.MaxPublicIPAddressDeletions < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPublicIPAddressDeletions"), *cfg.MaxPublicIPAddressDeletions, "must not be negative"))
+	}
+	if cfg.MaxVirtualMachineReapplies != nil && *cfg.MaxVirtualMachineReapplies < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxVirtualMachineReapplies"), *cfg.MaxVirtualMachineReapplies, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateAzureTargetConfigurations(cfgs []config.AzureTargetConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names, namespaces := make(map[string]bool), make(map[string]bool)
+	for i, cfg := range cfgs {
+		idxPath := fldPath.Index(i)
+		if cfg.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must specify the name of the target cluster"))
+		} else if names[cfg.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), cfg.Name))
+		}
+		if cfg.Namespace == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("namespace"), "must specify the namespace of the target cluster"))
+		} else if namespaces[cfg.Namespace] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("namespace"), cfg.Namespace))
+		}
+		if cfg.InfrastructureConfig == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("infrastructureConfig"), "must specify the infrastructure configuration file of the target cluster"))
+		}
+		switch {
+		case cfg.Kubeconfig == "" && cfg.KubeconfigSecretRef == nil:
+			allErrs = append(allErrs, field.Required(idxPath.Child("kubeconfig"), "must specify either kubeconfig or kubeconfigSecretRef"))
+		case cfg.Kubeconfig != "" && cfg.KubeconfigSecretRef != nil:
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("kubeconfigSecretRef"), "must not specify both kubeconfig and kubeconfigSecretRef"))
+		case cfg.KubeconfigSecretRef != nil && (cfg.KubeconfigSecretRef.Name == "" || cfg.KubeconfigSecretRef.Namespace == ""):
+			allErrs = append(allErrs, field.Required(idxPath.Child("kubeconfigSecretRef"), "must specify the name and namespace of the kubeconfig secret"))
+		}
+		names[cfg.Name], namespaces[cfg.Namespace] = true, true
+	}
+
+	return allErrs
+}
+
+func validateNotificationsConfiguration(cfg *config.NotificationsConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.Webhook != nil {
+		webhookPath := fldPath.Child("webhook")
+		if u, err := url.Parse(cfg.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(webhookPath.Child("url"), cfg.Webhook.URL, "must be an absolute http or https URL"))
+		}
+		if cfg.Webhook.Timeout != nil {
+			allErrs = append(allErrs, validatePositiveDuration(*cfg.Webhook.Timeout, webhookPath.Child("timeout"))...)
+		}
+		if cfg.Webhook.MaxAttempts != nil {
+			allErrs = append(allErrs, validateAttempts(*cfg.Webhook.MaxAttempts, webhookPath.Child("maxAttempts"))...)
+		}
+		if cfg.Webhook.RetryInterval != nil {
+			allErrs = append(allErrs, validateNonNegativeDuration(*cfg.Webhook.RetryInterval, webhookPath.Child("retryInterval"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validatePositiveDuration(d metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if d.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, d.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+func validateNonNegativeDuration(d metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if d.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, d.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateAttempts(value int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be positive"))
+	} else if value > maxAttempts {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must not be greater than %d", maxAttempts)))
+	}
+
+	return allErrs
+}
+
+func validatePositiveInt(value int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be positive"))
+	}
+
+	return allErrs
+}
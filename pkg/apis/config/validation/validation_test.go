@@ -0,0 +1,245 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/apis/config/validation"
+)
+
+var _ = Describe("Validation", func() {
+	var cfg *config.ControllerConfiguration
+
+	BeforeEach(func() {
+		cfg = &config.ControllerConfiguration{
+			Azure: &config.AzureConfiguration{
+				OrphanedPublicIPRemedy: &config.AzureOrphanedPublicIPRemedyConfiguration{
+					RequeueInterval:     metav1.Duration{Duration: 1 * time.Minute},
+					SyncPeriod:          metav1.Duration{Duration: 10 * time.Hour},
+					ServiceSyncPeriod:   metav1.Duration{Duration: 4 * time.Hour},
+					DeletionGracePeriod: metav1.Duration{Duration: 5 * time.Minute},
+					MaxGetAttempts:      5,
+					MaxCleanAttempts:    5,
+				},
+				FailedVMRemedy: &config.AzureFailedVMRemedyConfiguration{
+					RequeueInterval:    metav1.Duration{Duration: 1 * time.Minute},
+					SyncPeriod:         metav1.Duration{Duration: 2 * time.Hour},
+					NodeSyncPeriod:     metav1.Duration{Duration: 4 * time.Hour},
+					MaxGetAttempts:     5,
+					MaxReapplyAttempts: 5,
+				},
+			},
+		}
+	})
+
+	Describe("#ValidateControllerConfiguration", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControllerConfiguration(&config.ControllerConfiguration{})).To(BeEmpty())
+		})
+
+		It("should forbid non-positive requeue intervals and attempts", func() {
+			cfg.Azure.OrphanedPublicIPRemedy.RequeueInterval = metav1.Duration{}
+			cfg.Azure.FailedVMRemedy.MaxReapplyAttempts = 0
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.orphanedPublicIPRemedy.requeueInterval"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.failedVMRemedy.maxReapplyAttempts"),
+				})),
+			))
+		})
+
+		It("should forbid too many attempts", func() {
+			cfg.Azure.OrphanedPublicIPRemedy.MaxCleanAttempts = 101
+			cfg.Azure.FailedVMRemedy.ReapplyRetryPolicy = &config.RetryPolicyConfiguration{
+				MaxAttempts: ptr.To(1000),
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("azure.orphanedPublicIPRemedy.maxCleanAttempts"),
+					"Detail": Equal("must not be greater than 100"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("azure.failedVMRemedy.reapplyRetryPolicy.maxAttempts"),
+					"Detail": Equal("must not be greater than 100"),
+				})),
+			))
+		})
+
+		It("should forbid sync periods shorter than the requeue interval", func() {
+			cfg.Azure.FailedVMRemedy.SyncPeriod = metav1.Duration{Duration: 30 * time.Second}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.failedVMRemedy.syncPeriod"),
+				})),
+			))
+		})
+
+		It("should forbid negative garbage collection periods, but allow zero", func() {
+			cfg.Azure.OrphanedPublicIPRemedy.ServiceGarbageCollectionPeriod = metav1.Duration{Duration: -1 * time.Hour}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.orphanedPublicIPRemedy.serviceGarbageCollectionPeriod"),
+				})),
+			))
+		})
+
+		It("should forbid invalid retry policies", func() {
+			cfg.Azure.OrphanedPublicIPRemedy.CleanRetryPolicy = &config.RetryPolicyConfiguration{
+				BaseDelay:   &metav1.Duration{Duration: 1 * time.Minute},
+				MaxDelay:    &metav1.Duration{Duration: 30 * time.Second},
+				Jitter:      ptr.To(1.5),
+				MaxAttempts: ptr.To(0),
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.orphanedPublicIPRemedy.cleanRetryPolicy.maxDelay"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.orphanedPublicIPRemedy.cleanRetryPolicy.jitter"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.orphanedPublicIPRemedy.cleanRetryPolicy.maxAttempts"),
+				})),
+			))
+		})
+
+		It("should forbid invalid maintenance windows", func() {
+			cfg.Azure.FailedVMRemedy.MaintenanceWindow = &config.MaintenanceWindowConfiguration{
+				TimeZone: "Invalid/Zone",
+				TimeRanges: []config.MaintenanceTimeRange{
+					{Weekdays: []string{"Sat", "Funday"}, Begin: "22:00", End: "4am"},
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.failedVMRemedy.maintenanceWindow.timeZone"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("azure.failedVMRemedy.maintenanceWindow.timeRanges[0].weekdays[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.failedVMRemedy.maintenanceWindow.timeRanges[0].end"),
+				})),
+			))
+		})
+
+		It("should forbid invalid rate limiters and budgets", func() {
+			cfg.Azure.RateLimiter = &config.AzureRateLimiterConfiguration{ReadQPS: -1}
+			cfg.Azure.DestructiveActionBudget = &config.AzureDestructiveActionBudgetConfiguration{
+				MaxPublicIPAddressDeletions: ptr.To(-1),
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.rateLimiter.readQPS"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.destructiveActionBudget.window"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("azure.destructiveActionBudget.maxPublicIPAddressDeletions"),
+				})),
+			))
+		})
+
+		It("should forbid invalid target clusters", func() {
+			cfg.Azure.Targets = []config.AzureTargetConfiguration{
+				{Name: "a", Namespace: "ns-a", Kubeconfig: "/a", InfrastructureConfig: "/infra-a"},
+				{Name: "a", Namespace: "ns-a", Kubeconfig: "/b", KubeconfigSecretRef: &corev1.SecretReference{Name: "b", Namespace: "ns-b"}},
+				{Name: "c", Namespace: "ns-c", InfrastructureConfig: "/infra-c"},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("azure.targets[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("azure.targets[1].namespace"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("azure.targets[1].infrastructureConfig"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("azure.targets[1].kubeconfigSecretRef"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("azure.targets[2].kubeconfig"),
+				})),
+			))
+		})
+
+		It("should forbid invalid webhook notifications", func() {
+			cfg.Notifications = &config.NotificationsConfiguration{
+				Webhook: &config.WebhookNotifierConfiguration{
+					URL:         "hooks.example.com",
+					MaxAttempts: ptr.To(0),
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("notifications.webhook.url"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("notifications.webhook.maxAttempts"),
+				})),
+			))
+		})
+	})
+})
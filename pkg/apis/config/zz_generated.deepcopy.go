@@ -10,23 +10,73 @@
 package config
 
 import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnomalyGuardConfiguration) DeepCopyInto(out *AnomalyGuardConfiguration) {
+	*out = *in
+	if in.MaxOrphanRatio != nil {
+		in, out := &in.MaxOrphanRatio, &out.MaxOrphanRatio
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MinOrphans != nil {
+		in, out := &in.MinOrphans, &out.MinOrphans
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnomalyGuardConfiguration.
+func (in *AnomalyGuardConfiguration) DeepCopy() *AnomalyGuardConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AnomalyGuardConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureConfiguration) DeepCopyInto(out *AzureConfiguration) {
 	*out = *in
 	if in.OrphanedPublicIPRemedy != nil {
 		in, out := &in.OrphanedPublicIPRemedy, &out.OrphanedPublicIPRemedy
 		*out = new(AzureOrphanedPublicIPRemedyConfiguration)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.FailedVMRemedy != nil {
 		in, out := &in.FailedVMRemedy, &out.FailedVMRemedy
 		*out = new(AzureFailedVMRemedyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimiter != nil {
+		in, out := &in.RateLimiter, &out.RateLimiter
+		*out = new(AzureRateLimiterConfiguration)
+		**out = **in
+	}
+	if in.RemedyActions != nil {
+		in, out := &in.RemedyActions, &out.RemedyActions
+		*out = new(AzureRemedyActionsConfiguration)
 		**out = **in
 	}
+	if in.DestructiveActionBudget != nil {
+		in, out := &in.DestructiveActionBudget, &out.DestructiveActionBudget
+		*out = new(AzureDestructiveActionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]AzureTargetConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -40,12 +90,55 @@ func (in *AzureConfiguration) DeepCopy() *AzureConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDestructiveActionBudgetConfiguration) DeepCopyInto(out *AzureDestructiveActionBudgetConfiguration) {
+	*out = *in
+	out.Window = in.Window
+	if in.MaxPublicIPAddressDeletions != nil {
+		in, out := &in.MaxPublicIPAddressDeletions, &out.MaxPublicIPAddressDeletions
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxVirtualMachineReapplies != nil {
+		in, out := &in.MaxVirtualMachineReapplies, &out.MaxVirtualMachineReapplies
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDestructiveActionBudgetConfiguration.
+func (in *AzureDestructiveActionBudgetConfiguration) DeepCopy() *AzureDestructiveActionBudgetConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDestructiveActionBudgetConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureFailedVMRemedyConfiguration) DeepCopyInto(out *AzureFailedVMRemedyConfiguration) {
 	*out = *in
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.NodeSyncPeriod = in.NodeSyncPeriod
+	out.NodeGarbageCollectionPeriod = in.NodeGarbageCollectionPeriod
+	if in.GetRetryPolicy != nil {
+		in, out := &in.GetRetryPolicy, &out.GetRetryPolicy
+		*out = new(RetryPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReapplyRetryPolicy != nil {
+		in, out := &in.ReapplyRetryPolicy, &out.ReapplyRetryPolicy
+		*out = new(RetryPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -65,7 +158,28 @@ func (in *AzureOrphanedPublicIPRemedyConfiguration) DeepCopyInto(out *AzureOrpha
 	out.RequeueInterval = in.RequeueInterval
 	out.SyncPeriod = in.SyncPeriod
 	out.ServiceSyncPeriod = in.ServiceSyncPeriod
+	out.ServiceGarbageCollectionPeriod = in.ServiceGarbageCollectionPeriod
 	out.DeletionGracePeriod = in.DeletionGracePeriod
+	if in.GetRetryPolicy != nil {
+		in, out := &in.GetRetryPolicy, &out.GetRetryPolicy
+		*out = new(RetryPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanRetryPolicy != nil {
+		in, out := &in.CleanRetryPolicy, &out.CleanRetryPolicy
+		*out = new(RetryPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnomalyGuard != nil {
+		in, out := &in.AnomalyGuard, &out.AnomalyGuard
+		*out = new(AnomalyGuardConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -79,6 +193,60 @@ func (in *AzureOrphanedPublicIPRemedyConfiguration) DeepCopy() *AzureOrphanedPub
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRateLimiterConfiguration) DeepCopyInto(out *AzureRateLimiterConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRateLimiterConfiguration.
+func (in *AzureRateLimiterConfiguration) DeepCopy() *AzureRateLimiterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRateLimiterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRemedyActionsConfiguration) DeepCopyInto(out *AzureRemedyActionsConfiguration) {
+	*out = *in
+	out.RetentionPeriod = in.RetentionPeriod
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRemedyActionsConfiguration.
+func (in *AzureRemedyActionsConfiguration) DeepCopy() *AzureRemedyActionsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRemedyActionsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureTargetConfiguration) DeepCopyInto(out *AzureTargetConfiguration) {
+	*out = *in
+	if in.KubeconfigSecretRef != nil {
+		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureTargetConfiguration.
+func (in *AzureTargetConfiguration) DeepCopy() *AzureTargetConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureTargetConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -93,6 +261,11 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(AzureConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -113,3 +286,140 @@ func (in *ControllerConfiguration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceTimeRange) DeepCopyInto(out *MaintenanceTimeRange) {
+	*out = *in
+	if in.Weekdays != nil {
+		in, out := &in.Weekdays, &out.Weekdays
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceTimeRange.
+func (in *MaintenanceTimeRange) DeepCopy() *MaintenanceTimeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceTimeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowConfiguration) DeepCopyInto(out *MaintenanceWindowConfiguration) {
+	*out = *in
+	if in.TimeRanges != nil {
+		in, out := &in.TimeRanges, &out.TimeRanges
+		*out = make([]MaintenanceTimeRange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowConfiguration.
+func (in *MaintenanceWindowConfiguration) DeepCopy() *MaintenanceWindowConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfiguration) DeepCopyInto(out *NotificationsConfiguration) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookNotifierConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfiguration.
+func (in *NotificationsConfiguration) DeepCopy() *NotificationsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicyConfiguration) DeepCopyInto(out *RetryPolicyConfiguration) {
+	*out = *in
+	if in.BaseDelay != nil {
+		in, out := &in.BaseDelay, &out.BaseDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.CoolDown != nil {
+		in, out := &in.CoolDown, &out.CoolDown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicyConfiguration.
+func (in *RetryPolicyConfiguration) DeepCopy() *RetryPolicyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotifierConfiguration) DeepCopyInto(out *WebhookNotifierConfiguration) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotifierConfiguration.
+func (in *WebhookNotifierConfiguration) DeepCopy() *WebhookNotifierConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotifierConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,16 +16,19 @@ package azure
 
 import (
 	"context"
-	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
-	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/adal"
-	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
@@ -38,92 +41,119 @@ type Credentials struct {
 	TenantID           string `yaml:"tenantId"`
 	SubscriptionID     string `yaml:"subscriptionId"`
 	ResourceGroup      string `yaml:"resourceGroup"`
+	// UseManagedIdentity specifies whether to authenticate with a managed identity instead of a client secret or federated token.
+	UseManagedIdentity bool `yaml:"useManagedIdentity"`
+	// ManagedIdentityClientID is the client ID of the user-assigned managed identity to authenticate with.
+	// If empty, the system-assigned managed identity is used.
+	ManagedIdentityClientID string `yaml:"managedIdentityClientId"`
+	// ManagedIdentityTokenEndpoint overrides the token endpoint of the Azure Instance Metadata Service (IMDS)
+	// used to authenticate with a managed identity, if not empty.
+	ManagedIdentityTokenEndpoint string `yaml:"managedIdentityTokenEndpoint"`
+	// Cloud is the name of the Azure cloud environment, e.g. AzurePublicCloud, AzureChinaCloud, or AzureUSGovernmentCloud.
+	// If empty, AzurePublicCloud is used.
+	Cloud string `yaml:"cloud"`
+	// ResourceManagerEndpoint overrides the Azure Resource Manager endpoint of the cloud environment, if not empty.
+	ResourceManagerEndpoint string `yaml:"resourceManagerEndpoint"`
+	// ActiveDirectoryEndpoint overrides the Azure Active Directory endpoint of the cloud environment, if not empty.
+	ActiveDirectoryEndpoint string `yaml:"activeDirectoryEndpoint"`
+	// APIProfile is the name of the API profile, e.g. latest, 2020-09-01-hybrid, or 2019-03-01-hybrid.
+	// If empty, 2020-09-01-hybrid is used for AzureStackCloud, and latest for all other clouds.
+	APIProfile string `yaml:"apiProfile"`
 }
 
-// Future contains the method WaitForCompletionRef.
-type Future interface {
-	// WaitForCompletionRef will return when one of the following conditions is met ...
-	WaitForCompletionRef(context.Context, autorest.Client) error
+// Poller polls a long-running operation.
+type Poller interface {
+	// Done returns true if the long-running operation has reached a terminal state.
+	Done() bool
+	// Poll fetches the latest state of the long-running operation and returns the raw response.
+	Poll(context.Context) (*http.Response, error)
+	// Result returns the error of the long-running operation if it failed, or nil if it succeeded.
+	// It should only be called once the long-running operation is done.
+	Result(context.Context) error
 }
 
-// PublicIPAddressesClient contains the methods of network.PublicIPAddressesClient.
+// PublicIPAddressesClient contains the methods of armnetwork.PublicIPAddressesClient.
 type PublicIPAddressesClient interface {
 	// Get gets the specified public IP address in a specified resource group.
-	Get(context.Context, string, string, string) (network.PublicIPAddress, error)
-	// List gets all public IP addresses in a resource group.
-	List(context.Context, string) (network.PublicIPAddressListResultPage, error)
-	// Delete deletes the specified public IP address.
-	Delete(context.Context, string, string) (Future, error)
-	// Client returns the autorest.Client
-	Client() autorest.Client
+	Get(context.Context, string, string, *armnetwork.PublicIPAddressesClientGetOptions) (armnetwork.PublicIPAddressesClientGetResponse, error)
+	// NewListPager creates a pager over all public IP addresses in a resource group.
+	NewListPager(string, *armnetwork.PublicIPAddressesClientListOptions) *runtime.Pager[armnetwork.PublicIPAddressesClientListResponse]
+	// BeginDelete starts deleting the specified public IP address.
+	BeginDelete(context.Context, string, string) (Poller, error)
 }
 
-// LoadBalancersClient contains the methods of network.LoadBalancersClient.
+// LoadBalancersClient contains the methods of armnetwork.LoadBalancersClient.
 type LoadBalancersClient interface {
 	// Get gets the specified load balancer.
-	Get(context.Context, string, string, string) (network.LoadBalancer, error)
-	// CreateOrUpdate creates or updates a load balancer.
-	CreateOrUpdate(context.Context, string, string, network.LoadBalancer) (Future, error)
-	// Client returns the autorest.Client
-	Client() autorest.Client
+	Get(context.Context, string, string, *armnetwork.LoadBalancersClientGetOptions) (armnetwork.LoadBalancersClientGetResponse, error)
+	// BeginCreateOrUpdate starts creating or updating a load balancer.
+	BeginCreateOrUpdate(context.Context, string, string, armnetwork.LoadBalancer) (Poller, error)
 }
 
-// VirtualMachinesClient contains the methods of compute.VirtualMachinesClient.
+// VirtualMachinesClient contains the methods of armcompute.VirtualMachinesClient.
 type VirtualMachinesClient interface {
 	// Get gets the specified virtual machine.
-	Get(context.Context, string, string, compute.InstanceViewTypes) (compute.VirtualMachine, error)
-	// Reapply reapplies the virtual machine's state.
-	Reapply(context.Context, string, string) (Future, error)
-	// Client returns the autorest.Client
-	Client() autorest.Client
+	Get(context.Context, string, string, *armcompute.VirtualMachinesClientGetOptions) (armcompute.VirtualMachinesClientGetResponse, error)
+	// BeginReapply starts reapplying the virtual machine's state.
+	BeginReapply(context.Context, string, string) (Poller, error)
 }
 
-// PublicIPAddressesClientImpl is an implementation of PublicIPAddressesClient based on network.PublicIPAddressesClient.
+// PublicIPAddressesClientImpl is an implementation of PublicIPAddressesClient based on armnetwork.PublicIPAddressesClient.
 type PublicIPAddressesClientImpl struct {
-	network.PublicIPAddressesClient
+	*armnetwork.PublicIPAddressesClient
 }
 
-// Delete implements PublicIPAddressesClient.
-func (c PublicIPAddressesClientImpl) Delete(ctx context.Context, resourceGroupName string, publicIPAddressName string) (Future, error) {
-	f, err := c.PublicIPAddressesClient.Delete(ctx, resourceGroupName, publicIPAddressName)
-	return &f, err
+// BeginDelete implements PublicIPAddressesClient.
+func (c PublicIPAddressesClientImpl) BeginDelete(ctx context.Context, resourceGroupName string, publicIPAddressName string) (Poller, error) {
+	return newPoller(c.PublicIPAddressesClient.BeginDelete(ctx, resourceGroupName, publicIPAddressName, nil))
 }
 
-// Client implements PublicIPAddressesClient.
-func (c PublicIPAddressesClientImpl) Client() autorest.Client {
-	return c.PublicIPAddressesClient.Client
+// LoadBalancersClientImpl is an implementation of LoadBalancersClient based on armnetwork.LoadBalancersClient.
+type LoadBalancersClientImpl struct {
+	*armnetwork.LoadBalancersClient
 }
 
-// LoadBalancersClientImpl is an implementation of LoadBalancersClient based on network.LoadBalancersClient.
-type LoadBalancersClientImpl struct {
-	network.LoadBalancersClient
+// BeginCreateOrUpdate implements LoadBalancersClient.
+func (c LoadBalancersClientImpl) BeginCreateOrUpdate(ctx context.Context, resourceGroupName string, loadBalancerName string, loadBalancer armnetwork.LoadBalancer) (Poller, error) {
+	return newPoller(c.LoadBalancersClient.BeginCreateOrUpdate(ctx, resourceGroupName, loadBalancerName, loadBalancer, nil))
 }
 
-// CreateOrUpdate implements LoadBalancersClient.
-func (c LoadBalancersClientImpl) CreateOrUpdate(ctx context.Context, resourceGroupName string, loadBalancerName string, loadBalancer network.LoadBalancer) (Future, error) {
-	f, err := c.LoadBalancersClient.CreateOrUpdate(ctx, resourceGroupName, loadBalancerName, loadBalancer)
-	return &f, err
+// VirtualMachinesClientImpl is an implementation of VirtualMachinesClient based on armcompute.VirtualMachinesClient.
+type VirtualMachinesClientImpl struct {
+	*armcompute.VirtualMachinesClient
 }
 
-// Client implements LoadBalancersClient.
-func (c LoadBalancersClientImpl) Client() autorest.Client {
-	return c.LoadBalancersClient.Client
+// BeginReapply implements VirtualMachinesClient.
+func (c VirtualMachinesClientImpl) BeginReapply(ctx context.Context, resourceGroupName string, vmName string) (Poller, error) {
+	return newPoller(c.VirtualMachinesClient.BeginReapply(ctx, resourceGroupName, vmName, nil))
 }
 
-// VirtualMachinesClientImpl is an implementation of VirtualMachinesClient based on compute.VirtualMachinesClient.
-type VirtualMachinesClientImpl struct {
-	compute.VirtualMachinesClient
+// pollerImpl is an implementation of Poller based on runtime.Poller.
+type pollerImpl[T any] struct {
+	poller *runtime.Poller[T]
+}
+
+// Done implements Poller.
+func (p *pollerImpl[T]) Done() bool {
+	return p.poller.Done()
+}
+
+// Poll implements Poller.
+func (p *pollerImpl[T]) Poll(ctx context.Context) (*http.Response, error) {
+	return p.poller.Poll(ctx)
 }
 
-// Reapply implements VirtualMachinesClient.
-func (c VirtualMachinesClientImpl) Reapply(ctx context.Context, resourceGroupName string, vmName string) (Future, error) {
-	f, err := c.VirtualMachinesClient.Reapply(ctx, resourceGroupName, vmName)
-	return &f, err
+// Result implements Poller.
+func (p *pollerImpl[T]) Result(ctx context.Context) error {
+	_, err := p.poller.Result(ctx)
+	return err
 }
 
-// Client implements VirtualMachinesClient.
-func (c VirtualMachinesClientImpl) Client() autorest.Client {
-	return c.VirtualMachinesClient.Client
+func newPoller[T any](poller *runtime.Poller[T], err error) (Poller, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &pollerImpl[T]{poller: poller}, nil
 }
 
 // Clients contains all needed Azure clients.
@@ -131,6 +161,14 @@ type Clients struct {
 	PublicIPAddressesClient PublicIPAddressesClient
 	LoadBalancersClient     LoadBalancersClient
 	VirtualMachinesClient   VirtualMachinesClient
+	// APIProfile is the API profile used by the clients.
+	APIProfile *APIProfile
+}
+
+// Supports returns true if the given feature is supported by the API profile used by these clients.
+// If no API profile is set, all features are supported.
+func (c *Clients) Supports(feature Feature) bool {
+	return c.APIProfile == nil || c.APIProfile.Supports(feature)
 }
 
 // ReadConfig creates new Azure credentials by reading the configuration file at the given path.
@@ -151,54 +189,97 @@ func ReadConfig(path string) (*Credentials, error) {
 	return credentials, nil
 }
 
-// NewClients creates a new Clients instance using the given credentials.
-func NewClients(credentials *Credentials) (*Clients, error) {
-	// Create OAuth config
-	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, credentials.TenantID)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create OAuth config")
+// NewCredential creates a new azcore.TokenCredential for the given Azure cloud using the given credentials.
+func NewCredential(credentials *Credentials, cloudConfig *cloud.Configuration) (azcore.TokenCredential, error) {
+	if credentials.UseManagedIdentity {
+		return newManagedIdentityCredential(credentials.ManagedIdentityTokenEndpoint, credentials.ManagedIdentityClientID)
 	}
-	var servicePrincipalToken *adal.ServicePrincipalToken
+
+	// Instance discovery isn't supported by Azure Stack Hub
+	clientOptions := azcore.ClientOptions{Cloud: *cloudConfig}
+	disableInstanceDiscovery := strings.EqualFold(credentials.Cloud, AzureStackCloudName)
 	if len(credentials.FederatedTokenFile) > 0 {
-		servicePrincipalToken, err = adal.NewServicePrincipalTokenFromFederatedTokenCallback(
-			*oauthConfig,
+		credential, err := azidentity.NewClientAssertionCredential(
+			credentials.TenantID,
 			credentials.ClientID,
-			func() (string, error) {
+			func(context.Context) (string, error) {
 				b, err := os.ReadFile(credentials.FederatedTokenFile)
 				if err != nil {
-					return "", fmt.Errorf("could not read workload identity token from file: %w", err)
+					return "", errors.Wrap(err, "could not read workload identity token from file")
 				}
 				return string(b), nil
 			},
-			azure.PublicCloud.ResourceManagerEndpoint,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("could not create service principal from federated token: %w", err)
-		}
-	} else {
-		servicePrincipalToken, err = adal.NewServicePrincipalToken(
-			*oauthConfig,
-			credentials.ClientID,
-			credentials.ClientSecret,
-			azure.PublicCloud.ResourceManagerEndpoint,
+			&azidentity.ClientAssertionCredentialOptions{ClientOptions: clientOptions, DisableInstanceDiscovery: disableInstanceDiscovery},
 		)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not create service principal token")
-		}
+		return credential, errors.Wrap(err, "could not create client assertion credential from federated token")
+	}
+	credential, err := azidentity.NewClientSecretCredential(
+		credentials.TenantID,
+		credentials.ClientID,
+		credentials.ClientSecret,
+		&azidentity.ClientSecretCredentialOptions{ClientOptions: clientOptions, DisableInstanceDiscovery: disableInstanceDiscovery},
+	)
+	return credential, errors.Wrap(err, "could not create client secret credential")
+}
+
+// retryStatusCodes are the status codes of failed requests that are retried by the clients. Unlike the default of the
+// Azure SDK, throttled requests (429) are not retried, since the remedies retry them after the requested delay.
+var retryStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// NewClients creates a new Clients instance using the given credentials.
+func NewClients(credentials *Credentials) (*Clients, error) {
+	return NewClientsWithOptions(credentials, nil)
+}
+
+// NewClientsWithOptions creates a new Clients instance using the given credentials and client options.
+// The cloud and API version of the given client options are determined by the credentials.
+// If the given client options don't specify the status codes of failed requests that are retried,
+// throttled requests are not retried by the clients.
+func NewClientsWithOptions(credentials *Credentials, options *arm.ClientOptions) (*Clients, error) {
+	cloudConfig, err := credentials.CloudConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	profile, err := credentials.Profile()
+	if err != nil {
+		return nil, err
+	}
+	credential, err := NewCredential(credentials, cloudConfig)
+	if err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &arm.ClientOptions{}
+	}
+	options.Cloud = *cloudConfig
+	if options.Retry.StatusCodes == nil {
+		options.Retry.StatusCodes = retryStatusCodes
 	}
-	authorizer := autorest.NewBearerAuthorizer(servicePrincipalToken)
 
 	// Create clients
-	ipAddressesClient := network.NewPublicIPAddressesClient(credentials.SubscriptionID)
-	ipAddressesClient.Authorizer = authorizer
-	loadBalancersClient := network.NewLoadBalancersClient(credentials.SubscriptionID)
-	loadBalancersClient.Authorizer = authorizer
-	vmClient := compute.NewVirtualMachinesClient(credentials.SubscriptionID)
-	vmClient.Authorizer = authorizer
+	ipAddressesClient, err := armnetwork.NewPublicIPAddressesClient(credentials.SubscriptionID, credential, profile.NetworkClientOptions(options))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create public IP addresses client")
+	}
+	loadBalancersClient, err := armnetwork.NewLoadBalancersClient(credentials.SubscriptionID, credential, profile.NetworkClientOptions(options))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create load balancers client")
+	}
+	vmClient, err := armcompute.NewVirtualMachinesClient(credentials.SubscriptionID, credential, profile.ComputeClientOptions(options))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create virtual machines client")
+	}
 
 	return &Clients{
 		PublicIPAddressesClient: PublicIPAddressesClientImpl{PublicIPAddressesClient: ipAddressesClient},
 		LoadBalancersClient:     LoadBalancersClientImpl{LoadBalancersClient: loadBalancersClient},
 		VirtualMachinesClient:   VirtualMachinesClientImpl{VirtualMachinesClient: vmClient},
+		APIProfile:              profile,
 	}, nil
 }
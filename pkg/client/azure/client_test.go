@@ -0,0 +1,248 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+const token = `{"access_token":"token","expires_in":"3600","expires_on":"1900000000","not_before":"1800000000","resource":"https://management.azure.com/","token_type":"Bearer"}`
+
+var _ = Describe("Client", func() {
+	var (
+		ctx context.Context
+
+		tokenServer   *httptest.Server
+		tokenRequests chan *http.Request
+		tokenFailures []int
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		tokenRequests = make(chan *http.Request, 10)
+		tokenFailures = nil
+		tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenRequests <- r
+			w.Header().Set("Content-Type", "application/json")
+			if len(tokenFailures) > 0 {
+				w.Header().Set("Retry-After-Ms", "1")
+				w.WriteHeader(tokenFailures[0])
+				tokenFailures = tokenFailures[1:]
+				return
+			}
+			_, _ = w.Write([]byte(token))
+		}))
+	})
+
+	AfterEach(func() {
+		tokenServer.Close()
+	})
+
+	Describe("#NewCredential", func() {
+		getToken := func(credential azcore.TokenCredential) string {
+			accessToken, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://management.core.windows.net//.default"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(accessToken.ExpiresOn).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			return accessToken.Token
+		}
+
+		It("should authenticate with the system-assigned managed identity", func() {
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getToken(credential)).To(Equal("token"))
+			var req *http.Request
+			Expect(tokenRequests).To(Receive(&req))
+			Expect(req.Header.Get("Metadata")).To(Equal("true"))
+			Expect(req.URL.Query().Get("resource")).To(Equal("https://management.core.windows.net/"))
+			Expect(req.URL.Query().Has("client_id")).To(BeFalse())
+		})
+
+		It("should authenticate with a user-assigned managed identity", func() {
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityClientID: "identity", ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getToken(credential)).To(Equal("token"))
+			var req *http.Request
+			Expect(tokenRequests).To(Receive(&req))
+			Expect(req.URL.Query().Get("client_id")).To(Equal("identity"))
+		})
+
+		It("should retry managed identity token requests if throttled", func() {
+			tokenFailures = []int{http.StatusTooManyRequests}
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getToken(credential)).To(Equal("token"))
+			Expect(tokenRequests).To(HaveLen(2))
+		})
+
+		It("should retry managed identity token requests on server errors", func() {
+			tokenFailures = []int{http.StatusInternalServerError, http.StatusServiceUnavailable}
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getToken(credential)).To(Equal("token"))
+			Expect(tokenRequests).To(HaveLen(3))
+		})
+
+		It("should fail if managed identity token requests keep failing", func() {
+			tokenFailures = []int{500, 500, 500, 500, 500, 500}
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://management.core.windows.net//.default"}})
+			Expect(err).To(HaveOccurred())
+			Expect(tokenRequests).To(HaveLen(6))
+		})
+
+		It("should not retry managed identity token requests on client errors", func() {
+			tokenFailures = []int{http.StatusBadRequest}
+			credential, err := NewCredential(&Credentials{UseManagedIdentity: true, ManagedIdentityTokenEndpoint: tokenServer.URL}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://management.core.windows.net//.default"}})
+			Expect(err).To(HaveOccurred())
+			Expect(tokenRequests).To(HaveLen(1))
+		})
+
+		It("should authenticate with a client secret", func() {
+			credential, err := NewCredential(&Credentials{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credential).To(BeAssignableToTypeOf(&azidentity.ClientSecretCredential{}))
+		})
+
+		It("should authenticate with a federated token", func() {
+			credential, err := NewCredential(&Credentials{TenantID: "tenant", ClientID: "client", FederatedTokenFile: "/var/run/secrets/token"}, &cloud.AzurePublic)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credential).To(BeAssignableToTypeOf(&azidentity.ClientAssertionCredential{}))
+		})
+	})
+
+	Describe("#NewClients", func() {
+		var (
+			server     *httptest.Server
+			requests   chan *http.Request
+			statusCode int
+			options    *arm.ClientOptions
+		)
+
+		BeforeEach(func() {
+			requests = make(chan *http.Request, 10)
+			statusCode = http.StatusOK
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests <- r
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(statusCode)
+				_, _ = w.Write([]byte(`{"name":"test"}`))
+			}))
+			options = &arm.ClientOptions{ClientOptions: azcore.ClientOptions{Transport: server.Client()}}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should create clients for the endpoint of the cloud environment", func() {
+			clients, err := NewClientsWithOptions(&Credentials{
+				UseManagedIdentity:           true,
+				ManagedIdentityTokenEndpoint: tokenServer.URL,
+				SubscriptionID:               "subscription",
+				ResourceManagerEndpoint:      server.URL,
+			}, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clients.APIProfile.Name).To(Equal(LatestAPIProfileName))
+			Expect(clients.Supports(FeatureVMReapply)).To(BeTrue())
+
+			resp, err := clients.PublicIPAddressesClient.Get(ctx, "rg", "ip", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*resp.Name).To(Equal("test"))
+			var req *http.Request
+			Expect(requests).To(Receive(&req))
+			Expect(req.URL.Path).To(Equal("/subscriptions/subscription/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/ip"))
+			Expect(req.Header.Get("Authorization")).To(Equal("Bearer token"))
+			Expect(tokenRequests).To(Receive(&req))
+			Expect(req.URL.Query().Get("resource")).To(Equal(server.URL))
+		})
+
+		It("should create clients for the API profile", func() {
+			clients, err := NewClientsWithOptions(&Credentials{
+				UseManagedIdentity:           true,
+				ManagedIdentityTokenEndpoint: tokenServer.URL,
+				SubscriptionID:               "subscription",
+				ResourceManagerEndpoint:      server.URL,
+				APIProfile:                   "2019-03-01-hybrid",
+			}, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clients.APIProfile.Name).To(Equal(Hybrid20190301APIProfileName))
+			Expect(clients.Supports(FeatureVMReapply)).To(BeFalse())
+
+			_, err = clients.PublicIPAddressesClient.Get(ctx, "rg", "ip", nil)
+			Expect(err).NotTo(HaveOccurred())
+			var req *http.Request
+			Expect(requests).To(Receive(&req))
+			Expect(req.URL.Query().Get("api-version")).To(Equal("2017-10-01"))
+
+			_, err = clients.VirtualMachinesClient.Get(ctx, "rg", "vm", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Receive(&req))
+			Expect(req.URL.Path).To(Equal("/subscriptions/subscription/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm"))
+			Expect(req.URL.Query().Get("api-version")).To(Equal("2017-12-01"))
+		})
+
+		It("should not retry throttled requests", func() {
+			statusCode = http.StatusTooManyRequests
+			clients, err := NewClientsWithOptions(&Credentials{
+				UseManagedIdentity:           true,
+				ManagedIdentityTokenEndpoint: tokenServer.URL,
+				SubscriptionID:               "subscription",
+				ResourceManagerEndpoint:      server.URL,
+			}, options)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = clients.PublicIPAddressesClient.Get(ctx, "rg", "ip", nil)
+			Expect(err).To(BeAssignableToTypeOf(&azcore.ResponseError{}))
+			Expect(err.(*azcore.ResponseError).StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(requests).To(HaveLen(1))
+		})
+
+		It("should create clients authenticating with a client secret", func() {
+			clients, err := NewClients(&Credentials{Cloud: "AzureUSGovernmentCloud", TenantID: "tenant", ClientID: "client", ClientSecret: "secret", SubscriptionID: "subscription"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clients.PublicIPAddressesClient).To(BeAssignableToTypeOf(PublicIPAddressesClientImpl{}))
+			Expect(clients.LoadBalancersClient).To(BeAssignableToTypeOf(LoadBalancersClientImpl{}))
+			Expect(clients.VirtualMachinesClient).To(BeAssignableToTypeOf(VirtualMachinesClientImpl{}))
+		})
+
+		It("should fail if the API profile is unknown", func() {
+			_, err := NewClients(&Credentials{APIProfile: "2000-01-01-hybrid"})
+			Expect(err).To(MatchError("unknown Azure API profile 2000-01-01-hybrid"))
+		})
+	})
+})
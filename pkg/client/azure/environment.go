@@ -0,0 +1,163 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AzureStackCloudName is the name of the Azure Stack Hub cloud environment.
+	// Its endpoints are retrieved from the metadata endpoint of its Azure Resource Manager endpoint.
+	AzureStackCloudName = "AzureStackCloud"
+
+	// adfsTenantID is the tenant ID used with Azure Stack Hub deployments using AD FS as identity provider.
+	adfsTenantID = "adfs"
+	// metadataEndpointPath is the path of the metadata endpoint of an Azure Resource Manager endpoint.
+	metadataEndpointPath = "/metadata/endpoints?api-version=2015-01-01"
+	// metadataRequestTimeout is the timeout for retrieving the metadata of an Azure Resource Manager endpoint.
+	metadataRequestTimeout = 30 * time.Second
+)
+
+// metadataEndpoints are the endpoints returned by the metadata endpoint of an Azure Resource Manager endpoint.
+type metadataEndpoints struct {
+	Authentication struct {
+		LoginEndpoint string   `json:"loginEndpoint"`
+		Audiences     []string `json:"audiences"`
+	} `json:"authentication"`
+}
+
+// cloudConfigurations are the configurations of the well-known Azure clouds by name.
+var cloudConfigurations = map[string]cloud.Configuration{
+	"AzurePublicCloud":       cloud.AzurePublic,
+	"AzureChinaCloud":        cloud.AzureChina,
+	"AzureUSGovernmentCloud": cloud.AzureGovernment,
+}
+
+// CloudConfiguration returns the configuration of the Azure cloud of these credentials, with any endpoint overrides applied.
+// For AzureStackCloud, the configuration is built from the metadata endpoint of the Azure Resource Manager endpoint.
+func (c *Credentials) CloudConfiguration() (*cloud.Configuration, error) {
+	if strings.EqualFold(c.Cloud, AzureStackCloudName) {
+		return c.stackCloudConfiguration()
+	}
+
+	config := cloud.AzurePublic
+	if len(c.Cloud) > 0 {
+		var ok bool
+		if config, ok = getCloudConfiguration(c.Cloud); !ok {
+			return nil, errors.Errorf("could not determine Azure cloud environment %s", c.Cloud)
+		}
+	}
+	config = copyCloudConfiguration(config)
+	if len(c.ResourceManagerEndpoint) > 0 {
+		config.Services[cloud.ResourceManager] = cloud.ServiceConfiguration{
+			Endpoint: c.ResourceManagerEndpoint,
+			Audience: c.ResourceManagerEndpoint,
+		}
+	}
+	if len(c.ActiveDirectoryEndpoint) > 0 {
+		config.ActiveDirectoryAuthorityHost = c.ActiveDirectoryEndpoint
+	}
+	return &config, nil
+}
+
+// Profile returns the API profile of these credentials.
+func (c *Credentials) Profile() (*APIProfile, error) {
+	name := c.APIProfile
+	if len(name) == 0 {
+		name = LatestAPIProfileName
+		if strings.EqualFold(c.Cloud, AzureStackCloudName) {
+			name = Hybrid20200901APIProfileName
+		}
+	}
+	return GetAPIProfile(name)
+}
+
+func (c *Credentials) stackCloudConfiguration() (*cloud.Configuration, error) {
+	if len(c.ResourceManagerEndpoint) == 0 {
+		return nil, errors.Errorf("resource manager endpoint is required for Azure cloud environment %s", AzureStackCloudName)
+	}
+	metadata, err := getMetadataEndpoints(c.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get metadata of Azure Resource Manager endpoint %s", c.ResourceManagerEndpoint)
+	}
+
+	config := cloud.Configuration{
+		ActiveDirectoryAuthorityHost: metadata.Authentication.LoginEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Endpoint: c.ResourceManagerEndpoint,
+				Audience: metadata.Authentication.Audiences[0],
+			},
+		},
+	}
+	if strings.EqualFold(c.TenantID, adfsTenantID) {
+		// With AD FS, the login endpoint returned by the metadata endpoint already ends with the tenant ID,
+		// which is appended again when requesting tokens
+		config.ActiveDirectoryAuthorityHost = strings.TrimSuffix(strings.TrimSuffix(config.ActiveDirectoryAuthorityHost, "/"), "/"+adfsTenantID)
+	}
+	if len(c.ActiveDirectoryEndpoint) > 0 {
+		config.ActiveDirectoryAuthorityHost = c.ActiveDirectoryEndpoint
+	}
+	return &config, nil
+}
+
+// getCloudConfiguration returns the configuration of the well-known Azure cloud with the given case-insensitive name.
+func getCloudConfiguration(name string) (cloud.Configuration, bool) {
+	for n, config := range cloudConfigurations {
+		if strings.EqualFold(n, name) {
+			return config, true
+		}
+	}
+	return cloud.Configuration{}, false
+}
+
+// copyCloudConfiguration returns a copy of the given cloud configuration that can be modified safely.
+func copyCloudConfiguration(config cloud.Configuration) cloud.Configuration {
+	services := make(map[cloud.ServiceName]cloud.ServiceConfiguration, len(config.Services))
+	for name, service := range config.Services {
+		services[name] = service
+	}
+	config.Services = services
+	return config
+}
+
+// getMetadataEndpoints retrieves the metadata of the given Azure Resource Manager endpoint.
+func getMetadataEndpoints(resourceManagerEndpoint string) (*metadataEndpoints, error) {
+	client := &http.Client{Timeout: metadataRequestTimeout}
+	resp, err := client.Get(strings.TrimSuffix(resourceManagerEndpoint, "/") + metadataEndpointPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send metadata request")
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("metadata request failed with status %s", resp.Status)
+	}
+
+	metadata := &metadataEndpoints{}
+	if err := json.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, errors.Wrap(err, "could not decode metadata from JSON")
+	}
+	if len(metadata.Authentication.LoginEndpoint) == 0 || len(metadata.Authentication.Audiences) == 0 {
+		return nil, errors.New("metadata contains no login endpoint or audiences")
+	}
+	return metadata, nil
+}
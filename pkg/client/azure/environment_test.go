@@ -0,0 +1,158 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+var _ = Describe("Credentials", func() {
+	Describe("#CloudConfiguration", func() {
+		It("should return the public cloud environment if no cloud is specified", func() {
+			config, err := (&Credentials{}).CloudConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*config).To(Equal(cloud.AzurePublic))
+		})
+
+		It("should return the specified cloud environment", func() {
+			config, err := (&Credentials{Cloud: "AzureChinaCloud"}).CloudConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*config).To(Equal(cloud.AzureChina))
+
+			config, err = (&Credentials{Cloud: "azureusgovernmentcloud"}).CloudConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*config).To(Equal(cloud.AzureGovernment))
+		})
+
+		It("should apply endpoint overrides", func() {
+			config, err := (&Credentials{
+				Cloud:                   "AzureChinaCloud",
+				ResourceManagerEndpoint: "https://management.example.com/",
+				ActiveDirectoryEndpoint: "https://login.example.com/",
+			}).CloudConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Services).To(Equal(map[cloud.ServiceName]cloud.ServiceConfiguration{
+				cloud.ResourceManager: {Endpoint: "https://management.example.com/", Audience: "https://management.example.com/"},
+			}))
+			Expect(config.ActiveDirectoryAuthorityHost).To(Equal("https://login.example.com/"))
+			Expect(cloud.AzureChina.Services[cloud.ResourceManager].Endpoint).To(Equal("https://management.chinacloudapi.cn"))
+		})
+
+		It("should fail if the cloud is unknown", func() {
+			_, err := (&Credentials{Cloud: "AzureMoonCloud"}).CloudConfiguration()
+			Expect(err).To(MatchError(ContainSubstring("could not determine Azure cloud environment AzureMoonCloud")))
+		})
+
+		Context("AzureStackCloud", func() {
+			var (
+				server   *httptest.Server
+				status   int
+				metadata string
+			)
+
+			BeforeEach(func() {
+				status = http.StatusOK
+				metadata = `{
+  "authentication": {
+    "loginEndpoint": "https://adfs.local.azurestack.external/adfs",
+    "audiences": ["https://management.adfs.azurestack.external/00000000-0000-0000-0000-000000000000"]
+  }
+}`
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					Expect(r.URL.Path).To(Equal("/metadata/endpoints"))
+					w.WriteHeader(status)
+					_, _ = w.Write([]byte(metadata))
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("should build the configuration from the metadata endpoint", func() {
+				config, err := (&Credentials{Cloud: "AzureStackCloud", ResourceManagerEndpoint: server.URL, TenantID: "tenant"}).CloudConfiguration()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*config).To(Equal(cloud.Configuration{
+					ActiveDirectoryAuthorityHost: "https://adfs.local.azurestack.external/adfs",
+					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+						cloud.ResourceManager: {
+							Endpoint: server.URL,
+							Audience: "https://management.adfs.azurestack.external/00000000-0000-0000-0000-000000000000",
+						},
+					},
+				}))
+			})
+
+			It("should remove the tenant from the login endpoint with AD FS", func() {
+				config, err := (&Credentials{Cloud: "AzureStackCloud", ResourceManagerEndpoint: server.URL, TenantID: "adfs"}).CloudConfiguration()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ActiveDirectoryAuthorityHost).To(Equal("https://adfs.local.azurestack.external"))
+			})
+
+			It("should apply the active directory endpoint override", func() {
+				config, err := (&Credentials{Cloud: "AzureStackCloud", ResourceManagerEndpoint: server.URL, ActiveDirectoryEndpoint: "https://login.example.com/"}).CloudConfiguration()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ActiveDirectoryAuthorityHost).To(Equal("https://login.example.com/"))
+			})
+
+			It("should fail if the resource manager endpoint is not specified", func() {
+				_, err := (&Credentials{Cloud: "AzureStackCloud"}).CloudConfiguration()
+				Expect(err).To(MatchError("resource manager endpoint is required for Azure cloud environment AzureStackCloud"))
+			})
+
+			It("should fail if the metadata request fails", func() {
+				status = http.StatusNotFound
+
+				_, err := (&Credentials{Cloud: "AzureStackCloud", ResourceManagerEndpoint: server.URL}).CloudConfiguration()
+				Expect(err).To(MatchError(ContainSubstring("metadata request failed with status 404 Not Found")))
+			})
+
+			It("should fail if the metadata contains no audiences", func() {
+				metadata = `{"authentication": {"loginEndpoint": "https://login.local.azurestack.external/"}}`
+
+				_, err := (&Credentials{Cloud: "AzureStackCloud", ResourceManagerEndpoint: server.URL}).CloudConfiguration()
+				Expect(err).To(MatchError(ContainSubstring("metadata contains no login endpoint or audiences")))
+			})
+		})
+	})
+
+	Describe("#Profile", func() {
+		It("should return the latest API profile if no API profile is specified", func() {
+			profile, err := (&Credentials{}).Profile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.Name).To(Equal(LatestAPIProfileName))
+		})
+
+		It("should return the 2020-09-01-hybrid API profile for AzureStackCloud if no API profile is specified", func() {
+			profile, err := (&Credentials{Cloud: "AzureStackCloud"}).Profile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.Name).To(Equal(Hybrid20200901APIProfileName))
+		})
+
+		It("should return the specified API profile", func() {
+			profile, err := (&Credentials{Cloud: "AzureStackCloud", APIProfile: "2019-03-01-hybrid"}).Profile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.Name).To(Equal(Hybrid20190301APIProfileName))
+		})
+	})
+})
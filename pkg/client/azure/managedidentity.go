@@ -0,0 +1,66 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/pkg/errors"
+)
+
+// imdsTokenEndpoint is the token endpoint of the Azure Instance Metadata Service (IMDS).
+const imdsTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// newManagedIdentityCredential creates an azidentity.ManagedIdentityCredential for the system-assigned or user-assigned
+// managed identity. Token requests are retried with exponential backoff on throttling and server errors as recommended
+// for IMDS. azidentity only supports overriding the token endpoint via environment variables, so if an endpoint is given,
+// requests to the IMDS token endpoint are redirected to it by the transport.
+func newManagedIdentityCredential(endpoint, clientID string) (azcore.TokenCredential, error) {
+	options := &azidentity.ManagedIdentityCredentialOptions{}
+	if len(clientID) > 0 {
+		options.ID = azidentity.ClientID(clientID)
+	}
+	if len(endpoint) > 0 {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse managed identity token endpoint")
+		}
+		options.Transport = &tokenEndpointTransport{endpoint: endpointURL, transport: http.DefaultClient}
+	}
+	credential, err := azidentity.NewManagedIdentityCredential(options)
+	return credential, errors.Wrap(err, "could not create managed identity credential")
+}
+
+// tokenEndpointTransport is a policy.Transporter that redirects requests to the IMDS token endpoint to another endpoint.
+type tokenEndpointTransport struct {
+	endpoint  *url.URL
+	transport policy.Transporter
+}
+
+// Do sends the given request, redirecting it to the overridden endpoint if it is sent to the IMDS token endpoint.
+func (t *tokenEndpointTransport) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme+"://"+req.URL.Host+req.URL.Path == imdsTokenEndpoint {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = t.endpoint.Scheme
+		req.URL.Host = t.endpoint.Host
+		req.URL.Path = t.endpoint.Path
+		req.Host = t.endpoint.Host
+	}
+	return t.transport.Do(req)
+}
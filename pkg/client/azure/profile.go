@@ -0,0 +1,123 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// LatestAPIProfileName is the name of the API profile with the default API versions of the Azure SDK packages used by the remedy controller.
+	LatestAPIProfileName = "latest"
+	// Hybrid20200901APIProfileName is the name of the 2020-09-01-hybrid API profile supported by Azure Stack Hub.
+	Hybrid20200901APIProfileName = "2020-09-01-hybrid"
+	// Hybrid20190301APIProfileName is the name of the 2019-03-01-hybrid API profile supported by Azure Stack Hub.
+	Hybrid20190301APIProfileName = "2019-03-01-hybrid"
+)
+
+// Feature is a feature of the Azure API that is not supported by all API profiles.
+type Feature string
+
+const (
+	// FeatureVMReapply is the reapply operation of virtual machines.
+	FeatureVMReapply Feature = "VMReapply"
+)
+
+// APIProfile is a set of API versions of the Azure services used by the remedy controller.
+type APIProfile struct {
+	// Name is the name of the API profile.
+	Name string
+	// NetworkAPIVersion is the API version of the Azure network service.
+	// If empty, the default API version of the Azure SDK network package is used.
+	NetworkAPIVersion string
+	// ComputeAPIVersion is the API version of the Azure compute service.
+	// If empty, the default API version of the Azure SDK compute package is used.
+	ComputeAPIVersion string
+	// Features are the features supported by the API profile.
+	Features sets.Set[Feature]
+}
+
+var apiProfiles = map[string]*APIProfile{
+	LatestAPIProfileName: {
+		Name:     LatestAPIProfileName,
+		Features: sets.New(FeatureVMReapply),
+	},
+	Hybrid20200901APIProfileName: {
+		Name:              Hybrid20200901APIProfileName,
+		NetworkAPIVersion: "2018-11-01",
+		ComputeAPIVersion: "2020-06-01",
+		Features:          sets.New(FeatureVMReapply),
+	},
+	Hybrid20190301APIProfileName: {
+		Name:              Hybrid20190301APIProfileName,
+		NetworkAPIVersion: "2017-10-01",
+		ComputeAPIVersion: "2017-12-01",
+		Features:          sets.New[Feature](),
+	},
+}
+
+// GetAPIProfile returns the API profile with the given name.
+func GetAPIProfile(name string) (*APIProfile, error) {
+	profile, ok := apiProfiles[name]
+	if !ok {
+		return nil, errors.Errorf("unknown Azure API profile %s", name)
+	}
+	return profile, nil
+}
+
+// Supports returns true if the given feature is supported by this API profile.
+func (p *APIProfile) Supports(feature Feature) bool {
+	return p.Features.Has(feature)
+}
+
+// NetworkClientOptions returns a copy of the given options of clients of the Azure network service
+// that uses the network API version of this API profile.
+func (p *APIProfile) NetworkClientOptions(options *arm.ClientOptions) *arm.ClientOptions {
+	return withAPIVersion(options, p.NetworkAPIVersion)
+}
+
+// ComputeClientOptions returns a copy of the given options of clients of the Azure compute service
+// that uses the compute API version of this API profile.
+func (p *APIProfile) ComputeClientOptions(options *arm.ClientOptions) *arm.ClientOptions {
+	return withAPIVersion(options, p.ComputeAPIVersion)
+}
+
+// withAPIVersion returns a copy of the given client options with the given API version, unless it is empty.
+// The API version only applies to the operations of the client, and not to e.g. resource provider registrations.
+func withAPIVersion(options *arm.ClientOptions, apiVersion string) *arm.ClientOptions {
+	o := arm.ClientOptions{}
+	if options != nil {
+		o = *options
+	}
+	if len(apiVersion) > 0 {
+		o.APIVersion = apiVersion
+	}
+	return &o
+}
+
+// FeatureNotSupportedError is returned if a feature is not supported by the API profile used.
+type FeatureNotSupportedError struct {
+	// Feature is the feature that is not supported.
+	Feature Feature
+	// APIProfile is the name of the API profile used.
+	APIProfile string
+}
+
+// Error returns the error message.
+func (e *FeatureNotSupportedError) Error() string {
+	return "feature " + string(e.Feature) + " is not supported by Azure API profile " + e.APIProfile
+}
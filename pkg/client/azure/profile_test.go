@@ -0,0 +1,73 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+var _ = Describe("APIProfile", func() {
+	Describe("#GetAPIProfile", func() {
+		It("should return the API profile with the given name", func() {
+			profile, err := GetAPIProfile("2019-03-01-hybrid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.NetworkAPIVersion).To(Equal("2017-10-01"))
+			Expect(profile.ComputeAPIVersion).To(Equal("2017-12-01"))
+		})
+
+		It("should fail if the API profile is unknown", func() {
+			_, err := GetAPIProfile("2000-01-01-hybrid")
+			Expect(err).To(MatchError("unknown Azure API profile 2000-01-01-hybrid"))
+		})
+	})
+
+	Describe("#Supports", func() {
+		It("should return true only if the feature is supported", func() {
+			latest, _ := GetAPIProfile(LatestAPIProfileName)
+			Expect(latest.Supports(FeatureVMReapply)).To(BeTrue())
+			hybrid, _ := GetAPIProfile(Hybrid20190301APIProfileName)
+			Expect(hybrid.Supports(FeatureVMReapply)).To(BeFalse())
+		})
+	})
+
+	Describe("#NetworkClientOptions", func() {
+		It("should set the network API version of the API profile", func() {
+			profile, _ := GetAPIProfile(Hybrid20190301APIProfileName)
+			options := &arm.ClientOptions{ClientOptions: azcore.ClientOptions{APIVersion: "2023-05-01"}, DisableRPRegistration: true}
+
+			Expect(profile.NetworkClientOptions(options)).To(Equal(&arm.ClientOptions{ClientOptions: azcore.ClientOptions{APIVersion: "2017-10-01"}, DisableRPRegistration: true}))
+			Expect(options.APIVersion).To(Equal("2023-05-01"))
+		})
+
+		It("should not change the API version for the latest API profile", func() {
+			profile, _ := GetAPIProfile(LatestAPIProfileName)
+
+			Expect(profile.NetworkClientOptions(nil)).To(Equal(&arm.ClientOptions{}))
+		})
+	})
+
+	Describe("#ComputeClientOptions", func() {
+		It("should set the compute API version of the API profile", func() {
+			profile, _ := GetAPIProfile(Hybrid20200901APIProfileName)
+
+			Expect(profile.ComputeClientOptions(&arm.ClientOptions{})).To(Equal(&arm.ClientOptions{ClientOptions: azcore.ClientOptions{APIVersion: "2020-06-01"}}))
+		})
+	})
+})
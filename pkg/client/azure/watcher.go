@@ -0,0 +1,164 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+// ClientsProvider provides Azure clients.
+type ClientsProvider interface {
+	// Clients returns the current Azure clients.
+	Clients() *Clients
+}
+
+// Clients implements ClientsProvider for clients that are never rebuilt by returning themselves.
+func (c *Clients) Clients() *Clients {
+	return c
+}
+
+// NewClientsFunc creates a new Clients instance using the given credentials.
+type NewClientsFunc func(credentials *Credentials) (*Clients, error)
+
+// CredentialsWatcher watches an infrastructure configuration file and rebuilds the Azure clients
+// whenever the credentials in it change, e.g. because they have been rotated.
+type CredentialsWatcher interface {
+	ClientsProvider
+	// Credentials returns the current credentials.
+	Credentials() *Credentials
+	// Start watches the infrastructure configuration file until the given context is done.
+	Start(ctx context.Context) error
+	// NeedLeaderElection returns false, since the credentials should be reloaded whether or not this instance is the leader.
+	NeedLeaderElection() bool
+	// Reload reloads the infrastructure configuration file and rebuilds the Azure clients if the credentials have changed.
+	Reload() error
+}
+
+type credentialsWatcher struct {
+	path       string
+	newClients NewClientsFunc
+	logger     logr.Logger
+	state      atomic.Pointer[credentialsState]
+	mutex      sync.Mutex
+}
+
+type credentialsState struct {
+	credentials *Credentials
+	clients     *Clients
+}
+
+// NewCredentialsWatcher creates a new CredentialsWatcher for the infrastructure configuration file at the given path.
+// The file is read and the initial Azure clients are created using the given function immediately.
+func NewCredentialsWatcher(path string, newClients NewClientsFunc, logger logr.Logger) (CredentialsWatcher, error) {
+	credentials, err := ReadConfig(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read Azure credentials from infrastructure configuration file %s", path)
+	}
+	clients, err := newClients(credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Azure clients")
+	}
+
+	w := &credentialsWatcher{
+		path:       path,
+		newClients: newClients,
+		logger:     logger,
+	}
+	w.state.Store(&credentialsState{credentials: credentials, clients: clients})
+	return w, nil
+}
+
+// Clients returns the current Azure clients.
+func (w *credentialsWatcher) Clients() *Clients {
+	return w.state.Load().clients
+}
+
+// Credentials returns the current credentials.
+func (w *credentialsWatcher) Credentials() *Credentials {
+	return w.state.Load().credentials
+}
+
+// Start watches the directory of the infrastructure configuration file until the given context is done.
+// The directory rather than the file itself is watched, since a mounted Secret is updated by replacing a symlink.
+func (w *credentialsWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "could not create file watcher")
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return errors.Wrapf(err, "could not watch directory of infrastructure configuration file %s", w.path)
+	}
+
+	w.logger.Info("Watching infrastructure configuration file", "path", w.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if err := w.Reload(); err != nil {
+				w.logger.Error(err, "Could not reload infrastructure configuration file", "path", w.path)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Error(err, "Error watching infrastructure configuration file", "path", w.path)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, since the credentials should be reloaded whether or not this instance is the leader.
+func (w *credentialsWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload reloads the infrastructure configuration file and rebuilds the Azure clients if the credentials have changed.
+// If the file can't be read or the clients can't be created, the current credentials and clients are kept.
+func (w *credentialsWatcher) Reload() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	credentials, err := ReadConfig(w.path)
+	if err != nil {
+		return errors.Wrapf(err, "could not read Azure credentials from infrastructure configuration file %s", w.path)
+	}
+	current := w.state.Load()
+	if reflect.DeepEqual(credentials, current.credentials) {
+		return nil
+	}
+	if credentials.ResourceGroup != current.credentials.ResourceGroup {
+		return errors.New("resource group cannot be changed at runtime")
+	}
+	clients, err := w.newClients(credentials)
+	if err != nil {
+		return errors.Wrap(err, "could not create Azure clients")
+	}
+
+	w.logger.Info("Azure credentials changed, Azure clients rebuilt", "path", w.path)
+	w.state.Store(&credentialsState{credentials: credentials, clients: clients})
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	. "github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+var _ = Describe("CredentialsWatcher", func() {
+	const (
+		credentials1 = `aadClientId: client
+aadClientSecret: secret1
+tenantId: tenant
+subscriptionId: subscription
+resourceGroup: group
+`
+		credentials2 = `aadClientId: client
+aadClientSecret: secret2
+tenantId: tenant
+subscriptionId: subscription
+resourceGroup: group
+`
+	)
+
+	var (
+		path       string
+		newClients NewClientsFunc
+		watcher    CredentialsWatcher
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "credentials.yaml")
+		Expect(os.WriteFile(path, []byte(credentials1), 0600)).To(Succeed())
+
+		newClients = func(credentials *Credentials) (*Clients, error) {
+			if credentials.ClientSecret == "invalid" {
+				return nil, errors.New("test")
+			}
+			return &Clients{}, nil
+		}
+		var err error
+		watcher, err = NewCredentialsWatcher(path, newClients, log.Log)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("#NewCredentialsWatcher", func() {
+		It("should read the initial credentials and create the initial clients", func() {
+			Expect(watcher.Credentials().ClientSecret).To(Equal("secret1"))
+			Expect(watcher.Clients()).NotTo(BeNil())
+		})
+
+		It("should fail if the infrastructure configuration file can't be read", func() {
+			_, err := NewCredentialsWatcher(filepath.Join(filepath.Dir(path), "missing.yaml"), newClients, log.Log)
+			Expect(err).To(MatchError(ContainSubstring("could not read Azure credentials from infrastructure configuration file")))
+		})
+	})
+
+	Describe("#Reload", func() {
+		It("should rebuild the clients if the credentials have changed", func() {
+			initialClients := watcher.Clients()
+			Expect(os.WriteFile(path, []byte(credentials2), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(Succeed())
+			Expect(watcher.Credentials().ClientSecret).To(Equal("secret2"))
+			Expect(watcher.Clients()).NotTo(BeIdenticalTo(initialClients))
+		})
+
+		It("should not rebuild the clients if the credentials have not changed", func() {
+			initialClients := watcher.Clients()
+
+			Expect(watcher.Reload()).To(Succeed())
+			Expect(watcher.Clients()).To(BeIdenticalTo(initialClients))
+		})
+
+		It("should keep the current clients if the clients can't be created", func() {
+			initialClients := watcher.Clients()
+			Expect(os.WriteFile(path, []byte("aadClientSecret: invalid\nresourceGroup: group\n"), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(MatchError("could not create Azure clients: test"))
+			Expect(watcher.Credentials().ClientSecret).To(Equal("secret1"))
+			Expect(watcher.Clients()).To(BeIdenticalTo(initialClients))
+		})
+
+		It("should keep the current clients if the infrastructure configuration file is invalid", func() {
+			initialClients := watcher.Clients()
+			Expect(os.WriteFile(path, []byte("aadClientSecret: ["), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(MatchError(ContainSubstring("could not read Azure credentials from infrastructure configuration file")))
+			Expect(watcher.Clients()).To(BeIdenticalTo(initialClients))
+		})
+
+		It("should fail if the resource group has changed", func() {
+			Expect(os.WriteFile(path, []byte("aadClientSecret: secret2\nresourceGroup: other\n"), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(MatchError("resource group cannot be changed at runtime"))
+			Expect(watcher.Credentials().ClientSecret).To(Equal("secret1"))
+		})
+	})
+
+	Describe("#Start", func() {
+		It("should rebuild the clients if the infrastructure configuration file is replaced", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(watcher.Start(ctx)).To(Succeed())
+			}()
+
+			// Replace the infrastructure configuration file like a Secret update, until the watcher has started and picked it up
+			tmpPath := path + ".tmp"
+			Eventually(func(g Gomega) {
+				g.Expect(os.WriteFile(tmpPath, []byte(credentials2), 0600)).To(Succeed())
+				g.Expect(os.Rename(tmpPath, path)).To(Succeed())
+				g.Expect(watcher.Credentials().ClientSecret).To(Equal("secret2"))
+			}).Should(Succeed())
+		})
+	})
+})
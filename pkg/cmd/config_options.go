@@ -15,12 +15,18 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
 	"github.com/gardener/remedy-controller/pkg/apis/config"
 	confighelper "github.com/gardener/remedy-controller/pkg/apis/config/helper"
+	"github.com/gardener/remedy-controller/pkg/apis/config/validation"
 )
 
 // ConfigOptions are command line options that can be set for config.ControllerConfiguration.
@@ -35,13 +41,15 @@ type ConfigOptions struct {
 type Config struct {
 	// Config is the controller configuration.
 	Config *config.ControllerConfiguration
+	// Hash is the hex-encoded SHA-256 hash of the contents of the config file.
+	Hash string
 }
 
-func (c *ConfigOptions) buildConfig() (*config.ControllerConfiguration, error) {
+func (c *ConfigOptions) buildConfig() (*Config, error) {
 	if len(c.ConfigFilePath) == 0 {
 		return nil, fmt.Errorf("config file path not set")
 	}
-	return confighelper.LoadFromFile(c.ConfigFilePath)
+	return LoadConfigFile(c.ConfigFilePath)
 }
 
 // Complete implements RESTCompleter.Complete.
@@ -51,7 +59,7 @@ func (c *ConfigOptions) Complete() error {
 		return err
 	}
 
-	c.config = &Config{config}
+	c.config = config
 	return nil
 }
 
@@ -60,6 +68,24 @@ func (c *ConfigOptions) Completed() *Config {
 	return c.config
 }
 
+// LoadConfigFile loads and validates a completed controller configuration from the given config file.
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	config, err := confighelper.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	if errs := validation.ValidateControllerConfiguration(config); len(errs) > 0 {
+		return nil, errors.Wrap(errs.ToAggregate(), "invalid controller configuration")
+	}
+
+	hash := sha256.Sum256(data)
+	return &Config{Config: config, Hash: hex.EncodeToString(hash[:])}, nil
+}
+
 // AddFlags implements Flagger.AddFlags.
 func (c *ConfigOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ConfigFilePath, "config-file", "", "The path to the controller manager configuration file.")
@@ -78,15 +104,59 @@ func (c *Config) Options() config.ControllerConfiguration {
 }
 
 // ApplyAzureOrphanedPublicIPRemedy sets the given Azure orphaned public IP remedy configuration to that of this Config.
+// If dry-run mode is enabled globally, it is also enabled for the remedy.
 func (c *Config) ApplyAzureOrphanedPublicIPRemedy(cfg *config.AzureOrphanedPublicIPRemedyConfiguration) {
 	if c.Config.Azure != nil && c.Config.Azure.OrphanedPublicIPRemedy != nil {
 		*cfg = *c.Config.Azure.OrphanedPublicIPRemedy
 	}
+	if c.Config.DryRun {
+		cfg.DryRun = true
+	}
 }
 
 // ApplyAzureFailedVMRemedy sets the given Azure failed VM remedy configuration to that of this Config.
+// If dry-run mode is enabled globally, it is also enabled for the remedy.
 func (c *Config) ApplyAzureFailedVMRemedy(cfg *config.AzureFailedVMRemedyConfiguration) {
 	if c.Config.Azure != nil && c.Config.Azure.FailedVMRemedy != nil {
 		*cfg = *c.Config.Azure.FailedVMRemedy
 	}
+	if c.Config.DryRun {
+		cfg.DryRun = true
+	}
+}
+
+// AzureRateLimiter returns the configuration of the rate limiter for Azure API requests of this Config, or nil if not specified.
+func (c *Config) AzureRateLimiter() *config.AzureRateLimiterConfiguration {
+	if c.Config.Azure == nil {
+		return nil
+	}
+	return c.Config.Azure.RateLimiter
+}
+
+// AzureDestructiveActionBudget returns the configuration of the budget for destructive actions of this Config, or nil if not specified.
+func (c *Config) AzureDestructiveActionBudget() *config.AzureDestructiveActionBudgetConfiguration {
+	if c.Config.Azure == nil {
+		return nil
+	}
+	return c.Config.Azure.DestructiveActionBudget
+}
+
+// AzureTargets returns the target clusters of the Azure remedy controller of this Config, or nil if not specified.
+func (c *Config) AzureTargets() []config.AzureTargetConfiguration {
+	if c.Config.Azure == nil {
+		return nil
+	}
+	return c.Config.Azure.Targets
+}
+
+// Notifications returns the configuration for notifications about remedy outcomes of this Config, or nil if not specified.
+func (c *Config) Notifications() *config.NotificationsConfiguration {
+	return c.Config.Notifications
+}
+
+// ApplyAzureRemedyActions sets the given Azure remedy actions configuration to that of this Config.
+func (c *Config) ApplyAzureRemedyActions(cfg *config.AzureRemedyActionsConfiguration) {
+	if c.Config.Azure != nil && c.Config.Azure.RemedyActions != nil {
+		*cfg = *c.Config.Azure.RemedyActions
+	}
 }
@@ -0,0 +1,178 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// ConfigHashGauge is a global gauge for the hash of the currently loaded config file.
+	ConfigHashGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "config_hash",
+			Help: "Hash of the currently loaded config file",
+		},
+	)
+
+	// ConfigLastReloadSuccessfulGauge is a global gauge for whether the last attempt to reload the config file was successful.
+	ConfigLastReloadSuccessfulGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "config_last_reload_successful",
+			Help: "Whether the last attempt to reload the config file was successful",
+		},
+	)
+)
+
+// ConfigWatcher watches a config file and notifies subscribers whenever its contents change.
+type ConfigWatcher interface {
+	manager.Runnable
+	// Subscribe registers the given function to be called with the new config whenever the config file changes.
+	// The function should return an error if it rejects the new config.
+	Subscribe(fn func(config *Config) error)
+	// Reload reloads the config file and notifies subscribers if its contents have changed.
+	Reload() error
+}
+
+type configWatcher struct {
+	path                      string
+	config                    *Config
+	subscribers               []func(config *Config) error
+	hashGauge                 prometheus.Gauge
+	lastReloadSuccessfulGauge prometheus.Gauge
+	logger                    logr.Logger
+	mutex                     sync.Mutex
+}
+
+// NewConfigWatcher creates a new ConfigWatcher for the config file at the given path, which has been initially loaded as the given config.
+func NewConfigWatcher(path string, config *Config, hashGauge, lastReloadSuccessfulGauge prometheus.Gauge, logger logr.Logger) ConfigWatcher {
+	hashGauge.Set(hashValue(config.Hash))
+	lastReloadSuccessfulGauge.Set(1)
+	return &configWatcher{
+		path:                      path,
+		config:                    config,
+		hashGauge:                 hashGauge,
+		lastReloadSuccessfulGauge: lastReloadSuccessfulGauge,
+		logger:                    logger,
+	}
+}
+
+// Start watches the directory of the config file until the given context is done.
+// The directory rather than the file itself is watched, since a mounted ConfigMap is updated by replacing a symlink.
+func (w *configWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "could not create file watcher")
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return errors.Wrapf(err, "could not watch directory of config file %s", w.path)
+	}
+
+	w.logger.Info("Watching config file", "path", w.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if err := w.Reload(); err != nil {
+				w.logger.Error(err, "Could not reload config file", "path", w.path)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Error(err, "Error watching config file", "path", w.path)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, since the config should be reloaded whether or not this instance is the leader.
+func (w *configWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Subscribe registers the given function to be called with the new config whenever the config file changes.
+// The function should return an error if it rejects the new config.
+func (w *configWatcher) Subscribe(fn func(config *Config) error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload reloads the config file and notifies subscribers if its contents have changed.
+// If the config file can't be loaded, or any subscriber rejects the new config, the current config is kept,
+// so that the metrics don't report a config that is not in effect, and loading the same config file again is retried.
+func (w *configWatcher) Reload() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	config, err := LoadConfigFile(w.path)
+	if err != nil {
+		w.lastReloadSuccessfulGauge.Set(0)
+		return errors.Wrapf(err, "could not load config file %s", w.path)
+	}
+	if config.Hash == w.config.Hash {
+		w.lastReloadSuccessfulGauge.Set(1)
+		return nil
+	}
+
+	w.logger.Info("Config file changed", "path", w.path, "hash", config.Hash)
+	var errs []error
+	for _, fn := range w.subscribers {
+		if err := fn(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		w.lastReloadSuccessfulGauge.Set(0)
+		return errors.Wrapf(utilerrors.NewAggregate(errs), "could not apply config file %s", w.path)
+	}
+	w.config = config
+	w.hashGauge.Set(hashValue(config.Hash))
+	w.lastReloadSuccessfulGauge.Set(1)
+	return nil
+}
+
+// hashValue returns the first 48 bits of the given hex-encoded hash as a float, which represents them exactly.
+func hashValue(hash string) float64 {
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	value, err := strconv.ParseUint(hash, 16, 64)
+	if err != nil {
+		return 0
+	}
+	return float64(value)
+}
+
+func init() {
+	// Register metrics with the global Prometheus registry
+	metrics.Registry.MustRegister(ConfigHashGauge, ConfigLastReloadSuccessfulGauge)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	. "github.com/gardener/remedy-controller/pkg/cmd"
+)
+
+var _ = Describe("ConfigWatcher", func() {
+	const (
+		config1 = `apiVersion: remedy.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+azure:
+  orphanedPublicIPRemedy:
+    deletionGracePeriod: 5m
+`
+		config2 = `apiVersion: remedy.config.gardener.cloud/v1alpha1
+kind: ControllerConfiguration
+azure:
+  orphanedPublicIPRemedy:
+    deletionGracePeriod: 10m
+`
+	)
+
+	var (
+		path                      string
+		hashGauge                 prometheus.Gauge
+		lastReloadSuccessfulGauge prometheus.Gauge
+		configs                   chan *Config
+		subscriberErr             error
+		watcher                   ConfigWatcher
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte(config1), 0600)).To(Succeed())
+		config, err := LoadConfigFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		hashGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_config_hash"})
+		lastReloadSuccessfulGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_config_last_reload_successful"})
+		configs = make(chan *Config, 10)
+		subscriberErr = nil
+		watcher = NewConfigWatcher(path, config, hashGauge, lastReloadSuccessfulGauge, log.Log)
+		watcher.Subscribe(func(config *Config) error {
+			configs <- config
+			return subscriberErr
+		})
+	})
+
+	Describe("#NewConfigWatcher", func() {
+		It("should set the metrics for the initial config", func() {
+			Expect(testutil.ToFloat64(hashGauge)).NotTo(BeZero())
+			Expect(testutil.ToFloat64(lastReloadSuccessfulGauge)).To(Equal(1.0))
+		})
+	})
+
+	Describe("#Reload", func() {
+		It("should notify subscribers if the config file has changed", func() {
+			initialHash := testutil.ToFloat64(hashGauge)
+			Expect(os.WriteFile(path, []byte(config2), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(Succeed())
+			var config *Config
+			Expect(configs).To(Receive(&config))
+			Expect(config.Config.Azure.OrphanedPublicIPRemedy.DeletionGracePeriod.Duration).To(Equal(10 * time.Minute))
+			Expect(testutil.ToFloat64(hashGauge)).NotTo(Equal(initialHash))
+			Expect(testutil.ToFloat64(lastReloadSuccessfulGauge)).To(Equal(1.0))
+		})
+
+		It("should not notify subscribers if the config file has not changed", func() {
+			Expect(watcher.Reload()).To(Succeed())
+			Expect(configs).NotTo(Receive())
+		})
+
+		It("should keep the current config if a subscriber rejects the new config", func() {
+			initialHash := testutil.ToFloat64(hashGauge)
+			Expect(os.WriteFile(path, []byte(config2), 0600)).To(Succeed())
+			subscriberErr = errors.New("test")
+
+			Expect(watcher.Reload()).To(MatchError(ContainSubstring("could not apply config file")))
+			Expect(configs).To(Receive())
+			Expect(testutil.ToFloat64(hashGauge)).To(Equal(initialHash))
+			Expect(testutil.ToFloat64(lastReloadSuccessfulGauge)).To(BeZero())
+
+			// Reloading the same config file again is retried
+			subscriberErr = nil
+			Expect(watcher.Reload()).To(Succeed())
+			Expect(configs).To(Receive())
+			Expect(testutil.ToFloat64(hashGauge)).NotTo(Equal(initialHash))
+			Expect(testutil.ToFloat64(lastReloadSuccessfulGauge)).To(Equal(1.0))
+		})
+
+		It("should keep the current config if the config file is invalid", func() {
+			initialHash := testutil.ToFloat64(hashGauge)
+			Expect(os.WriteFile(path, []byte("azure: ["), 0600)).To(Succeed())
+
+			Expect(watcher.Reload()).To(MatchError(ContainSubstring("could not load config file")))
+			Expect(configs).NotTo(Receive())
+			Expect(testutil.ToFloat64(hashGauge)).To(Equal(initialHash))
+			Expect(testutil.ToFloat64(lastReloadSuccessfulGauge)).To(BeZero())
+		})
+	})
+
+	Describe("#Start", func() {
+		It("should notify subscribers if the config file is replaced", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(watcher.Start(ctx)).To(Succeed())
+			}()
+
+			// Replace the config file like a ConfigMap update, until the watcher has started and picked it up
+			tmpPath := path + ".tmp"
+			Eventually(func(g Gomega) {
+				g.Expect(os.WriteFile(tmpPath, []byte(config2), 0600)).To(Succeed())
+				g.Expect(os.Rename(tmpPath, path)).To(Succeed())
+				g.Expect(configs).To(Receive())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -19,6 +19,7 @@ import (
 
 	azurenode "github.com/gardener/remedy-controller/pkg/controller/azure/node"
 	azurepublicipaddress "github.com/gardener/remedy-controller/pkg/controller/azure/publicipaddress"
+	azureremedyaction "github.com/gardener/remedy-controller/pkg/controller/azure/remedyaction"
 	azureservice "github.com/gardener/remedy-controller/pkg/controller/azure/service"
 	azurevirtualmachine "github.com/gardener/remedy-controller/pkg/controller/azure/virtualmachine"
 )
@@ -28,6 +29,7 @@ func ControllerSwitchOptions() *controllercmd.SwitchOptions {
 	return controllercmd.NewSwitchOptions(
 		controllercmd.Switch(azurepublicipaddress.ControllerName, azurepublicipaddress.AddToManager),
 		controllercmd.Switch(azurevirtualmachine.ControllerName, azurevirtualmachine.AddToManager),
+		controllercmd.Switch(azureremedyaction.ControllerName, azureremedyaction.AddToManager),
 	)
 }
 
@@ -73,8 +73,6 @@ func (c *ManagerConfig) Apply(opts *manager.Options) {
 	if c.Namespace != "" {
 		opts.Cache.DefaultNamespaces = map[string]cache.Config{c.Namespace: {}}
 	}
-	// disable health probe as not used but defaulted to the same port twice
-	opts.HealthProbeBindAddress = ""
 }
 
 // Options initializes empty manager.Options, applies the set values and returns it.
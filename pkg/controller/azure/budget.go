@@ -0,0 +1,100 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"sync"
+	"time"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	"github.com/gardener/remedy-controller/pkg/utils"
+	utilsprometheus "github.com/gardener/remedy-controller/pkg/utils/prometheus"
+)
+
+// DestructiveActionBudget limits the number of destructive actions of each type that can be performed within a time window.
+type DestructiveActionBudget interface {
+	// Acquire consumes a unit of the budget for the given action type and returns true if the budget is not exhausted.
+	// Otherwise, it returns false and the duration after which a unit of the budget will become available again.
+	Acquire(actionType azurev1alpha1.RemedyActionType) (bool, time.Duration)
+}
+
+// NewDestructiveActionBudget creates a new instance of DestructiveActionBudget with the given configuration.
+// If the configuration is nil, or the max number for an action type is nil, actions of that type are not limited.
+// Whether the budget for an action type is exhausted is reported via the given gauge vector, labeled by action type.
+func NewDestructiveActionBudget(
+	cfg *config.AzureDestructiveActionBudgetConfiguration,
+	timestamper utils.Timestamper,
+	exhaustedGaugeVec utilsprometheus.GaugeVec,
+) DestructiveActionBudget {
+	budget := &destructiveActionBudget{
+		max:               make(map[azurev1alpha1.RemedyActionType]int),
+		actions:           make(map[azurev1alpha1.RemedyActionType][]time.Time),
+		timestamper:       timestamper,
+		exhaustedGaugeVec: exhaustedGaugeVec,
+	}
+	if cfg != nil {
+		budget.window = cfg.Window.Duration
+		if cfg.MaxPublicIPAddressDeletions != nil {
+			budget.max[azurev1alpha1.RemedyActionTypeDeletePublicIPAddress] = *cfg.MaxPublicIPAddressDeletions
+		}
+		if cfg.MaxVirtualMachineReapplies != nil {
+			budget.max[azurev1alpha1.RemedyActionTypeReapplyVirtualMachine] = *cfg.MaxVirtualMachineReapplies
+		}
+	}
+	return budget
+}
+
+type destructiveActionBudget struct {
+	window            time.Duration
+	max               map[azurev1alpha1.RemedyActionType]int
+	actions           map[azurev1alpha1.RemedyActionType][]time.Time
+	timestamper       utils.Timestamper
+	exhaustedGaugeVec utilsprometheus.GaugeVec
+	mutex             sync.Mutex
+}
+
+// Acquire consumes a unit of the budget for the given action type and returns true if the budget is not exhausted.
+// Otherwise, it returns false and the duration after which a unit of the budget will become available again.
+func (b *destructiveActionBudget) Acquire(actionType azurev1alpha1.RemedyActionType) (bool, time.Duration) {
+	maxActions, ok := b.max[actionType]
+	if !ok {
+		return true, 0
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Forget the actions that are no longer within the window
+	now := b.timestamper.Now().Time
+	actions := b.actions[actionType]
+	for len(actions) > 0 && !now.Before(actions[0].Add(b.window)) {
+		actions = actions[1:]
+	}
+	b.actions[actionType] = actions
+
+	// If the budget is exhausted, return the duration until the oldest action is no longer within the window
+	if len(actions) >= maxActions {
+		b.exhaustedGaugeVec.WithLabelValues(string(actionType)).Set(1)
+		if len(actions) == 0 {
+			return false, b.window
+		}
+		return false, actions[0].Add(b.window).Sub(now)
+	}
+
+	b.actions[actionType] = append(actions, now)
+	b.exhaustedGaugeVec.WithLabelValues(string(actionType)).Set(0)
+	return true, 0
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/controller/azure"
+	mockprometheus "github.com/gardener/remedy-controller/pkg/mock/prometheus"
+	mockutilsprometheus "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/utils/prometheus"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("DestructiveActionBudget", func() {
+	const window = time.Hour
+
+	var (
+		ctrl *gomock.Controller
+
+		exhaustedGaugeVec *mockutilsprometheus.MockGaugeVec
+		exhaustedGauge    *mockprometheus.MockGauge
+
+		now    time.Time
+		budget DestructiveActionBudget
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		exhaustedGaugeVec = mockutilsprometheus.NewMockGaugeVec(ctrl)
+		exhaustedGauge = mockprometheus.NewMockGauge(ctrl)
+		exhaustedGaugeVec.EXPECT().WithLabelValues(string(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).Return(exhaustedGauge).AnyTimes()
+
+		now = time.Now()
+		budget = NewDestructiveActionBudget(&config.AzureDestructiveActionBudgetConfiguration{
+			Window:                      metav1.Duration{Duration: window},
+			MaxPublicIPAddressDeletions: ptr.To(2),
+		}, utils.TimestamperFunc(func() metav1.Time { return metav1.NewTime(now) }), exhaustedGaugeVec)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Acquire", func() {
+		It("should allow actions until the budget is exhausted", func() {
+			exhaustedGauge.EXPECT().Set(float64(0)).Times(2)
+			exhaustedGauge.EXPECT().Set(float64(1))
+
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+			now = now.Add(10 * time.Minute)
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+			now = now.Add(10 * time.Minute)
+			ok, retryAfter := budget.Acquire(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
+			Expect(ok).To(BeFalse())
+			Expect(retryAfter).To(Equal(40 * time.Minute))
+		})
+
+		It("should allow actions again after the window has elapsed", func() {
+			exhaustedGauge.EXPECT().Set(float64(0)).Times(3)
+			exhaustedGauge.EXPECT().Set(float64(1))
+
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeFalse())
+			now = now.Add(window)
+			Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+		})
+
+		It("should not limit actions without a configured max", func() {
+			for i := 0; i < 10; i++ {
+				Expect(acquire(budget, azurev1alpha1.RemedyActionTypeReapplyVirtualMachine)).To(BeTrue())
+			}
+		})
+
+		It("should not limit actions if there is no configuration", func() {
+			budget = NewDestructiveActionBudget(nil, nil, nil)
+			for i := 0; i < 10; i++ {
+				Expect(acquire(budget, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+			}
+		})
+	})
+})
+
+func acquire(budget DestructiveActionBudget, actionType azurev1alpha1.RemedyActionType) bool {
+	ok, _ := budget.Acquire(actionType)
+	return ok
+}
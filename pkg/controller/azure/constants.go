@@ -20,9 +20,37 @@ const (
 	// DoNotCleanAnnotation is an annotation that can be used to specify that a particular PublicIPAddress
 	// should be not be cleaned when deleted.
 	DoNotCleanAnnotation = "azure.remedy.gardener.cloud/do-not-clean"
+	// ApprovedAnnotation is an annotation that can be used to approve a destructive remedy on a particular
+	// PublicIPAddress or VirtualMachine, if the remedy requires approval.
+	ApprovedAnnotation = "azure.remedy.gardener.cloud/approved"
 
 	// ServiceLabel is the label to put on a PublicIPAddress object that identifies its service.
+	// The same key is used for an annotation that contains the full identity of the service,
+	// since the label value may be shortened.
 	ServiceLabel = "azure.remedy.gardener.cloud/service"
 	// NodeLabel is the label to put on a VirtualMachine object that identifies its node.
+	// The same key is used for an annotation that contains the full identity of the node,
+	// since the label value may be shortened.
 	NodeLabel = "azure.remedy.gardener.cloud/node"
 )
+
+const (
+	// EventReasonDeletionGracePeriodStarted is the reason of events emitted when the deletion grace period of a public IP address starts.
+	EventReasonDeletionGracePeriodStarted = "DeletionGracePeriodStarted"
+	// EventReasonPublicIPAddressCleaned is the reason of events emitted when an orphaned Azure public IP address is cleaned.
+	EventReasonPublicIPAddressCleaned = "PublicIPAddressCleaned"
+	// EventReasonVirtualMachineReapplied is the reason of events emitted when a failed Azure virtual machine is reapplied.
+	EventReasonVirtualMachineReapplied = "VirtualMachineReapplied"
+	// EventReasonMaxAttemptsReached is the reason of events emitted when an Azure operation has reached its max attempts.
+	EventReasonMaxAttemptsReached = "MaxAttemptsReached"
+	// EventReasonPermanentFailure is the reason of events emitted when an Azure operation has failed with a permanent error.
+	EventReasonPermanentFailure = "PermanentFailure"
+	// EventReasonBudgetExhausted is the reason of events emitted when a destructive action is deferred because the destructive action budget is exhausted.
+	EventReasonBudgetExhausted = "BudgetExhausted"
+	// EventReasonAnomalyDetected is the reason of events emitted when cleaning an orphaned resource is refused as anomalous.
+	EventReasonAnomalyDetected = "AnomalyDetected"
+	// EventReasonApprovalRequired is the reason of events emitted when a destructive remedy is waiting for manual approval.
+	EventReasonApprovalRequired = "ApprovalRequired"
+	// EventReasonOutsideMaintenanceWindow is the reason of events emitted when a destructive action is deferred until the next maintenance window.
+	EventReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
+)
@@ -0,0 +1,37 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// BudgetExhaustedGaugeVec is a global gauge vector indicating whether the destructive action budget is exhausted,
+	// by target cluster namespace and action type.
+	BudgetExhaustedGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "remedy_budget_exhausted",
+			Help: "Whether the destructive action budget is exhausted (1) or not (0), by target cluster namespace and action type",
+		},
+		[]string{"namespace", "action"},
+	)
+)
+
+func init() {
+	// Register metrics with the global Prometheus registry
+	metrics.Registry.MustRegister(BudgetExhaustedGaugeVec)
+}
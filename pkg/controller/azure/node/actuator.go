@@ -29,6 +29,7 @@ import (
 	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/remedy-controller/pkg/controller"
 	"github.com/gardener/remedy-controller/pkg/controller/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
 )
 
 const (
@@ -86,6 +87,7 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		_, err := controllerutil.CreateOrUpdate(ctx, a.client, vm, func() error {
 			vm.Labels = vmLabels
+			vm.Annotations = utils.Add(vm.Annotations, azure.NodeLabel, ObjectLabeler.GetIdentity(node))
 			vm.Spec.Hostname = hostname
 			vm.Spec.ProviderID = providerID
 			vm.Spec.NotReadyOrUnreachable = notReadyOrUnreachable
@@ -104,6 +104,9 @@ var _ = Describe("Actuator", func() {
 				Name:      nodeName,
 				Namespace: namespace,
 				Labels:    vmLabels,
+				Annotations: map[string]string{
+					azure.NodeLabel: nodeName,
+				},
 			},
 			Spec: azurev1alpha1.VirtualMachineSpec{
 				Hostname:              hostname,
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
@@ -45,6 +46,10 @@ const (
 	PredicateName = "azurenode-predicate"
 	// VirtualMachinePredicateName is the name of the predicate of the Azure node controller for filtering virtualmachine events.
 	VirtualMachinePredicateName = "azurenode-virtualmachine-predicate"
+	// GarbageCollectorName is the name of the garbage collector for virtualmachine resources of the Azure node controller.
+	GarbageCollectorName = "azurenode-garbage-collector"
+	// IdentityMigratorName is the name of the identity migrator for virtualmachine resources of the Azure node controller.
+	IdentityMigratorName = "azurenode-identity-migrator"
 	// FinalizerName is the finalizer to put on node resources.
 	FinalizerName = "azure.remedy.gardener.cloud/node"
 )
@@ -71,15 +76,49 @@ type AddOptions struct {
 	Namespace string
 	// Manager is the control cluster manager.
 	Manager manager.Manager
+	// TargetName is the name of the target cluster if there are multiple target clusters.
+	// If specified, it is appended to the names of the controller and its runnables to make them unique.
+	TargetName string
 	// Config is the configuration for the Azure failed virtual machine remedy.
 	Config config.AzureFailedVMRemedyConfiguration
 }
 
 // AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
 func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
+	controllerName, identityMigratorName, garbageCollectorName := ControllerName, IdentityMigratorName, GarbageCollectorName
+	if options.TargetName != "" {
+		controllerName += "-" + options.TargetName
+		identityMigratorName += "-" + options.TargetName
+		garbageCollectorName += "-" + options.TargetName
+	}
+
+	if err := mgr.Add(remedycontroller.NewIdentityMigrator(remedycontroller.IdentityMigratorArgs{
+		Client:    options.Client,
+		Namespace: options.Namespace,
+		ListType:  &azurev1alpha1.VirtualMachineList{},
+		Label:     azure.NodeLabel,
+	}, log.Log.WithName(identityMigratorName))); err != nil {
+		return errors.Wrap(err, "could not add identity migrator to manager")
+	}
+
+	if options.Config.NodeGarbageCollectionPeriod.Duration > 0 {
+		if err := mgr.Add(remedycontroller.NewGarbageCollector(remedycontroller.GarbageCollectorArgs{
+			Client:        options.Client,
+			TargetReader:  mgr.GetAPIReader(),
+			Namespace:     options.Namespace,
+			ListType:      &azurev1alpha1.VirtualMachineList{},
+			TargetType:    &corev1.Node{},
+			Label:         azure.NodeLabel,
+			ObjectLabeler: ObjectLabeler,
+			Period:        options.Config.NodeGarbageCollectionPeriod.Duration,
+		}, log.Log.WithName(garbageCollectorName))); err != nil {
+			return errors.Wrap(err, "could not add garbage collector to manager")
+		}
+	}
+
 	return remedycontroller.Add(mgr, remedycontroller.AddArgs{
 		Actuator:            NewActuator(options.Client, options.Namespace, options.Config.NodeSyncPeriod.Duration, log.Log.WithName(ActuatorName)),
-		ControllerName:      ControllerName,
+		ControllerName:      controllerName,
 		FinalizerName:       FinalizerName,
 		ControllerOptions:   options.Controller,
 		Type:                &corev1.Node{},
@@ -93,6 +132,7 @@ func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
 				source.Kind[client.Object](options.Manager.GetCache(),
 					&azurev1alpha1.VirtualMachine{},
 					handler.EnqueueRequestsFromMapFunc(remedycontroller.MapFuncFromMapper(nodeMapper)),
+					remedycontroller.NewNamespacePredicate(options.Namespace),
 					remedycontroller.NewOwnedObjectPredicate(&corev1.Node{}, mgr.GetCache(), nodeMapper, FinalizerName, log.Log.WithName(VirtualMachinePredicateName)),
 				))
 		}),
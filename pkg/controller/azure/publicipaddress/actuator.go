@@ -16,14 +16,20 @@ package publicipaddress
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"sync/atomic"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -34,6 +40,7 @@ import (
 	"github.com/gardener/remedy-controller/pkg/controller/azure/service"
 	"github.com/gardener/remedy-controller/pkg/utils"
 	"github.com/gardener/remedy-controller/pkg/utils/azure"
+	utilsprometheus "github.com/gardener/remedy-controller/pkg/utils/prometheus"
 )
 
 const (
@@ -41,13 +48,26 @@ const (
 	ServiceTag = "service"
 )
 
-type actuator struct {
-	client            client.Client
-	pubipUtils        azure.PublicIPAddressUtils
+// settings are the parts of an actuator that are derived from its configuration and can be replaced at runtime.
+type settings struct {
 	config            config.AzureOrphanedPublicIPRemedyConfiguration
-	timestamper       utils.Timestamper
-	logger            logr.Logger
-	cleanedIPsCounter prometheus.Counter
+	maintenanceWindow *utils.MaintenanceWindow
+	anomalyGuard      utils.AnomalyGuard
+}
+
+type actuator struct {
+	client                    client.Client
+	pubipUtils                azure.PublicIPAddressUtils
+	timestamper               utils.Timestamper
+	recorder                  record.EventRecorder
+	targetRecorder            record.EventRecorder
+	remedyActionRecorder      controllerazure.RemedyActionRecorder
+	budget                    controllerazure.DestructiveActionBudget
+	settings                  atomic.Pointer[settings]
+	notifier                  controller.Notifier
+	logger                    logr.Logger
+	cleanedIPsCounter         prometheus.Counter
+	permanentErrorsCounterVec utilsprometheus.CounterVec
 }
 
 // NewActuator creates a new Actuator.
@@ -56,18 +76,72 @@ func NewActuator(
 	pubipUtils azure.PublicIPAddressUtils,
 	config config.AzureOrphanedPublicIPRemedyConfiguration,
 	timestamper utils.Timestamper,
+	recorder record.EventRecorder,
+	targetRecorder record.EventRecorder,
+	remedyActionRecorder controllerazure.RemedyActionRecorder,
+	budget controllerazure.DestructiveActionBudget,
+	maintenanceWindow *utils.MaintenanceWindow,
+	notifier controller.Notifier,
 	logger logr.Logger,
 	cleanedIPsCounter prometheus.Counter,
-) controller.Actuator {
+	permanentErrorsCounterVec utilsprometheus.CounterVec,
+) controller.ConfigurableActuator[config.AzureOrphanedPublicIPRemedyConfiguration] {
 	logger.Info("Creating actuator", "config", config)
-	return &actuator{
-		client:            client,
-		pubipUtils:        pubipUtils,
+	a := &actuator{
+		client:                    client,
+		pubipUtils:                pubipUtils,
+		timestamper:               timestamper,
+		recorder:                  recorder,
+		targetRecorder:            targetRecorder,
+		remedyActionRecorder:      remedyActionRecorder,
+		budget:                    budget,
+		notifier:                  notifier,
+		logger:                    logger,
+		cleanedIPsCounter:         cleanedIPsCounter,
+		permanentErrorsCounterVec: permanentErrorsCounterVec,
+	}
+	a.settings.Store(&settings{
 		config:            config,
-		timestamper:       timestamper,
-		logger:            logger,
-		cleanedIPsCounter: cleanedIPsCounter,
+		maintenanceWindow: maintenanceWindow,
+		anomalyGuard:      utils.NewAnomalyGuard(config.AnomalyGuard),
+	})
+	return a
+}
+
+// SetConfig validates the given configuration and, if valid, replaces the current configuration of the actuator with it.
+// Reconciliations that start after this call use the new configuration. Changing the dry-run mode is not supported,
+// since it also determines the Azure utilities and metrics used by the actuator.
+func (a *actuator) SetConfig(config config.AzureOrphanedPublicIPRemedyConfiguration) error {
+	if config.DryRun != a.config().DryRun {
+		return errors.New("dry-run mode of the orphaned public IP remedy cannot be changed at runtime")
+	}
+	maintenanceWindow, err := utils.NewMaintenanceWindow(config.MaintenanceWindow)
+	if err != nil {
+		return errors.Wrap(err, "could not create maintenance window")
 	}
+
+	a.logger.Info("Updating actuator configuration", "config", config)
+	a.settings.Store(&settings{
+		config:            config,
+		maintenanceWindow: maintenanceWindow,
+		anomalyGuard:      utils.NewAnomalyGuard(config.AnomalyGuard),
+	})
+	return nil
+}
+
+// config returns the current configuration of the actuator.
+func (a *actuator) config() config.AzureOrphanedPublicIPRemedyConfiguration {
+	return a.settings.Load().config
+}
+
+// maintenanceWindow returns the current maintenance window of the actuator.
+func (a *actuator) maintenanceWindow() *utils.MaintenanceWindow {
+	return a.settings.Load().maintenanceWindow
+}
+
+// anomalyGuard returns the current anomaly guard of the actuator.
+func (a *actuator) anomalyGuard() utils.AnomalyGuard {
+	return a.settings.Load().anomalyGuard
 }
 
 // CreateOrUpdate reconciles object creation or update.
@@ -79,42 +153,31 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 		return 0, errors.New("reconciled object is not a publicipaddress")
 	}
 
-	// Initialize failed operations from PublicIPAddress status
+	// Initialize failed operations and conditions from PublicIPAddress status
 	failedOperations := getFailedOperations(pubip)
+	conditions := getConditions(pubip)
 
 	// Get the Azure public IP address
 	azurePublicIP, err := a.getAzurePublicIPAddress(ctx, pubip)
 	if err != nil {
-		// Add or update the failed operation
-		failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-			azurev1alpha1.OperationTypeGetPublicIPAddress, err.Error(), a.timestamper.Now())
-		a.logger.Error(err, "Getting Azure public IP address failed", "attempts", failedOperation.Attempts)
-
-		// Update resource status
-		if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations); err != nil {
-			return 0, err
-		}
-
-		// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-		if failedOperation.Attempts < a.config.MaxGetAttempts {
-			return 0, &controllererror.RequeueAfterError{
-				Cause:        err,
-				RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
-			}
-		}
-		return a.config.SyncPeriod.Duration, nil
+		return a.handleFailedOperation(ctx, pubip, azurePublicIP, &failedOperations, &conditions,
+			azurev1alpha1.OperationTypeGetPublicIPAddress, err, "Getting Azure public IP address failed")
 	}
 	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetPublicIPAddress)
 
+	// The public IP address is still in use, so no remedy is needed
+	a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue,
+		azurev1alpha1.ConditionReasonNotNeeded, "Public IP address is still in use")
+
 	// Update resource status
-	if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations); err != nil {
+	if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
 		return 0, err
 	}
 
 	// Requeue if the Azure public IP address doesn't exist or is in a transient state
-	requeueAfter = a.config.SyncPeriod.Duration
-	if azurePublicIP == nil || (getProvisioningState(azurePublicIP) != network.Succeeded && getProvisioningState(azurePublicIP) != network.Failed) {
-		requeueAfter = a.config.RequeueInterval.Duration
+	requeueAfter = a.config().SyncPeriod.Duration
+	if azurePublicIP == nil || (getProvisioningState(azurePublicIP) != armnetwork.ProvisioningStateSucceeded && getProvisioningState(azurePublicIP) != armnetwork.ProvisioningStateFailed) {
+		requeueAfter = a.config().RequeueInterval.Duration
 	}
 
 	return requeueAfter, nil
@@ -129,77 +192,173 @@ func (a *actuator) Delete(ctx context.Context, obj client.Object) (requeueAfter
 		return 0, errors.New("reconciled object is not a publicipaddress")
 	}
 
-	// Initialize failed operations from PublicIPAddress status
+	// Initialize failed operations and conditions from PublicIPAddress status
 	failedOperations := getFailedOperations(pubip)
+	conditions := getConditions(pubip)
 
 	// Get the Azure public IP address
 	azurePublicIP, err := a.getAzurePublicIPAddress(ctx, pubip)
 	if err != nil {
-		// Add or update the failed operation
-		failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-			azurev1alpha1.OperationTypeGetPublicIPAddress, err.Error(), a.timestamper.Now())
-		a.logger.Error(err, "Getting Azure public IP address failed", "attempts", failedOperation.Attempts)
-
-		// Update resource status
-		if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations); err != nil {
-			return 0, err
-		}
+		return a.handleFailedOperation(ctx, pubip, azurePublicIP, &failedOperations, &conditions,
+			azurev1alpha1.OperationTypeGetPublicIPAddress, err, "Getting Azure public IP address failed")
+	}
+	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetPublicIPAddress)
 
-		// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-		if failedOperation.Attempts < a.config.MaxGetAttempts {
-			return 0, &controllererror.RequeueAfterError{
-				Cause:        err,
-				RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
-			}
+	// Determine if we are still within the deletion grace period
+	inGracePeriod := pubip.DeletionTimestamp != nil &&
+		!a.timestamper.Now().After(pubip.DeletionTimestamp.Add(a.config().DeletionGracePeriod.Duration))
+
+	// Set the Remedied condition if the Azure public IP address will not be cleaned right away
+	switch {
+	case azurePublicIP == nil:
+		a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue,
+			azurev1alpha1.ConditionReasonNotNeeded, "Azure public IP address does not exist")
+	case shouldNotClean(pubip):
+		a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonCleaningSkipped, "Public IP address is annotated with "+controllerazure.DoNotCleanAnnotation)
+	case inGracePeriod:
+		if cond := meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypeRemedied); cond == nil || cond.Reason != azurev1alpha1.ConditionReasonGracePeriodPending {
+			a.recordEvent(pubip, corev1.EventTypeNormal, controllerazure.EventReasonDeletionGracePeriodStarted,
+				"Azure public IP address %s will be cleaned after a deletion grace period of %s", pubip.Spec.IPAddress, a.config().DeletionGracePeriod.Duration)
 		}
-		return a.config.SyncPeriod.Duration, nil
+		a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonGracePeriodPending, "Waiting for the deletion grace period to elapse")
 	}
-	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetPublicIPAddress)
 
 	// Update resource status
-	if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations); err != nil {
+	if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
 		return 0, err
 	}
 
 	// Clean the Azure public IP address if it still exists and the deletion grace period has elapsed
 	if azurePublicIP != nil && !shouldNotClean(pubip) {
 		// If within the deletion grace period, requeue so we could check again
-		if pubip.DeletionTimestamp != nil &&
-			!a.timestamper.Now().After(pubip.DeletionTimestamp.Add(a.config.DeletionGracePeriod.Duration)) {
+		if inGracePeriod {
 			return 0, &controllererror.RequeueAfterError{
 				Cause:        errors.New("public IP address still exists"),
-				RequeueAfter: a.config.RequeueInterval.Duration,
+				RequeueAfter: a.config().RequeueInterval.Duration,
 			}
 		}
 
-		// Clean the Azure public IP address
-		if err := a.cleanAzurePublicIPAddress(ctx, pubip); err != nil {
-			// Add or update the failed operation
-			failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-				azurev1alpha1.OperationTypeCleanPublicIPAddress, err.Error(), a.timestamper.Now())
-			a.logger.Error(err, "Cleaning Azure public IP address failed", "attempts", failedOperation.Attempts)
+		// Refuse to clean the Azure public IP address if the number of orphaned public IP addresses is anomalous,
+		// i.e. if too many public IP addresses are being deleted at once
+		orphans, known, err := a.countPublicIPAddresses(ctx, pubip.Namespace)
+		if err != nil {
+			return 0, err
+		}
+		if err := a.anomalyGuard().Check(orphans, known); err != nil {
+			a.logger.Error(err, "Refusing to clean Azure public IP address", "name", pubip.Name, "namespace", pubip.Namespace)
+			a.recordEvent(pubip, corev1.EventTypeWarning, controllerazure.EventReasonAnomalyDetected,
+				"Refusing to clean orphaned Azure public IP address %s: %s", pubip.Spec.IPAddress, err.Error())
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonAnomalyDetected, "Cleaning refused: "+err.Error())
 
 			// Update resource status
-			if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations); err != nil {
+			if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
 				return 0, err
 			}
+			return 0, &controllererror.RequeueAfterError{
+				Cause:        err,
+				RequeueAfter: a.config().RequeueInterval.Duration,
+			}
+		}
 
-			// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-			if failedOperation.Attempts < a.config.MaxCleanAttempts {
+		// Wait for approval before cleaning the Azure public IP address, if required
+		if a.requiresApproval() {
+			if !isApproved(pubip) {
+				if cond := meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypePendingApproval); cond == nil || cond.Status != metav1.ConditionTrue {
+					a.recordEvent(pubip, corev1.EventTypeNormal, controllerazure.EventReasonApprovalRequired,
+						"Cleaning orphaned Azure public IP address %s requires approval via the %s=true annotation", pubip.Spec.IPAddress, controllerazure.ApprovedAnnotation)
+				}
+				a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionTrue,
+					azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for the "+controllerazure.ApprovedAnnotation+"=true annotation")
+				a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+					azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for approval to clean the Azure public IP address")
+
+				// Update resource status
+				if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
+					return 0, err
+				}
 				return 0, &controllererror.RequeueAfterError{
-					Cause:        err,
-					RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
+					Cause:        errors.New("public IP address cleaning not approved"),
+					RequeueAfter: a.config().RequeueInterval.Duration,
 				}
 			}
-			return a.config.SyncPeriod.Duration, nil
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonApproved, "Cleaning has been approved")
+		}
+
+		// Defer cleaning the Azure public IP address until the next maintenance window, if outside of it
+		if until := a.maintenanceWindow().Until(a.timestamper.Now().Time); until > 0 {
+			if cond := meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypeRemedied); cond == nil || cond.Reason != azurev1alpha1.ConditionReasonOutsideMaintenanceWindow {
+				a.recordEvent(pubip, corev1.EventTypeNormal, controllerazure.EventReasonOutsideMaintenanceWindow,
+					"Cleaning orphaned Azure public IP address %s deferred by %s until the next maintenance window", pubip.Spec.IPAddress, until.Round(time.Second))
+			}
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonOutsideMaintenanceWindow, "Waiting for the next maintenance window to clean the Azure public IP address")
+
+			// Update resource status
+			if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
+				return 0, err
+			}
+			return 0, &controllererror.RequeueAfterError{
+				Cause:        errors.New("outside of maintenance window"),
+				RequeueAfter: until,
+			}
+		}
+
+		// Defer cleaning the Azure public IP address if the destructive action budget is exhausted
+		if ok, retryAfter := a.acquireBudget(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress); !ok {
+			a.recordEvent(pubip, corev1.EventTypeWarning, controllerazure.EventReasonBudgetExhausted,
+				"Cleaning orphaned Azure public IP address %s deferred by %s, destructive action budget exhausted", pubip.Spec.IPAddress, retryAfter.Round(time.Second))
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonBudgetExhausted, "Destructive action budget is exhausted, cleaning deferred")
+
+			// Update resource status
+			if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
+				return 0, err
+			}
+			return 0, &controllererror.RequeueAfterError{
+				Cause:        errors.New("destructive action budget exhausted"),
+				RequeueAfter: retryAfter,
+			}
+		}
+
+		// Clean the Azure public IP address
+		if err := a.cleanAzurePublicIPAddress(ctx, pubip); err != nil {
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonCleanFailed, err.Error())
+			return a.handleFailedOperation(ctx, pubip, azurePublicIP, &failedOperations, &conditions,
+				azurev1alpha1.OperationTypeCleanPublicIPAddress, err, "Cleaning Azure public IP address failed")
 		}
 		azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeCleanPublicIPAddress)
 
 		// Increase the cleaned IPs counter
 		a.cleanedIPsCounter.Inc()
 
+		// In dry-run mode, the Azure public IP address still exists, so record what would have been done
+		if a.config().DryRun {
+			a.recordEvent(pubip, corev1.EventTypeNormal, controllerazure.EventReasonPublicIPAddressCleaned,
+				"Would have cleaned orphaned Azure public IP address %s (dry run)", pubip.Spec.IPAddress)
+			a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonDryRun, "Azure public IP address would have been cleaned (dry run)")
+
+			// Update resource status
+			if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, failedOperations, &conditions); err != nil {
+				return 0, err
+			}
+			return 0, nil
+		}
+
+		a.recordEvent(pubip, corev1.EventTypeNormal, controllerazure.EventReasonPublicIPAddressCleaned,
+			"Cleaned orphaned Azure public IP address %s", pubip.Spec.IPAddress)
+		a.notify(ctx, pubip, azurev1alpha1.OperationTypeCleanPublicIPAddress, controller.NotificationOutcomeSucceeded,
+			"Cleaned orphaned Azure public IP address %s", pubip.Spec.IPAddress)
+		a.setCondition(&conditions, pubip, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue,
+			azurev1alpha1.ConditionReasonCleaned, "Azure public IP address has been cleaned")
+
 		// Update resource status
-		if err := a.updatePublicIPAddressStatus(ctx, pubip, nil, failedOperations); err != nil {
+		if err := a.updatePublicIPAddressStatus(ctx, pubip, nil, failedOperations, &conditions); err != nil {
 			return 0, err
 		}
 	}
@@ -212,7 +371,7 @@ func (a *actuator) ShouldFinalize(_ context.Context, _ client.Object) (bool, err
 	return true, nil
 }
 
-func (a *actuator) getAzurePublicIPAddress(ctx context.Context, pubip *azurev1alpha1.PublicIPAddress) (*network.PublicIPAddress, error) {
+func (a *actuator) getAzurePublicIPAddress(ctx context.Context, pubip *azurev1alpha1.PublicIPAddress) (*armnetwork.PublicIPAddress, error) {
 	// If status.name is initialized, search by name
 	if pubip.Status.Name != nil {
 		azurePublicIP, err := a.pubipUtils.GetByName(ctx, *pubip.Status.Name)
@@ -221,7 +380,7 @@ func (a *actuator) getAzurePublicIPAddress(ctx context.Context, pubip *azurev1al
 		}
 
 		// If an Azure public IP address is found, compare its IP to the PublicIPAddress IP and return it only if there is a match
-		if azurePublicIP != nil && *azurePublicIP.IPAddress == pubip.Spec.IPAddress {
+		if azurePublicIP != nil && getIPAddress(azurePublicIP) == pubip.Spec.IPAddress {
 			return azurePublicIP, nil
 		}
 	}
@@ -233,7 +392,7 @@ func (a *actuator) getAzurePublicIPAddress(ctx context.Context, pubip *azurev1al
 	}
 
 	// If an Azure public IP address is found, compare its service tag to the PublicIPAddress service name and return it only if there is a match
-	serviceName := service.ObjectLabeler.GetNamespacedName(pubip.Labels[controllerazure.ServiceLabel]).String()
+	serviceName := service.ObjectLabeler.GetNamespacedName(controller.GetIdentity(pubip, controllerazure.ServiceLabel)).String()
 	if azurePublicIP != nil && (serviceName == "/" || azurePublicIP.Tags[ServiceTag] != nil && *azurePublicIP.Tags[ServiceTag] == serviceName) {
 		return azurePublicIP, nil
 	}
@@ -243,21 +402,144 @@ func (a *actuator) getAzurePublicIPAddress(ctx context.Context, pubip *azurev1al
 
 func (a *actuator) cleanAzurePublicIPAddress(ctx context.Context, pubip *azurev1alpha1.PublicIPAddress) error {
 	a.logger.Info("Removing Azure public IP address from the load balancer", "id", *pubip.Status.ID)
-	if err := a.pubipUtils.RemoveFromLoadBalancer(ctx, []string{*pubip.Status.ID}); err != nil {
+	if err := a.recordRemedyAction(ctx, pubip, azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer, func() error {
+		return a.pubipUtils.RemoveFromLoadBalancer(ctx, []string{*pubip.Status.ID})
+	}); err != nil {
 		return errors.Wrap(err, "could not remove Azure public IP address from the load balancer")
 	}
 	a.logger.Info("Deleting Azure public IP address", "name", *pubip.Status.Name)
-	if err := a.pubipUtils.Delete(ctx, *pubip.Status.Name); err != nil {
+	if err := a.recordRemedyAction(ctx, pubip, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress, func() error {
+		return a.pubipUtils.Delete(ctx, *pubip.Status.Name)
+	}); err != nil {
 		return errors.Wrap(err, "could not delete Azure public IP address")
 	}
 	return nil
 }
 
+// countPublicIPAddresses returns the number of orphaned public IP addresses, i.e. public IP addresses that are being deleted
+// and should be cleaned, and the number of known public IP addresses, i.e. public IP addresses that are not being deleted,
+// in the given namespace. Both are counted from the PublicIPAddress resources in the control cluster, not from the services
+// in the target cluster, so they only reflect how many public IP addresses are being deleted at the same time, and depend
+// on the order in which PublicIPAddress resources are deleted. The anomaly guard therefore only limits bursts of deletions,
+// e.g. when the service controller or garbage collection deletes many PublicIPAddress resources at once.
+func (a *actuator) countPublicIPAddresses(ctx context.Context, namespace string) (orphans, known int, err error) {
+	pubipList := &azurev1alpha1.PublicIPAddressList{}
+	if err := a.client.List(ctx, pubipList, client.InNamespace(namespace)); err != nil {
+		return 0, 0, errors.Wrap(err, "could not list publicipaddresses")
+	}
+	for _, pubip := range pubipList.Items {
+		switch {
+		case pubip.DeletionTimestamp == nil:
+			known++
+		case pubip.Status.Exists && !shouldNotClean(&pubip):
+			orphans++
+		}
+	}
+	return orphans, known, nil
+}
+
+// recordRemedyAction performs the given action on the Azure public IP address, recording it as a RemedyAction.
+// In dry-run mode, nothing is actually changed in Azure, so the action is not recorded.
+func (a *actuator) recordRemedyAction(
+	ctx context.Context,
+	pubip *azurev1alpha1.PublicIPAddress,
+	actionType azurev1alpha1.RemedyActionType,
+	action func() error,
+) error {
+	if a.config().DryRun {
+		return action()
+	}
+	return a.remedyActionRecorder.Record(ctx, pubip, actionType, *pubip.Status.ID, action)
+}
+
+// requiresApproval returns true if cleaning Azure public IP addresses requires approval.
+// In dry-run mode, nothing is actually changed in Azure, so no approval is required.
+func (a *actuator) requiresApproval() bool {
+	return a.config().RequireApproval && !a.config().DryRun
+}
+
+// acquireBudget acquires a unit of the destructive action budget for the given action type.
+// In dry-run mode, nothing is actually changed in Azure, so no budget is consumed.
+func (a *actuator) acquireBudget(actionType azurev1alpha1.RemedyActionType) (bool, time.Duration) {
+	if a.config().DryRun {
+		return true, 0
+	}
+	return a.budget.Acquire(actionType)
+}
+
+// handleFailedOperation adds or updates the failed operation of the given type and updates the PublicIPAddress status.
+// If the request has been throttled by Azure, it doesn't count the failed attempt and returns a RequeueAfterError
+// with the delay requested by Azure instead.
+// If the operation failed with a permanent error, it gives up until the next sync.
+// If the operation should be retried according to its retry policy, it returns a RequeueAfterError with the appropriate delay.
+// Otherwise, it gives up until the next sync.
+func (a *actuator) handleFailedOperation(
+	ctx context.Context,
+	pubip *azurev1alpha1.PublicIPAddress,
+	azurePublicIP *armnetwork.PublicIPAddress,
+	failedOperations *[]azurev1alpha1.FailedOperation,
+	conditions *[]metav1.Condition,
+	opType azurev1alpha1.OperationType,
+	err error,
+	msg string,
+) (time.Duration, error) {
+	// Throttled requests are not counted as failed attempts, instead requeue after the delay requested by Azure
+	if throttledErr, ok := azure.AsThrottledError(err); ok {
+		a.logger.Info(msg+", request throttled by Azure", "retryAfter", throttledErr.RetryAfter, "error", err.Error())
+		return 0, &controllererror.RequeueAfterError{
+			Cause:        err,
+			RequeueAfter: throttledErr.RetryAfter,
+		}
+	}
+
+	retryPolicy := a.getRetryPolicy(opType)
+
+	// Add or update the failed operation, resetting its attempts if the cool-down has elapsed since its last failure
+	now := a.timestamper.Now()
+	if op := azurev1alpha1.GetFailedOperation(*failedOperations, opType); op != nil && retryPolicy.CoolDownElapsed(op.Timestamp.Time, now.Time) {
+		azurev1alpha1.DeleteFailedOperation(failedOperations, opType)
+	}
+	classification := azure.ClassifyError(err)
+	reason := azurev1alpha1.FailureReasonTransient
+	if classification.Permanent {
+		reason = azurev1alpha1.FailureReasonPermanent
+	}
+	failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(failedOperations, azurev1alpha1.FailedOperation{
+		Type:          opType,
+		ErrorMessage:  err.Error(),
+		Reason:        reason,
+		Code:          classification.Code,
+		CorrelationID: classification.CorrelationID,
+		Timestamp:     now,
+	})
+	a.logger.Error(err, msg, "attempts", failedOperation.Attempts, "reason", reason, "code", classification.Code, "correlationID", classification.CorrelationID)
+
+	// Update resource status
+	if err := a.updatePublicIPAddressStatus(ctx, pubip, azurePublicIP, *failedOperations, conditions); err != nil {
+		return 0, err
+	}
+
+	// If the failed operation failed with a permanent error, don't retry it until the next sync
+	if classification.Permanent {
+		a.permanentErrorsCounterVec.WithLabelValues(string(opType), classification.Code).Inc()
+		a.reportPermanentFailure(ctx, pubip, failedOperation)
+		return a.config().SyncPeriod.Duration, nil
+	}
+
+	// If the failed operation should be retried, requeue with exponential backoff
+	if requeueAfterErr := retryPolicy.RequeueAfterError(err, failedOperation.Attempts); requeueAfterErr != nil {
+		return 0, requeueAfterErr
+	}
+	a.reportMaxAttemptsReached(ctx, pubip, failedOperation)
+	return a.config().SyncPeriod.Duration, nil
+}
+
 func (a *actuator) updatePublicIPAddressStatus(
 	ctx context.Context,
 	pubip *azurev1alpha1.PublicIPAddress,
-	azurePublicIP *network.PublicIPAddress,
+	azurePublicIP *armnetwork.PublicIPAddress,
 	failedOperations []azurev1alpha1.FailedOperation,
+	conditions *[]metav1.Condition,
 ) error {
 	// Build status
 	status := azurev1alpha1.PublicIPAddressStatus{}
@@ -266,13 +548,18 @@ func (a *actuator) updatePublicIPAddressStatus(
 			Exists:            true,
 			ID:                azurePublicIP.ID,
 			Name:              azurePublicIP.Name,
-			ProvisioningState: azurePublicIP.ProvisioningState,
+			ProvisioningState: (*string)(getProvisioningStatePtr(azurePublicIP)),
 		}
 	}
 	if len(failedOperations) > 0 {
 		status.FailedOperations = make([]azurev1alpha1.FailedOperation, len(failedOperations))
 		copy(status.FailedOperations, failedOperations)
 	}
+	a.setConditions(conditions, pubip, azurePublicIP != nil, failedOperations)
+	if len(*conditions) > 0 {
+		status.Conditions = make([]metav1.Condition, len(*conditions))
+		copy(status.Conditions, *conditions)
+	}
 
 	// Update resource status
 	a.logger.Info("Updating publicipaddress status", "name", pubip.Name, "namespace", pubip.Namespace, "status", status)
@@ -287,6 +574,123 @@ func (a *actuator) updatePublicIPAddressStatus(
 	return nil
 }
 
+func (a *actuator) setConditions(
+	conditions *[]metav1.Condition,
+	pubip *azurev1alpha1.PublicIPAddress,
+	exists bool,
+	failedOperations []azurev1alpha1.FailedOperation,
+) {
+	// Set Tracked and AzureReachable conditions depending on the outcome of getting the Azure public IP address
+	if getOp := azurev1alpha1.GetFailedOperation(failedOperations, azurev1alpha1.OperationTypeGetPublicIPAddress); getOp != nil {
+		a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeTracked, metav1.ConditionUnknown,
+			azurev1alpha1.ConditionReasonRequestFailed, "Could not determine if Azure public IP address exists")
+		a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonRequestFailed, getOp.ErrorMessage)
+	} else {
+		if exists {
+			a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeTracked, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonFound, "Azure public IP address exists")
+		} else {
+			a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeTracked, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonNotFound, "Azure public IP address does not exist")
+		}
+		a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionTrue,
+			azurev1alpha1.ConditionReasonRequestSucceeded, "Azure public IP address retrieved successfully")
+	}
+
+	// Set RemedyExhausted condition depending on whether any failed operation has reached its max attempts
+	for _, op := range failedOperations {
+		if op.Reason == azurev1alpha1.FailureReasonPermanent {
+			a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonPermanentFailure, fmt.Sprintf("Operation %s failed with a permanent error: %s", op.Type, op.ErrorMessage))
+			return
+		}
+		if a.getRetryPolicy(op.Type).IsExhausted(op.Attempts) {
+			a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonMaxAttemptsReached, fmt.Sprintf("Operation %s failed %d times: %s", op.Type, op.Attempts, op.ErrorMessage))
+			return
+		}
+	}
+	a.setCondition(conditions, pubip, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionFalse,
+		azurev1alpha1.ConditionReasonAttemptsRemaining, "No operation has reached its max attempts")
+}
+
+func (a *actuator) setCondition(
+	conditions *[]metav1.Condition,
+	pubip *azurev1alpha1.PublicIPAddress,
+	condType string,
+	status metav1.ConditionStatus,
+	reason, message string,
+) {
+	azurev1alpha1.SetCondition(conditions, condType, status, reason, message, pubip.Generation, a.timestamper.Now())
+}
+
+func (a *actuator) reportMaxAttemptsReached(ctx context.Context, pubip *azurev1alpha1.PublicIPAddress, failedOperation *azurev1alpha1.FailedOperation) {
+	a.recordEvent(pubip, corev1.EventTypeWarning, controllerazure.EventReasonMaxAttemptsReached,
+		"Operation %s failed %d times, giving up until next sync: %s", failedOperation.Type, failedOperation.Attempts, failedOperation.ErrorMessage)
+	a.notify(ctx, pubip, failedOperation.Type, controller.NotificationOutcomeGaveUp,
+		"Operation %s failed %d times, giving up until next sync: %s", failedOperation.Type, failedOperation.Attempts, failedOperation.ErrorMessage)
+}
+
+func (a *actuator) reportPermanentFailure(ctx context.Context, pubip *azurev1alpha1.PublicIPAddress, failedOperation *azurev1alpha1.FailedOperation) {
+	a.recordEvent(pubip, corev1.EventTypeWarning, controllerazure.EventReasonPermanentFailure,
+		"Operation %s failed with a permanent error, giving up until next sync: %s", failedOperation.Type, failedOperation.ErrorMessage)
+	a.notify(ctx, pubip, failedOperation.Type, controller.NotificationOutcomeGaveUp,
+		"Operation %s failed with a permanent error, giving up until next sync: %s", failedOperation.Type, failedOperation.ErrorMessage)
+}
+
+// notify sends a notification about the outcome of the given operation on the given PublicIPAddress.
+// Sending the notification may fail without affecting the remedy, so errors are only logged.
+func (a *actuator) notify(
+	ctx context.Context,
+	pubip *azurev1alpha1.PublicIPAddress,
+	opType azurev1alpha1.OperationType,
+	outcome controller.NotificationOutcome,
+	messageFmt string,
+	args ...interface{},
+) {
+	notification := controller.Notification{
+		Resource: controller.NotificationResource{
+			Kind:      "PublicIPAddress",
+			Namespace: pubip.Namespace,
+			Name:      pubip.Name,
+		},
+		Action:    string(opType),
+		Outcome:   outcome,
+		Message:   fmt.Sprintf(messageFmt, args...),
+		Timestamp: a.timestamper.Now(),
+	}
+	if pubip.Status.ID != nil {
+		notification.Resource.ProviderID = *pubip.Status.ID
+	}
+	if err := a.notifier.Notify(ctx, notification); err != nil {
+		a.logger.Error(err, "Could not send notification", "name", pubip.Name, "namespace", pubip.Namespace)
+	}
+}
+
+// recordEvent records an event on the given PublicIPAddress and on the service it belongs to, if known.
+func (a *actuator) recordEvent(pubip *azurev1alpha1.PublicIPAddress, eventType, reason, messageFmt string, args ...interface{}) {
+	a.recorder.Eventf(pubip, eventType, reason, messageFmt, args...)
+	if a.targetRecorder == nil {
+		return
+	}
+	if serviceName := service.ObjectLabeler.GetNamespacedName(controller.GetIdentity(pubip, controllerazure.ServiceLabel)); serviceName.Name != "" {
+		a.targetRecorder.Eventf(&corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Namespace:  serviceName.Namespace,
+			Name:       serviceName.Name,
+		}, eventType, reason, messageFmt, args...)
+	}
+}
+
+func (a *actuator) getRetryPolicy(opType azurev1alpha1.OperationType) controller.RetryPolicy {
+	if opType == azurev1alpha1.OperationTypeCleanPublicIPAddress {
+		return controller.NewRetryPolicy(a.config().CleanRetryPolicy, a.config().RequeueInterval.Duration, a.config().MaxCleanAttempts)
+	}
+	return controller.NewRetryPolicy(a.config().GetRetryPolicy, a.config().RequeueInterval.Duration, a.config().MaxGetAttempts)
+}
+
 func getFailedOperations(pubip *azurev1alpha1.PublicIPAddress) []azurev1alpha1.FailedOperation {
 	var failedOperations []azurev1alpha1.FailedOperation
 	if len(pubip.Status.FailedOperations) > 0 {
@@ -296,13 +700,40 @@ func getFailedOperations(pubip *azurev1alpha1.PublicIPAddress) []azurev1alpha1.F
 	return failedOperations
 }
 
+func getConditions(pubip *azurev1alpha1.PublicIPAddress) []metav1.Condition {
+	var conditions []metav1.Condition
+	if len(pubip.Status.Conditions) > 0 {
+		conditions = make([]metav1.Condition, len(pubip.Status.Conditions))
+		copy(conditions, pubip.Status.Conditions)
+	}
+	return conditions
+}
+
 func shouldNotClean(pubip *azurev1alpha1.PublicIPAddress) bool {
 	return pubip.Annotations[controllerazure.DoNotCleanAnnotation] == strconv.FormatBool(true)
 }
 
-func getProvisioningState(azurePublicIP *network.PublicIPAddress) network.ProvisioningState {
-	if azurePublicIP.ProvisioningState == nil {
+func isApproved(pubip *azurev1alpha1.PublicIPAddress) bool {
+	return pubip.Annotations[controllerazure.ApprovedAnnotation] == strconv.FormatBool(true)
+}
+
+func getProvisioningState(azurePublicIP *armnetwork.PublicIPAddress) armnetwork.ProvisioningState {
+	if provisioningState := getProvisioningStatePtr(azurePublicIP); provisioningState != nil {
+		return *provisioningState
+	}
+	return ""
+}
+
+func getProvisioningStatePtr(azurePublicIP *armnetwork.PublicIPAddress) *armnetwork.ProvisioningState {
+	if azurePublicIP.Properties == nil {
+		return nil
+	}
+	return azurePublicIP.Properties.ProvisioningState
+}
+
+func getIPAddress(azurePublicIP *armnetwork.PublicIPAddress) string {
+	if azurePublicIP.Properties == nil || azurePublicIP.Properties.IPAddress == nil {
 		return ""
 	}
-	return network.ProvisioningState(*azurePublicIP.ProvisioningState)
+	return *azurePublicIP.Properties.IPAddress
 }
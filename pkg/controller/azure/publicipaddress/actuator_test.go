@@ -16,10 +16,14 @@ package publicipaddress_test
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -27,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,8 +43,12 @@ import (
 	"github.com/gardener/remedy-controller/pkg/controller/azure/publicipaddress"
 	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
 	mockprometheus "github.com/gardener/remedy-controller/pkg/mock/prometheus"
+	mockcontroller "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller"
+	mockcontrollerazure "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller/azure"
 	mockutilsazure "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/utils/azure"
+	mockutilsprometheus "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/utils/prometheus"
 	"github.com/gardener/remedy-controller/pkg/utils"
+	utilsazure "github.com/gardener/remedy-controller/pkg/utils/azure"
 )
 
 var _ = Describe("Actuator", func() {
@@ -61,24 +70,38 @@ var _ = Describe("Actuator", func() {
 		ctrl *gomock.Controller
 		ctx  context.Context
 
-		c                 *mockclient.MockClient
-		sw                *mockclient.MockStatusWriter
-		pubipUtils        *mockutilsazure.MockPublicIPAddressUtils
-		cleanedIPsCounter *mockprometheus.MockCounter
-
-		cfg         config.AzureOrphanedPublicIPRemedyConfiguration
-		now         metav1.Time
-		timestamper utils.Timestamper
-		logger      logr.Logger
-		actuator    controller.Actuator
+		c                         *mockclient.MockClient
+		sw                        *mockclient.MockStatusWriter
+		pubipUtils                *mockutilsazure.MockPublicIPAddressUtils
+		remedyActionRecorder      *mockcontrollerazure.MockRemedyActionRecorder
+		budget                    azure.DestructiveActionBudget
+		notifier                  controller.Notifier
+		cleanedIPsCounter         *mockprometheus.MockCounter
+		permanentErrorsCounterVec *mockutilsprometheus.MockCounterVec
+		permanentErrorsCounter    *mockprometheus.MockCounter
+
+		cfg            config.AzureOrphanedPublicIPRemedyConfiguration
+		now            metav1.Time
+		timestamper    utils.Timestamper
+		recorder       *record.FakeRecorder
+		targetRecorder *record.FakeRecorder
+		logger         logr.Logger
+		actuator       controller.ConfigurableActuator[config.AzureOrphanedPublicIPRemedyConfiguration]
 
 		earlyDeletionTimestamp metav1.Time
 
 		newPubip                      func(withStatus bool, failedOps []azurev1alpha1.FailedOperation, deletionTimestamp *metav1.Time, annotations map[string]string) *azurev1alpha1.PublicIPAddress
+		withConditions                func(pubip *azurev1alpha1.PublicIPAddress, conditions ...metav1.Condition) *azurev1alpha1.PublicIPAddress
+		newCondition                  func(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition
 		newFailedOps                  func(azurev1alpha1.OperationType, int, string) []azurev1alpha1.FailedOperation
-		newAzurePublicIPAddress       func(ip string, withServiceTag bool) *network.PublicIPAddress
+		newAzurePublicIPAddress       func(ip string, withServiceTag bool) *armnetwork.PublicIPAddress
 		expectPatchStatus             func(pubip, pubipUpdated *azurev1alpha1.PublicIPAddress) *gomock.Call
+		expectRecordRemedyAction      func(actionType azurev1alpha1.RemedyActionType)
+		expectListPubips              func(pubips ...azurev1alpha1.PublicIPAddress)
 		expectCleanIpAdressWithoutErr func()
+
+		trackedFound, trackedNotFound, trackedUnknown, reachable, notExhausted metav1.Condition
+		remediedInUse, remediedNotFound                                        metav1.Condition
 	)
 
 	BeforeEach(func() {
@@ -89,7 +112,12 @@ var _ = Describe("Actuator", func() {
 		sw = mockclient.NewMockStatusWriter(ctrl)
 		c.EXPECT().Status().Return(sw).AnyTimes()
 		pubipUtils = mockutilsazure.NewMockPublicIPAddressUtils(ctrl)
+		remedyActionRecorder = mockcontrollerazure.NewMockRemedyActionRecorder(ctrl)
+		budget = azure.NewDestructiveActionBudget(nil, nil, nil)
+		notifier = controller.NewNotifier(nil, log.Log)
 		cleanedIPsCounter = mockprometheus.NewMockCounter(ctrl)
+		permanentErrorsCounterVec = mockutilsprometheus.NewMockCounterVec(ctrl)
+		permanentErrorsCounter = mockprometheus.NewMockCounter(ctrl)
 
 		cfg = config.AzureOrphanedPublicIPRemedyConfiguration{
 			RequeueInterval:     metav1.Duration{Duration: requeueInterval},
@@ -100,8 +128,10 @@ var _ = Describe("Actuator", func() {
 		}
 		now = metav1.Now()
 		timestamper = utils.TimestamperFunc(func() metav1.Time { return now })
+		recorder = record.NewFakeRecorder(10)
+		targetRecorder = record.NewFakeRecorder(10)
 		logger = log.Log.WithName("test")
-		actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, logger, cleanedIPsCounter)
+		actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
 
 		earlyDeletionTimestamp = metav1.NewTime(now.Add(-10 * time.Minute))
 
@@ -112,7 +142,7 @@ var _ = Describe("Actuator", func() {
 					Exists:            true,
 					ID:                ptr.To(azurePublicIPAddressID),
 					Name:              ptr.To(azurePublicIPAddressName),
-					ProvisioningState: ptr.To(string(network.Succeeded)),
+					ProvisioningState: ptr.To(string(armnetwork.ProvisioningStateSucceeded)),
 				}
 			}
 			status.FailedOperations = failedOperations
@@ -132,12 +162,48 @@ var _ = Describe("Actuator", func() {
 				Status: status,
 			}
 		}
+		withConditions = func(pubip *azurev1alpha1.PublicIPAddress, conditions ...metav1.Condition) *azurev1alpha1.PublicIPAddress {
+			pubip.Status.Conditions = conditions
+			return pubip
+		}
+		newCondition = func(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+			return metav1.Condition{
+				Type:               condType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+			}
+		}
+		trackedFound = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionTrue, azurev1alpha1.ConditionReasonFound, "Azure public IP address exists")
+		trackedNotFound = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionFalse, azurev1alpha1.ConditionReasonNotFound, "Azure public IP address does not exist")
+		trackedUnknown = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionUnknown, azurev1alpha1.ConditionReasonRequestFailed, "Could not determine if Azure public IP address exists")
+		reachable = newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionTrue, azurev1alpha1.ConditionReasonRequestSucceeded, "Azure public IP address retrieved successfully")
+		notExhausted = newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionFalse, azurev1alpha1.ConditionReasonAttemptsRemaining, "No operation has reached its max attempts")
+		remediedInUse = newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonNotNeeded, "Public IP address is still in use")
+		remediedNotFound = newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonNotNeeded, "Azure public IP address does not exist")
 		expectPatchStatus = func(pubip, pubipUpdated *azurev1alpha1.PublicIPAddress) *gomock.Call {
 			c.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
 			return sw.EXPECT().Patch(gomock.Any(), pubipUpdated, gomock.Any())
 		}
+		expectRecordRemedyAction = func(actionType azurev1alpha1.RemedyActionType) {
+			remedyActionRecorder.EXPECT().Record(ctx, gomock.Any(), actionType, azurePublicIPAddressID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ client.Object, _ azurev1alpha1.RemedyActionType, _ string, action func() error) error {
+					return action()
+				})
+		}
+		expectListPubips = func(pubips ...azurev1alpha1.PublicIPAddress) {
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddressList{}), client.InNamespace(namespace)).DoAndReturn(
+				func(_ context.Context, list *azurev1alpha1.PublicIPAddressList, _ ...client.ListOption) error {
+					list.Items = pubips
+					return nil
+				})
+		}
 		expectCleanIpAdressWithoutErr = func() {
+			expectListPubips()
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
 			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
 			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(errors.New("test"))
 		}
 		newFailedOps = func(opType azurev1alpha1.OperationType, attempts int, errorMessage string) []azurev1alpha1.FailedOperation {
@@ -146,23 +212,24 @@ var _ = Describe("Actuator", func() {
 					Type:         opType,
 					Attempts:     attempts,
 					ErrorMessage: errorMessage,
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
 			}
 		}
-		newAzurePublicIPAddress = func(ip string, withServiceTag bool) *network.PublicIPAddress {
+		newAzurePublicIPAddress = func(ip string, withServiceTag bool) *armnetwork.PublicIPAddress {
 			var tags map[string]*string
 			if withServiceTag {
 				tags = map[string]*string{
 					publicipaddress.ServiceTag: ptr.To(namespace + "/" + serviceName),
 				}
 			}
-			return &network.PublicIPAddress{
+			return &armnetwork.PublicIPAddress{
 				ID:   ptr.To(azurePublicIPAddressID),
 				Name: ptr.To(azurePublicIPAddressName),
-				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+				Properties: &armnetwork.PublicIPAddressPropertiesFormat{
 					IPAddress:         ptr.To(ip),
-					ProvisioningState: ptr.To(string(network.Succeeded)),
+					ProvisioningState: ptr.To(armnetwork.ProvisioningStateSucceeded),
 				},
 				Tags: tags,
 			}
@@ -175,7 +242,8 @@ var _ = Describe("Actuator", func() {
 
 	Describe("#CreateOrUpdate", func() {
 		It("should update the PublicIPAddress object status if the IP is found", func() {
-			pubip, pubipWithStatus := newPubip(false, nil, nil, nil), newPubip(true, nil, nil, nil)
+			pubip := newPubip(false, nil, nil, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
 
@@ -186,21 +254,25 @@ var _ = Describe("Actuator", func() {
 			Expect(requeueAfter).To(Equal(syncPeriod))
 		})
 
-		It("should not update the PublicIPAddress object status if the IP is not found", func() {
+		It("should only update the PublicIPAddress object conditions if the IP is not found", func() {
 			pubip := newPubip(false, nil, nil, nil)
+			pubipWithConditions := withConditions(newPubip(false, nil, nil, nil), remediedInUse, trackedNotFound, reachable, notExhausted)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
+
+			expectPatchStatus(pubip, pubipWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(requeueInterval))
 		})
 
-		It("should not update the PublicIPAddress object status if the IP is found but doesn't have the service tag", func() {
+		It("should only update the PublicIPAddress object conditions if the IP is found but doesn't have the service tag", func() {
 			pubip := newPubip(false, nil, nil, nil)
+			pubipWithConditions := withConditions(newPubip(false, nil, nil, nil), remediedInUse, trackedNotFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, false)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
+
+			expectPatchStatus(pubip, pubipWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
@@ -208,7 +280,7 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should not update the PublicIPAddress object status if the IP is found and the status is already initialized", func() {
-			pubipWithStatus := newPubip(true, nil, nil, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubipWithStatus).Return(nil)
@@ -219,7 +291,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the PublicIPAddress object status if the IP is not found and the status is already initialized", func() {
-			pubip, pubipWithStatus := newPubip(false, nil, nil, nil), newPubip(true, nil, nil, nil)
+			pubip := withConditions(newPubip(false, nil, nil, nil), remediedInUse, trackedNotFound, reachable, notExhausted)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(nil, nil)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
 
@@ -231,7 +304,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the PublicIPAddress object status if the IP address has changed and the status is already initialized", func() {
-			pubip, pubipWithStatus := newPubip(false, nil, nil, nil), newPubip(true, nil, nil, nil)
+			pubip := withConditions(newPubip(false, nil, nil, nil), remediedInUse, trackedNotFound, reachable, notExhausted)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			azurePublicIPAddress2 := newAzurePublicIPAddress(ip2, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress2, nil)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
@@ -246,7 +320,9 @@ var _ = Describe("Actuator", func() {
 		It("should fail and requeue if getting the Azure IP address by IP fails", func() {
 			pubip := newPubip(false, nil, nil, nil)
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, 1, "could not get Azure public IP address by IP: test")
-			pubipWithFailedOps := newPubip(false, failedOps, nil, nil)
+			pubipWithFailedOps := withConditions(newPubip(false, failedOps, nil, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure public IP address by IP: test"),
+				notExhausted)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, errors.New("test"))
 
 			expectPatchStatus(pubip, pubipWithFailedOps).Return(nil)
@@ -262,7 +338,9 @@ var _ = Describe("Actuator", func() {
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, cfg.MaxGetAttempts-1, "could not get Azure public IP address by IP: test")
 			pubip := newPubip(false, failedOps, nil, nil)
 			failedOps2 := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, cfg.MaxGetAttempts, "could not get Azure public IP address by IP: test")
-			pubip2 := newPubip(false, failedOps2, nil, nil)
+			pubip2 := withConditions(newPubip(false, failedOps2, nil, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure public IP address by IP: test"),
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonMaxAttemptsReached, "Operation GetPublicIPAddress failed 2 times: could not get Azure public IP address by IP: test"))
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, errors.New("test"))
 
 			expectPatchStatus(pubip, pubip2).Return(nil)
@@ -270,10 +348,81 @@ var _ = Describe("Actuator", func() {
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Warning MaxAttemptsReached Operation GetPublicIPAddress failed 2 times, giving up until next sync: could not get Azure public IP address by IP: test")))
+			Expect(targetRecorder.Events).To(Receive(Equal("Warning MaxAttemptsReached Operation GetPublicIPAddress failed 2 times, giving up until next sync: could not get Azure public IP address by IP: test")))
+		})
+
+		It("should reset the attempts and requeue if getting the Azure IP address by IP fails after the cool-down has elapsed", func() {
+			cfg.GetRetryPolicy = &config.RetryPolicyConfiguration{CoolDown: &metav1.Duration{Duration: time.Hour}}
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			failedOps := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, cfg.MaxGetAttempts, "could not get Azure public IP address by IP: test")
+			failedOps[0].Timestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+			pubip := newPubip(false, failedOps, nil, nil)
+			failedOps2 := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, 1, "could not get Azure public IP address by IP: test")
+			pubip2 := withConditions(newPubip(false, failedOps2, nil, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure public IP address by IP: test"),
+				notExhausted)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, errors.New("test"))
+
+			expectPatchStatus(pubip, pubip2).Return(nil)
+
+			_, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			re := err.(*controllererror.RequeueAfterError)
+			Expect(re.Cause).To(MatchError("could not get Azure public IP address by IP: test"))
+			Expect(re.RequeueAfter).To(Equal(requeueInterval))
+		})
+
+		It("should requeue after the Retry-After delay without counting the attempt if getting the Azure IP address by IP is throttled", func() {
+			pubip := newPubip(false, nil, nil, nil)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, &utilsazure.ThrottledError{Cause: errors.New("test"), RetryAfter: 30 * time.Second})
+
+			_, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			re := err.(*controllererror.RequeueAfterError)
+			Expect(re.Cause).To(MatchError("could not get Azure public IP address by IP: test"))
+			Expect(re.RequeueAfter).To(Equal(30 * time.Second))
+		})
+
+		It("should not retry if getting the Azure IP address by IP fails with a permanent error", func() {
+			pubip := newPubip(false, nil, nil, nil)
+			permanentErr := runtime.NewResponseError(&http.Response{
+				StatusCode: http.StatusForbidden,
+				Header:     http.Header{"X-Ms-Correlation-Request-Id": []string{"correlation-id"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"AuthorizationFailed","message":"test"}}`)),
+			})
+			errorMessage := "could not get Azure public IP address by IP: " + permanentErr.Error()
+			failedOps := []azurev1alpha1.FailedOperation{
+				{
+					Type:          azurev1alpha1.OperationTypeGetPublicIPAddress,
+					Attempts:      1,
+					ErrorMessage:  errorMessage,
+					Reason:        azurev1alpha1.FailureReasonPermanent,
+					Code:          "AuthorizationFailed",
+					CorrelationID: "correlation-id",
+					Timestamp:     now,
+				},
+			}
+			pubip2 := withConditions(newPubip(false, failedOps, nil, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, errorMessage),
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonPermanentFailure, "Operation GetPublicIPAddress failed with a permanent error: "+errorMessage))
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, permanentErr)
+			permanentErrorsCounterVec.EXPECT().WithLabelValues(string(azurev1alpha1.OperationTypeGetPublicIPAddress), "AuthorizationFailed").Return(permanentErrorsCounter)
+			permanentErrorsCounter.EXPECT().Inc()
+
+			expectPatchStatus(pubip, pubip2).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(syncPeriod))
+			event := "Warning PermanentFailure Operation GetPublicIPAddress failed with a permanent error, giving up until next sync: " + errorMessage
+			Expect(recorder.Events).To(Receive(Equal(event)))
+			Expect(targetRecorder.Events).To(Receive(Equal(event)))
 		})
 
 		It("should fail if updating the PublicIPAddress object status fails", func() {
-			pubip, pubipWithStatus := newPubip(false, nil, nil, nil), newPubip(true, nil, nil, nil)
+			pubip := newPubip(false, nil, nil, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
 
@@ -284,41 +433,288 @@ var _ = Describe("Actuator", func() {
 		})
 	})
 
+	Describe("#SetConfig", func() {
+		It("should use the new configuration for subsequent reconciliations", func() {
+			pubip := newPubip(false, nil, nil, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			newCfg := cfg
+			newCfg.SyncPeriod = metav1.Duration{Duration: 2 * syncPeriod}
+			Expect(actuator.SetConfig(newCfg)).To(Succeed())
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(2 * syncPeriod))
+		})
+
+		It("should fail if the dry-run mode is changed", func() {
+			newCfg := cfg
+			newCfg.DryRun = true
+			Expect(actuator.SetConfig(newCfg)).To(MatchError(ContainSubstring("dry-run mode")))
+		})
+
+		It("should fail if the maintenance window is invalid", func() {
+			newCfg := cfg
+			newCfg.MaintenanceWindow = &config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{Begin: "invalid", End: "04:00"}},
+			}
+			Expect(actuator.SetConfig(newCfg)).To(MatchError(ContainSubstring("could not create maintenance window")))
+		})
+	})
+
 	Describe("#Delete", func() {
 		It("should clean the IP and update the PublicIPAddress object status if the IP is found", func() {
+			mockNotifier := mockcontroller.NewMockNotifier(ctrl)
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, mockNotifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
 			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
-			pubipWithStatus := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipCleaned := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), trackedNotFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonCleaned, "Azure public IP address has been cleaned"))
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
 
 			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
 
+			expectListPubips()
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
 			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
 			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(nil)
 			cleanedIPsCounter.EXPECT().Inc()
+			mockNotifier.EXPECT().Notify(ctx, controller.Notification{
+				Resource: controller.NotificationResource{
+					Kind:       "PublicIPAddress",
+					Namespace:  namespace,
+					Name:       pubipName,
+					ProviderID: azurePublicIPAddressID,
+				},
+				Action:    string(azurev1alpha1.OperationTypeCleanPublicIPAddress),
+				Outcome:   controller.NotificationOutcomeSucceeded,
+				Message:   "Cleaned orphaned Azure public IP address " + ip,
+				Timestamp: now,
+			}).Return(nil)
 
-			expectPatchStatus(pubipWithStatus, pubip).Return(nil)
+			expectPatchStatus(pubipWithStatus, pubipCleaned).Return(nil)
 
 			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(time.Duration(0)))
+			Expect(recorder.Events).To(Receive(Equal("Normal PublicIPAddressCleaned Cleaned orphaned Azure public IP address " + ip)))
+			Expect(targetRecorder.Events).To(Receive(Equal("Normal PublicIPAddressCleaned Cleaned orphaned Azure public IP address " + ip)))
 		})
 
-		It("should not update the PublicIPAddress object status if the IP is not found", func() {
+		It("should refuse to clean the IP if the target cluster appears to be empty", func() {
 			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipRefused := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonAnomalyDetected,
+					"Cleaning refused: all 3 resources appear to be orphaned, the target cluster appears to be empty"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips(*pubipWithStatus, *pubipWithStatus, *pubipWithStatus)
+
+			expectPatchStatus(pubipWithStatus, pubipRefused).Return(nil)
+
+			_, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(HaveOccurred())
+			requeueAfterError, ok := err.(*controllererror.RequeueAfterError)
+			Expect(ok).To(BeTrue())
+			Expect(requeueAfterError.RequeueAfter).To(Equal(requeueInterval))
+			Expect(recorder.Events).To(Receive(Equal("Warning AnomalyDetected Refusing to clean orphaned Azure public IP address " + ip +
+				": all 3 resources appear to be orphaned, the target cluster appears to be empty")))
+		})
+
+		It("should clean the IP if the ratio of orphaned to known IPs is not exceeded", func() {
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipCleaned := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), trackedNotFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonCleaned, "Azure public IP address has been cleaned"))
+			knownPubip := newPubip(true, nil, nil, nil)
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips(*pubipWithStatus, *pubipWithStatus, *pubipWithStatus, *knownPubip, *knownPubip, *knownPubip)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
+			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
+			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(nil)
+			cleanedIPsCounter.EXPECT().Inc()
+
+			expectPatchStatus(pubipWithStatus, pubipCleaned).Return(nil)
+
+			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should wait for approval before cleaning the IP if approval is required", func() {
+			cfg.RequireApproval = true
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipPendingApproval := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionTrue, azurev1alpha1.ConditionReasonApprovalRequired,
+					"Waiting for the azure.remedy.gardener.cloud/approved=true annotation"),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonApprovalRequired,
+					"Waiting for approval to clean the Azure public IP address"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips()
+
+			expectPatchStatus(pubipWithStatus, pubipPendingApproval).Return(nil)
+
+			_, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(HaveOccurred())
+			requeueAfterError, ok := err.(*controllererror.RequeueAfterError)
+			Expect(ok).To(BeTrue())
+			Expect(requeueAfterError.RequeueAfter).To(Equal(requeueInterval))
+			Expect(recorder.Events).To(Receive(Equal("Normal ApprovalRequired Cleaning orphaned Azure public IP address " + ip +
+				" requires approval via the azure.remedy.gardener.cloud/approved=true annotation")))
+		})
+
+		It("should clean the IP if approval is required and it has the approved annotation", func() {
+			cfg.RequireApproval = true
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			annotations := map[string]string{azure.ApprovedAnnotation: "true"}
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, annotations)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, annotations), trackedFound, reachable, notExhausted)
+			pubipCleaned := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, annotations), trackedNotFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionFalse, azurev1alpha1.ConditionReasonApproved, "Cleaning has been approved"),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonCleaned, "Azure public IP address has been cleaned"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips()
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
+			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
+			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(nil)
+			cleanedIPsCounter.EXPECT().Inc()
+
+			expectPatchStatus(pubipWithStatus, pubipCleaned).Return(nil)
+
+			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+			Expect(recorder.Events).To(Receive(Equal("Normal PublicIPAddressCleaned Cleaned orphaned Azure public IP address " + ip)))
+		})
+
+		It("should defer cleaning the IP until the next maintenance window if outside of it", func() {
+			maintenanceWindow, err := utils.NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{
+					Begin: now.UTC().Add(2 * time.Hour).Format("15:04"),
+					End:   now.UTC().Add(3 * time.Hour).Format("15:04"),
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			until := now.Add(2 * time.Hour).Truncate(time.Minute).Sub(now.Time)
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, maintenanceWindow, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipDeferred := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonOutsideMaintenanceWindow,
+					"Waiting for the next maintenance window to clean the Azure public IP address"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips()
+
+			expectPatchStatus(pubipWithStatus, pubipDeferred).Return(nil)
+
+			_, err = actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(HaveOccurred())
+			requeueAfterError, ok := err.(*controllererror.RequeueAfterError)
+			Expect(ok).To(BeTrue())
+			Expect(requeueAfterError.RequeueAfter).To(Equal(until))
+			Expect(recorder.Events).To(Receive(Equal("Normal OutsideMaintenanceWindow Cleaning orphaned Azure public IP address " + ip +
+				" deferred by " + until.Round(time.Second).String() + " until the next maintenance window")))
+		})
+
+		It("should defer cleaning the IP if the destructive action budget is exhausted", func() {
+			exhaustedBudget := mockcontrollerazure.NewMockDestructiveActionBudget(ctrl)
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, exhaustedBudget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipDeferred := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonBudgetExhausted, "Destructive action budget is exhausted, cleaning deferred"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips()
+			exhaustedBudget.EXPECT().Acquire(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress).Return(false, 10*time.Minute)
+
+			expectPatchStatus(pubipWithStatus, pubipDeferred).Return(nil)
+
+			_, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(HaveOccurred())
+			requeueAfterError, ok := err.(*controllererror.RequeueAfterError)
+			Expect(ok).To(BeTrue())
+			Expect(requeueAfterError.RequeueAfter).To(Equal(10 * time.Minute))
+			Expect(recorder.Events).To(Receive(Equal("Warning BudgetExhausted Cleaning orphaned Azure public IP address " + ip + " deferred by 10m0s, destructive action budget exhausted")))
+		})
+
+		It("should only record that the IP would have been cleaned in dry-run mode", func() {
+			cfg.DryRun = true
+			actuator = publicipaddress.NewActuator(c, pubipUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, cleanedIPsCounter, permanentErrorsCounterVec)
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipDryRun := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonDryRun, "Azure public IP address would have been cleaned (dry run)"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
+
+			expectListPubips()
+			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(nil)
+			cleanedIPsCounter.EXPECT().Inc()
+
+			expectPatchStatus(pubipWithStatus, pubipDryRun).Return(nil)
+
+			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+			Expect(recorder.Events).To(Receive(Equal("Normal PublicIPAddressCleaned Would have cleaned orphaned Azure public IP address " + ip + " (dry run)")))
+		})
+
+		It("should only update the PublicIPAddress object conditions if the IP is not found", func() {
+			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithConditions := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), remediedNotFound, trackedNotFound, reachable, notExhausted)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
+
+			expectPatchStatus(pubip, pubipWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(time.Duration(0)))
 		})
 
-		It("should not update the PublicIPAddress object status if the IP is found but doesn't have the service tag", func() {
+		It("should only update the PublicIPAddress object conditions if the IP is found but doesn't have the service tag", func() {
 			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
+			pubipWithConditions := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), remediedNotFound, trackedNotFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, false)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
+
+			expectPatchStatus(pubip, pubipWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
@@ -326,16 +722,20 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should clean the IP and not update the PublicIPAddress object status if the IP is found and the status is already initialized", func() {
-			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
-			pubipWithStatus := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			pubipCleaned := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), trackedNotFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonCleaned, "Azure public IP address has been cleaned"))
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubipWithStatus).Return(nil)
+			expectListPubips()
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
 			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(nil)
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)
 			pubipUtils.EXPECT().Delete(ctx, azurePublicIPAddressName).Return(nil)
 			cleanedIPsCounter.EXPECT().Inc()
 
-			expectPatchStatus(pubipWithStatus, pubip).Return(nil)
+			expectPatchStatus(pubipWithStatus, pubipCleaned).Return(nil)
 
 			requeueAfter, err := actuator.Delete(ctx, pubipWithStatus.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
@@ -343,8 +743,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the PublicIPAddress object status if the IP is not found and the status is already initialized", func() {
-			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
-			pubipWithStatus := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubip := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), trackedNotFound, reachable, notExhausted, remediedNotFound)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(nil, nil)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
 
@@ -356,8 +756,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the PublicIPAddress object status if the IP address has changed and the status is already initialized", func() {
-			pubip := newPubip(false, nil, &earlyDeletionTimestamp, nil)
-			pubipWithStatus := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubip := withConditions(newPubip(false, nil, &earlyDeletionTimestamp, nil), trackedNotFound, reachable, notExhausted, remediedNotFound)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
 			azurePublicIPAddress2 := newAzurePublicIPAddress(ip2, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress2, nil)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(nil, nil)
@@ -372,7 +772,9 @@ var _ = Describe("Actuator", func() {
 		It("should not clean the IP if it has the do-not-clean annotation", func() {
 			annotations := map[string]string{azure.DoNotCleanAnnotation: strconv.FormatBool(true)}
 			pubip := newPubip(false, nil, &earlyDeletionTimestamp, annotations)
-			pubipWithStatus := newPubip(true, nil, &earlyDeletionTimestamp, annotations)
+			pubipWithStatus := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, annotations),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonCleaningSkipped, "Public IP address is annotated with "+azure.DoNotCleanAnnotation),
+				trackedFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
 			expectPatchStatus(pubip, pubipWithStatus).Return(nil)
@@ -383,7 +785,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should fail if updating the PublicIPAddress object status fails", func() {
-			pubip, pubipWithStatus := newPubip(false, nil, nil, nil), newPubip(true, nil, nil, nil)
+			pubip := newPubip(false, nil, nil, nil)
+			pubipWithStatus := withConditions(newPubip(true, nil, nil, nil), remediedInUse, trackedFound, reachable, notExhausted)
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByIP(ctx, ip).Return(azurePublicIPAddress, nil)
 			expectPatchStatus(pubip, pubipWithStatus).Return(errors.New("test"))
@@ -393,8 +796,24 @@ var _ = Describe("Actuator", func() {
 
 		})
 
+		It("should start the grace period before cleaning the IP when trying to delete immediately (now)", func() {
+			pubip := withConditions(newPubip(true, nil, &now, nil), trackedFound, reachable, notExhausted)
+			pubipWithGracePeriod := withConditions(newPubip(true, nil, &now, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonGracePeriodPending, "Waiting for the deletion grace period to elapse"))
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
+
+			expectPatchStatus(pubip, pubipWithGracePeriod).Return(nil)
+
+			_, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			Expect(recorder.Events).To(Receive(Equal("Normal DeletionGracePeriodStarted Azure public IP address " + ip + " will be cleaned after a deletion grace period of 1s")))
+			Expect(targetRecorder.Events).To(Receive(Equal("Normal DeletionGracePeriodStarted Azure public IP address " + ip + " will be cleaned after a deletion grace period of 1s")))
+		})
+
 		It("should honour the grace period before cleaning the IP when trying to delete immediately (now)", func() {
-			pubip := newPubip(true, nil, &now, nil)
+			pubip := withConditions(newPubip(true, nil, &now, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonGracePeriodPending, "Waiting for the deletion grace period to elapse"))
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
@@ -405,12 +824,15 @@ var _ = Describe("Actuator", func() {
 			Expect(ok).To(BeTrue())
 			Expect(requeueAfterError.Cause).To(MatchError("public IP address still exists"))
 			Expect(requeueAfterError.RequeueAfter).To(Equal(cfg.RequeueInterval.Duration))
+			Expect(recorder.Events).NotTo(Receive())
 		})
 
 		It("should fail and requeue if getting the Azure IP address fails", func() {
 			pubip := newPubip(true, nil, &earlyDeletionTimestamp, nil)
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, 1, "could not get Azure public IP address by name: test")
-			pubipWithFailedOps := newPubip(false, failedOps, &earlyDeletionTimestamp, nil)
+			pubipWithFailedOps := withConditions(newPubip(false, failedOps, &earlyDeletionTimestamp, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure public IP address by name: test"),
+				notExhausted)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(nil, errors.New("test"))
 
 			expectPatchStatus(pubip, pubipWithFailedOps).Return(nil)
@@ -427,7 +849,9 @@ var _ = Describe("Actuator", func() {
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, cfg.MaxGetAttempts-1, "could not get Azure public IP address by name: test")
 			pubip := newPubip(true, failedOps, &earlyDeletionTimestamp, nil)
 			failedOps2 := newFailedOps(azurev1alpha1.OperationTypeGetPublicIPAddress, cfg.MaxGetAttempts, "could not get Azure public IP address by name: test")
-			pubip2 := newPubip(false, failedOps2, &earlyDeletionTimestamp, nil)
+			pubip2 := withConditions(newPubip(false, failedOps2, &earlyDeletionTimestamp, nil), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure public IP address by name: test"),
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonMaxAttemptsReached, "Operation GetPublicIPAddress failed 2 times: could not get Azure public IP address by name: test"))
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(nil, errors.New("test"))
 
 			expectPatchStatus(pubip, pubip2).Return(nil)
@@ -438,15 +862,18 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should fail and requeue if removing the Azure IP from the load balancer fails", func() {
-			pubip := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubip := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeCleanPublicIPAddress, 1, "could not remove Azure public IP address from the load balancer: test")
 
-			pubipWithFailedOps := newPubip(true, failedOps, &earlyDeletionTimestamp, nil)
+			pubipWithFailedOps := withConditions(newPubip(true, failedOps, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonCleanFailed, "could not remove Azure public IP address from the load balancer: test"))
 
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
 
 			// cleanIp fails
+			expectListPubips()
+			expectRecordRemedyAction(azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer)
 			pubipUtils.EXPECT().RemoveFromLoadBalancer(ctx, []string{string(azurePublicIPAddressID)}).Return(errors.New("test"))
 
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
@@ -460,11 +887,37 @@ var _ = Describe("Actuator", func() {
 			Expect(requeueAfterError.RequeueAfter).To(Equal(cfg.RequeueInterval.Duration))
 		})
 
+		It("should fail and requeue without removing the Azure IP from the load balancer if recording the remedy action fails", func() {
+			pubip := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
+			failedOps := newFailedOps(azurev1alpha1.OperationTypeCleanPublicIPAddress, 1, "could not remove Azure public IP address from the load balancer: could not create remedyaction: test")
+
+			pubipWithFailedOps := withConditions(newPubip(true, failedOps, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonCleanFailed, "could not remove Azure public IP address from the load balancer: could not create remedyaction: test"))
+
+			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
+			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
+
+			expectListPubips()
+			// recording the remedy action fails
+			remedyActionRecorder.EXPECT().Record(ctx, gomock.Any(), azurev1alpha1.RemedyActionTypeRemovePublicIPAddressFromLoadBalancer, azurePublicIPAddressID, gomock.Any()).
+				Return(errors.Wrap(errors.New("test"), "could not create remedyaction"))
+
+			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: pubipName}, pubip).Return(nil)
+			expectPatchStatus(pubip, pubipWithFailedOps).Return(nil)
+
+			_, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
+			Expect(err).To(HaveOccurred())
+			requeueAfterError, ok := err.(*controllererror.RequeueAfterError)
+			Expect(ok).To(BeTrue())
+			Expect(requeueAfterError.Cause).To(MatchError("could not remove Azure public IP address from the load balancer: could not create remedyaction: test"))
+		})
+
 		It("should fail and requeue if deleting the Azure IP fails", func() {
-			pubip := newPubip(true, nil, &earlyDeletionTimestamp, nil)
+			pubip := withConditions(newPubip(true, nil, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
 
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeCleanPublicIPAddress, 1, "could not delete Azure public IP address: test")
-			pubipWithFailedOps := newPubip(true, failedOps, &earlyDeletionTimestamp, nil)
+			pubipWithFailedOps := withConditions(newPubip(true, failedOps, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonCleanFailed, "could not delete Azure public IP address: test"))
 
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
@@ -484,10 +937,12 @@ var _ = Describe("Actuator", func() {
 
 		It("should not fail if deleting the Azure IP address fails and max attempts have been reached", func() {
 			failedOps := newFailedOps(azurev1alpha1.OperationTypeCleanPublicIPAddress, cfg.MaxCleanAttempts-1, "could not delete Azure public IP address: test")
-			pubip := newPubip(true, failedOps, &earlyDeletionTimestamp, nil)
+			pubip := withConditions(newPubip(true, failedOps, &earlyDeletionTimestamp, nil), trackedFound, reachable, notExhausted)
 
 			failedOps2 := newFailedOps(azurev1alpha1.OperationTypeCleanPublicIPAddress, cfg.MaxCleanAttempts, "could not delete Azure public IP address: test")
-			pubip2 := newPubip(true, failedOps2, &earlyDeletionTimestamp, nil)
+			pubip2 := withConditions(newPubip(true, failedOps2, &earlyDeletionTimestamp, nil), trackedFound, reachable,
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonMaxAttemptsReached, "Operation CleanPublicIPAddress failed 2 times: could not delete Azure public IP address: test"),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonCleanFailed, "could not delete Azure public IP address: test"))
 
 			azurePublicIPAddress := newAzurePublicIPAddress(ip, true)
 			pubipUtils.EXPECT().GetByName(ctx, azurePublicIPAddressName).Return(azurePublicIPAddress, nil)
@@ -500,6 +955,7 @@ var _ = Describe("Actuator", func() {
 			requeueAfter, err := actuator.Delete(ctx, pubip.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Warning MaxAttemptsReached Operation CleanPublicIPAddress failed 2 times, giving up until next sync: could not delete Azure public IP address: test")))
 		})
 	})
 })
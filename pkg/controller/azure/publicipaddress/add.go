@@ -18,9 +18,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -29,8 +32,8 @@ import (
 
 	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/remedy-controller/pkg/apis/config"
-	"github.com/gardener/remedy-controller/pkg/client/azure"
 	remedycontroller "github.com/gardener/remedy-controller/pkg/controller"
+	controllerazure "github.com/gardener/remedy-controller/pkg/controller/azure"
 	"github.com/gardener/remedy-controller/pkg/utils"
 	utilsazure "github.com/gardener/remedy-controller/pkg/utils/azure"
 )
@@ -63,6 +66,14 @@ var (
 			Help: "Number of cleaned Azure public IPs",
 		},
 	)
+
+	// DryRunCleanedIPsCounter is a global counter for Azure public IP addresses that would have been cleaned in dry-run mode.
+	DryRunCleanedIPsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "dry_run_cleaned_azure_public_ips_total",
+			Help: "Number of Azure public IPs that would have been cleaned in dry-run mode",
+		},
+	)
 )
 
 // AddOptions are options to apply when adding a controller to a manager.
@@ -70,34 +81,98 @@ type AddOptions struct {
 	// Controller are the controller.Options.
 	Controller controller.Options
 	// InfraConfigPath is the path to the infrastructure configuration file.
+	// Only used if Targets is empty.
 	InfraConfigPath string
+	// TargetRecorder is the event recorder for the target cluster, used to emit events on the service
+	// the tracked resource belongs to. If nil, no such events are emitted. Only used if Targets is empty.
+	TargetRecorder record.EventRecorder
+	// Targets are the target clusters, each with its own namespace in the control cluster and Azure credentials.
+	// If empty, a single target cluster specified by InfraConfigPath and TargetRecorder is used for all namespaces.
+	// Targets are only set up once when the controller is added, so adding or removing targets requires a restart.
+	Targets []controllerazure.Target
+	// RateLimiter is the rate limiter for Azure API requests, which should be shared by all Azure controllers.
+	// If nil, Azure API requests are not rate limited.
+	RateLimiter utilsazure.RateLimiter
+	// Notifier is the notifier for remedy outcomes, which should be shared by all controllers.
+	// If nil, no notifications are sent.
+	Notifier remedycontroller.Notifier
 	// Config is the configuration for the Azure orphaned public IP remedy.
 	Config config.AzureOrphanedPublicIPRemedyConfiguration
+	// ConfigSource provides changes to the configuration for the Azure orphaned public IP remedy at runtime,
+	// e.g. when the configuration file is reloaded. Its initial configuration should be equal to Config.
+	// If nil, the configuration can't be changed at runtime.
+	ConfigSource remedycontroller.ConfigSource[config.AzureOrphanedPublicIPRemedyConfiguration]
 }
 
 // AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
 func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
-	// Read Azure credentials from infrastructure config file
-	credentials, err := azure.ReadConfig(options.InfraConfigPath)
-	if err != nil {
-		return errors.Wrap(err, "could not read Azure credentials from infrastructure configuration file")
+	// Count IPs that would have been cleaned separately in dry-run mode
+	cleanedIPsCounter := CleanedIPsCounter
+	if options.Config.DryRun {
+		cleanedIPsCounter = DryRunCleanedIPsCounter
+	}
+
+	rateLimiter := options.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = utilsazure.NewRateLimiter(nil, utilsazure.ReadRequestsWaitSecondsCounter, utilsazure.WriteRequestsWaitSecondsCounter)
+	}
+
+	notifier := options.Notifier
+	if notifier == nil {
+		notifier = remedycontroller.NewNotifier(nil, log.Log.WithName(ActuatorName))
 	}
 
-	// Create Azure clients
-	azureClients, err := azure.NewClients(credentials)
+	maintenanceWindow, err := utils.NewMaintenanceWindow(options.Config.MaintenanceWindow)
 	if err != nil {
-		return errors.Wrap(err, "could not create Azure clients")
+		return errors.Wrap(err, "could not create maintenance window")
+	}
+
+	remedyActionRecorder := controllerazure.NewRemedyActionRecorder(mgr.GetClient(), utils.TimestamperFunc(metav1.Now), log.Log.WithName(ActuatorName))
+	recorder := mgr.GetEventRecorderFor(ControllerName)
+
+	targets := options.Targets
+	if len(targets) == 0 {
+		targets = []controllerazure.Target{{InfraConfigPath: options.InfraConfigPath, Recorder: options.TargetRecorder}}
+	}
+	actuators := make(map[string]remedycontroller.Actuator, len(targets))
+	var configurableActuators []remedycontroller.ConfigurableActuator[config.AzureOrphanedPublicIPRemedyConfiguration]
+	for _, target := range targets {
+		azureClients, credentials, err := target.AzureClients()
+		if err != nil {
+			return err
+		}
+
+		actuator := NewActuator(mgr.GetClient(), utilsazure.NewPublicIPAddressUtils(azureClients, credentials.ResourceGroup, utilsazure.ReadRequestsCounter, utilsazure.WriteRequestsCounter, rateLimiter, options.Config.DryRun),
+			options.Config, utils.TimestamperFunc(metav1.Now), recorder, target.Recorder, remedyActionRecorder, target.DestructiveActionBudget(), maintenanceWindow, notifier, log.Log.WithName(ActuatorName), cleanedIPsCounter, utilsazure.PermanentErrorsCounterVec)
+		actuators[target.Namespace] = actuator
+		configurableActuators = append(configurableActuators, actuator)
+	}
+
+	// Propagate configuration changes to all actuators
+	if options.ConfigSource != nil {
+		logger := log.Log.WithName(ActuatorName)
+		options.ConfigSource.Subscribe(func(oldConfig, newConfig config.AzureOrphanedPublicIPRemedyConfiguration) error {
+			logger.Info("Configuration changed", "diff", cmp.Diff(oldConfig, newConfig))
+			var errs []error
+			for _, actuator := range configurableActuators {
+				if err := actuator.SetConfig(newConfig); err != nil {
+					errs = append(errs, errors.Wrap(err, "could not update actuator configuration"))
+				}
+			}
+			return utilerrors.NewAggregate(errs)
+		})
 	}
 
 	return remedycontroller.Add(mgr, remedycontroller.AddArgs{
-		Actuator: NewActuator(mgr.GetClient(), utilsazure.NewPublicIPAddressUtils(azureClients, credentials.ResourceGroup, utilsazure.ReadRequestsCounter, utilsazure.WriteRequestsCounter),
-			options.Config, utils.TimestamperFunc(metav1.Now), log.Log.WithName(ActuatorName), CleanedIPsCounter),
+		Actuator:          remedycontroller.NewNamespacedActuator(actuators),
 		ControllerName:    ControllerName,
 		FinalizerName:     FinalizerName,
 		ControllerOptions: options.Controller,
+		Recorder:          recorder,
 		Type:              &azurev1alpha1.PublicIPAddress{},
 		Predicates: []predicate.Predicate{
-			predicate.GenerationChangedPredicate{},
+			// Annotation changes are needed to react promptly to the paused and approved annotations
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
 		},
 	})
 }
@@ -109,5 +184,5 @@ func AddToManager(_ context.Context, mgr manager.Manager) error {
 
 func init() {
 	// Register metrics with the global Prometheus registry
-	metrics.Registry.MustRegister(CleanedIPsCounter)
+	metrics.Registry.MustRegister(CleanedIPsCounter, DryRunCleanedIPsCounter)
 }
@@ -0,0 +1,116 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+// RemedyActionRecorder records destructive actions performed by remedies on Azure resources as RemedyAction objects.
+type RemedyActionRecorder interface {
+	// Record creates a RemedyAction of the given type for the given Azure resource and Kubernetes object,
+	// performs the action by calling the given function, and updates the RemedyAction status with its outcome.
+	// If the RemedyAction could not be created, the action is not performed and an error is returned.
+	// Otherwise, the error returned by the given function is returned.
+	Record(ctx context.Context, triggeredBy client.Object, actionType azurev1alpha1.RemedyActionType, azureResourceID string, action func() error) error
+}
+
+// NewRemedyActionRecorder creates a new RemedyActionRecorder.
+func NewRemedyActionRecorder(client client.Client, timestamper utils.Timestamper, logger logr.Logger) RemedyActionRecorder {
+	return &remedyActionRecorder{
+		client:      client,
+		timestamper: timestamper,
+		logger:      logger,
+	}
+}
+
+type remedyActionRecorder struct {
+	client      client.Client
+	timestamper utils.Timestamper
+	logger      logr.Logger
+}
+
+// Record creates a RemedyAction of the given type for the given Azure resource and Kubernetes object,
+// performs the action by calling the given function, and updates the RemedyAction status with its outcome.
+func (r *remedyActionRecorder) Record(
+	ctx context.Context,
+	triggeredBy client.Object,
+	actionType azurev1alpha1.RemedyActionType,
+	azureResourceID string,
+	action func() error,
+) error {
+	gvk, err := apiutil.GVKForObject(triggeredBy, r.client.Scheme())
+	if err != nil {
+		return errors.Wrap(err, "could not determine the kind of the object triggering the remedy action")
+	}
+
+	// Create the remedy action before performing the action.
+	// No owner reference is set, since the action should be retained after the triggering object is deleted.
+	remedyAction := &azurev1alpha1.RemedyAction{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: triggeredBy.GetName() + "-",
+			Namespace:    triggeredBy.GetNamespace(),
+		},
+		Spec: azurev1alpha1.RemedyActionSpec{
+			Type:            actionType,
+			AzureResourceID: azureResourceID,
+			TriggeredBy: corev1.ObjectReference{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Namespace:  triggeredBy.GetNamespace(),
+				Name:       triggeredBy.GetName(),
+				UID:        triggeredBy.GetUID(),
+			},
+		},
+	}
+	r.logger.Info("Creating remedyaction", "type", actionType, "azureResourceID", azureResourceID)
+	if err := r.client.Create(ctx, remedyAction); err != nil {
+		return errors.Wrap(err, "could not create remedyaction")
+	}
+
+	// Perform the action
+	startTime := r.timestamper.Now()
+	actionErr := action()
+	completionTime := r.timestamper.Now()
+
+	// Update the remedy action status with the outcome of the action
+	patch := client.MergeFrom(remedyAction.DeepCopy())
+	remedyAction.Status = azurev1alpha1.RemedyActionStatus{
+		Outcome:        azurev1alpha1.RemedyActionOutcomeSucceeded,
+		StartTime:      &startTime,
+		CompletionTime: &completionTime,
+	}
+	if actionErr != nil {
+		remedyAction.Status.Outcome = azurev1alpha1.RemedyActionOutcomeFailed
+		remedyAction.Status.ErrorMessage = actionErr.Error()
+	}
+	r.logger.Info("Updating remedyaction status", "name", remedyAction.Name, "namespace", remedyAction.Namespace, "status", remedyAction.Status)
+	if err := r.client.Status().Patch(ctx, remedyAction, patch); err != nil {
+		// The action has already been performed, so only log the error
+		r.logger.Error(err, "Could not update remedyaction status", "name", remedyAction.Name, "namespace", remedyAction.Namespace)
+	}
+
+	return actionErr
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remedyaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	"github.com/gardener/remedy-controller/pkg/controller"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+type actuator struct {
+	client      client.Client
+	config      config.AzureRemedyActionsConfiguration
+	timestamper utils.Timestamper
+	logger      logr.Logger
+}
+
+// NewActuator creates a new Actuator.
+func NewActuator(
+	client client.Client,
+	config config.AzureRemedyActionsConfiguration,
+	timestamper utils.Timestamper,
+	logger logr.Logger,
+) controller.Actuator {
+	logger.Info("Creating actuator", "config", config)
+	return &actuator{
+		client:      client,
+		config:      config,
+		timestamper: timestamper,
+		logger:      logger,
+	}
+}
+
+// CreateOrUpdate reconciles object creation or update.
+func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (requeueAfter time.Duration, err error) {
+	// Cast object to RemedyAction
+	var remedyAction *azurev1alpha1.RemedyAction
+	var ok bool
+	if remedyAction, ok = obj.(*azurev1alpha1.RemedyAction); !ok {
+		return 0, errors.New("reconciled object is not a remedyaction")
+	}
+
+	// Keep the remedy action until its action has been completed, so that its final status is not lost.
+	// The remedy action is reconciled again when its completion time is set.
+	if remedyAction.Status.CompletionTime == nil {
+		return 0, nil
+	}
+
+	// Requeue if the retention period has not yet elapsed
+	expirationTime := remedyAction.Status.CompletionTime.Add(a.config.RetentionPeriod.Duration)
+	if now := a.timestamper.Now(); now.Time.Before(expirationTime) {
+		return expirationTime.Sub(now.Time), nil
+	}
+
+	// Delete the remedy action
+	a.logger.Info("Deleting remedyaction after its retention period has elapsed", "name", remedyAction.Name, "namespace", remedyAction.Namespace)
+	if err := client.IgnoreNotFound(a.client.Delete(ctx, remedyAction)); err != nil {
+		return 0, errors.Wrap(err, "could not delete remedyaction")
+	}
+
+	return 0, nil
+}
+
+// Delete reconciles object deletion.
+func (a *actuator) Delete(_ context.Context, _ client.Object) (requeueAfter time.Duration, err error) {
+	return 0, nil
+}
+
+// ShouldFinalize returns true if the object should be finalized.
+// Remedy actions have no external resources that need to be cleaned up, so they are never finalized.
+func (a *actuator) ShouldFinalize(_ context.Context, _ client.Object) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remedyaction_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	"github.com/gardener/remedy-controller/pkg/controller"
+	"github.com/gardener/remedy-controller/pkg/controller/azure/remedyaction"
+	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("Actuator", func() {
+	const (
+		name      = "test-service-1.2.3.4-abcde"
+		namespace = "test"
+
+		retentionPeriod = 24 * time.Hour
+	)
+
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		c *mockclient.MockClient
+
+		now         metav1.Time
+		timestamper utils.Timestamper
+		logger      logr.Logger
+		actuator    controller.Actuator
+
+		newRemedyAction func(creationTimestamp metav1.Time, completionTime *metav1.Time) *azurev1alpha1.RemedyAction
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		c = mockclient.NewMockClient(ctrl)
+
+		now = metav1.Now()
+		timestamper = utils.TimestamperFunc(func() metav1.Time { return now })
+		logger = log.Log.WithName("test")
+		actuator = remedyaction.NewActuator(c, config.AzureRemedyActionsConfiguration{
+			RetentionPeriod: metav1.Duration{Duration: retentionPeriod},
+		}, timestamper, logger)
+
+		newRemedyAction = func(creationTimestamp metav1.Time, completionTime *metav1.Time) *azurev1alpha1.RemedyAction {
+			return &azurev1alpha1.RemedyAction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         namespace,
+					CreationTimestamp: creationTimestamp,
+				},
+				Spec: azurev1alpha1.RemedyActionSpec{
+					Type: azurev1alpha1.RemedyActionTypeDeletePublicIPAddress,
+				},
+				Status: azurev1alpha1.RemedyActionStatus{
+					Outcome:        azurev1alpha1.RemedyActionOutcomeSucceeded,
+					CompletionTime: completionTime,
+				},
+			}
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#CreateOrUpdate", func() {
+		It("should requeue until the retention period after the completion time has elapsed", func() {
+			completionTime := metav1.NewTime(now.Add(-time.Hour))
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-2*time.Hour)), &completionTime)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(retentionPeriod - time.Hour))
+		})
+
+		It("should not delete the RemedyAction object if the action has not been completed", func() {
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-2*retentionPeriod)), nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should not delete the RemedyAction object if the action has not been completed, even if the retention period is zero", func() {
+			actuator = remedyaction.NewActuator(c, config.AzureRemedyActionsConfiguration{}, timestamper, logger)
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-time.Minute)), nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should delete the RemedyAction object as soon as the action has been completed if the retention period is zero", func() {
+			actuator = remedyaction.NewActuator(c, config.AzureRemedyActionsConfiguration{}, timestamper, logger)
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-time.Minute)), &now)
+			c.EXPECT().Delete(ctx, remedyAction).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should delete the RemedyAction object if the retention period has elapsed", func() {
+			completionTime := metav1.NewTime(now.Add(-retentionPeriod))
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-retentionPeriod-time.Minute)), &completionTime)
+			c.EXPECT().Delete(ctx, remedyAction).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should not fail if the RemedyAction object has already been deleted", func() {
+			completionTime := metav1.NewTime(now.Add(-retentionPeriod))
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-2*retentionPeriod)), &completionTime)
+			c.EXPECT().Delete(ctx, remedyAction).Return(apierrors.NewNotFound(schema.GroupResource{}, name))
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+
+		It("should fail if deleting the RemedyAction object fails", func() {
+			completionTime := metav1.NewTime(now.Add(-retentionPeriod))
+			remedyAction := newRemedyAction(metav1.NewTime(now.Add(-2*retentionPeriod)), &completionTime)
+			c.EXPECT().Delete(ctx, remedyAction).Return(errors.New("test"))
+
+			_, err := actuator.CreateOrUpdate(ctx, remedyAction)
+			Expect(err).To(MatchError("could not delete remedyaction: test"))
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("should do nothing", func() {
+			requeueAfter, err := actuator.Delete(ctx, newRemedyAction(now, nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Describe("#ShouldFinalize", func() {
+		It("should return false", func() {
+			shouldFinalize, err := actuator.ShouldFinalize(ctx, newRemedyAction(now, nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shouldFinalize).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,92 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remedyaction
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	remedycontroller "github.com/gardener/remedy-controller/pkg/controller"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+const (
+	// ControllerName is the name of the Azure remedy action controller.
+	ControllerName = "azureremedyaction-controller"
+	// ActuatorName is the name of the Azure remedy action actuator.
+	ActuatorName = "azureremedyaction-actuator"
+)
+
+// DefaultAddOptions are the default AddOptions for AddToManager.
+var DefaultAddOptions = AddOptions{
+	Config: config.AzureRemedyActionsConfiguration{
+		RetentionPeriod: metav1.Duration{Duration: 30 * 24 * time.Hour},
+	},
+}
+
+// AddOptions are options to apply when adding a controller to a manager.
+type AddOptions struct {
+	// Controller are the controller.Options.
+	Controller controller.Options
+	// Config is the configuration for Azure remedy actions.
+	Config config.AzureRemedyActionsConfiguration
+}
+
+// AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
+func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
+	return remedycontroller.Add(mgr, remedycontroller.AddArgs{
+		Actuator:          NewActuator(mgr.GetClient(), options.Config, utils.TimestamperFunc(metav1.Now), log.Log.WithName(ActuatorName)),
+		ControllerName:    ControllerName,
+		ControllerOptions: options.Controller,
+		Type:              &azurev1alpha1.RemedyAction{},
+		Predicates: []predicate.Predicate{
+			// Completion time changes are needed to start the retention period once the action has been completed
+			predicate.Or(predicate.GenerationChangedPredicate{}, completionTimeChangedPredicate()),
+		},
+	})
+}
+
+// completionTimeChangedPredicate returns a predicate that accepts updates of RemedyAction objects
+// whose completion time has changed.
+func completionTimeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldRemedyAction, ok := e.ObjectOld.(*azurev1alpha1.RemedyAction)
+			if !ok {
+				return false
+			}
+			newRemedyAction, ok := e.ObjectNew.(*azurev1alpha1.RemedyAction)
+			if !ok {
+				return false
+			}
+			return !ptr.Equal(oldRemedyAction.Status.CompletionTime, newRemedyAction.Status.CompletionTime)
+		},
+	}
+}
+
+// AddToManager adds a controller with the default AddOptions to the given manager.
+func AddToManager(_ context.Context, mgr manager.Manager) error {
+	return AddToManagerWithOptions(mgr, DefaultAddOptions)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	azureinstall "github.com/gardener/remedy-controller/pkg/apis/azure/install"
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	. "github.com/gardener/remedy-controller/pkg/controller/azure"
+	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("RemedyActionRecorder", func() {
+	const (
+		pubipName       = "test-service-1.2.3.4"
+		namespace       = "test"
+		azureResourceID = "/subscriptions/xxx/resourceGroups/shoot--dev--test/providers/Microsoft.Network/publicIPAddresses/shoot--dev--test-ip1"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		c  *mockclient.MockClient
+		sw *mockclient.MockStatusWriter
+
+		now      metav1.Time
+		recorder RemedyActionRecorder
+
+		pubip        *azurev1alpha1.PublicIPAddress
+		remedyAction *azurev1alpha1.RemedyAction
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		scheme := runtime.NewScheme()
+		Expect(azureinstall.AddToScheme(scheme)).To(Succeed())
+		c = mockclient.NewMockClient(ctrl)
+		sw = mockclient.NewMockStatusWriter(ctrl)
+		c.EXPECT().Scheme().Return(scheme).AnyTimes()
+		c.EXPECT().Status().Return(sw).AnyTimes()
+
+		now = metav1.NewTime(time.Now().Truncate(time.Second))
+		recorder = NewRemedyActionRecorder(c, utils.TimestamperFunc(func() metav1.Time { return now }), log.Log.WithName("test"))
+
+		pubip = &azurev1alpha1.PublicIPAddress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pubipName,
+				Namespace: namespace,
+				UID:       "test-uid",
+			},
+		}
+		remedyAction = &azurev1alpha1.RemedyAction{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: pubipName + "-",
+				Namespace:    namespace,
+			},
+			Spec: azurev1alpha1.RemedyActionSpec{
+				Type:            azurev1alpha1.RemedyActionTypeDeletePublicIPAddress,
+				AzureResourceID: azureResourceID,
+				TriggeredBy: corev1.ObjectReference{
+					APIVersion: azurev1alpha1.SchemeGroupVersion.String(),
+					Kind:       "PublicIPAddress",
+					Namespace:  namespace,
+					Name:       pubipName,
+					UID:        "test-uid",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Record", func() {
+		It("should create a RemedyAction object, perform the action, and record its success", func() {
+			remedyActionCompleted := remedyAction.DeepCopy()
+			remedyActionCompleted.Status = azurev1alpha1.RemedyActionStatus{
+				Outcome:        azurev1alpha1.RemedyActionOutcomeSucceeded,
+				StartTime:      &now,
+				CompletionTime: &now,
+			}
+			performed := false
+
+			gomock.InOrder(
+				c.EXPECT().Create(ctx, remedyAction).Return(nil),
+				sw.EXPECT().Patch(ctx, remedyActionCompleted, gomock.Any()).Return(nil),
+			)
+
+			Expect(recorder.Record(ctx, pubip, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress, azureResourceID, func() error {
+				performed = true
+				return nil
+			})).To(Succeed())
+			Expect(performed).To(BeTrue())
+		})
+
+		It("should record the failure of the action and return its error", func() {
+			remedyActionFailed := remedyAction.DeepCopy()
+			remedyActionFailed.Status = azurev1alpha1.RemedyActionStatus{
+				Outcome:        azurev1alpha1.RemedyActionOutcomeFailed,
+				ErrorMessage:   "test",
+				StartTime:      &now,
+				CompletionTime: &now,
+			}
+
+			gomock.InOrder(
+				c.EXPECT().Create(ctx, remedyAction).Return(nil),
+				sw.EXPECT().Patch(ctx, remedyActionFailed, gomock.Any()).Return(nil),
+			)
+
+			Expect(recorder.Record(ctx, pubip, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress, azureResourceID, func() error {
+				return errors.New("test")
+			})).To(MatchError("test"))
+		})
+
+		It("should not perform the action if creating the RemedyAction object fails", func() {
+			performed := false
+
+			c.EXPECT().Create(ctx, remedyAction).Return(errors.New("test"))
+
+			Expect(recorder.Record(ctx, pubip, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress, azureResourceID, func() error {
+				performed = true
+				return nil
+			})).To(MatchError("could not create remedyaction: test"))
+			Expect(performed).To(BeFalse())
+		})
+
+		It("should not fail if updating the RemedyAction object status fails", func() {
+			gomock.InOrder(
+				c.EXPECT().Create(ctx, remedyAction).Return(nil),
+				sw.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(errors.New("test")),
+			)
+
+			Expect(recorder.Record(ctx, pubip, azurev1alpha1.RemedyActionTypeDeletePublicIPAddress, azureResourceID, func() error {
+				return nil
+			})).To(Succeed())
+		})
+	})
+})
@@ -82,6 +82,7 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 			if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				_, err := controllerutil.CreateOrUpdate(ctx, a.client, pubip, func() error {
 					pubip.Labels = pubipLabels
+					pubip.Annotations = utils.Add(pubip.Annotations, azure.ServiceLabel, ObjectLabeler.GetIdentity(svc))
 					delete(pubip.Annotations, azure.DoNotCleanAnnotation)
 					pubip.Spec.IPAddress = ip
 					return nil
@@ -195,5 +196,5 @@ func shouldIgnoreService(svc *corev1.Service) bool {
 }
 
 func generatePublicIPAddressName(serviceNamespace, serviceName, ip string) string {
-	return serviceNamespace + "-" + serviceName + "-" + ip
+	return controller.ShortenName(serviceNamespace + "-" + serviceName + "-" + ip)
 }
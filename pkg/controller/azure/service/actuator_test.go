@@ -133,6 +133,9 @@ var _ = Describe("Actuator", func() {
 				Name:      serviceNamespace + "-" + serviceName + "-" + ip,
 				Namespace: namespace,
 				Labels:    pubipLabels,
+				Annotations: map[string]string{
+					azure.ServiceLabel: serviceNamespace + "." + serviceName,
+				},
 			},
 			Spec: azurev1alpha1.PublicIPAddressSpec{
 				IPAddress: ip,
@@ -144,6 +147,7 @@ var _ = Describe("Actuator", func() {
 				Namespace: namespace,
 				Labels:    pubipLabels,
 				Annotations: map[string]string{
+					azure.ServiceLabel:         serviceNamespace + "." + serviceName,
 					azure.DoNotCleanAnnotation: strconv.FormatBool(true),
 				},
 			},
@@ -19,9 +19,11 @@ import (
 	"time"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -34,6 +36,7 @@ import (
 	"github.com/gardener/remedy-controller/pkg/apis/config"
 	remedycontroller "github.com/gardener/remedy-controller/pkg/controller"
 	"github.com/gardener/remedy-controller/pkg/controller/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
 )
 
 const (
@@ -45,6 +48,10 @@ const (
 	PredicateName = "azureservice-predicate"
 	// PublicIPAddressPredicateName is the name of the predicate of the Azure service controller for filtering publicipaddress events.
 	PublicIPAddressPredicateName = "azureservice-publicipaddress-predicate"
+	// GarbageCollectorName is the name of the garbage collector for publicipaddress resources of the Azure service controller.
+	GarbageCollectorName = "azureservice-garbage-collector"
+	// IdentityMigratorName is the name of the identity migrator for publicipaddress resources of the Azure service controller.
+	IdentityMigratorName = "azureservice-identity-migrator"
 	// FinalizerName is the finalizer to put on service resources.
 	FinalizerName = "azure.remedy.gardener.cloud/service"
 )
@@ -71,15 +78,50 @@ type AddOptions struct {
 	Namespace string
 	// Manager is the control cluster manager.
 	Manager manager.Manager
+	// TargetName is the name of the target cluster if there are multiple target clusters.
+	// If specified, it is appended to the names of the controller and its runnables to make them unique.
+	TargetName string
 	// Config is the configuration for the Azure orphaned public IP remedy.
 	Config config.AzureOrphanedPublicIPRemedyConfiguration
 }
 
 // AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
 func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
+	controllerName, identityMigratorName, garbageCollectorName := ControllerName, IdentityMigratorName, GarbageCollectorName
+	if options.TargetName != "" {
+		controllerName += "-" + options.TargetName
+		identityMigratorName += "-" + options.TargetName
+		garbageCollectorName += "-" + options.TargetName
+	}
+
+	if err := mgr.Add(remedycontroller.NewIdentityMigrator(remedycontroller.IdentityMigratorArgs{
+		Client:    options.Client,
+		Namespace: options.Namespace,
+		ListType:  &azurev1alpha1.PublicIPAddressList{},
+		Label:     azure.ServiceLabel,
+	}, log.Log.WithName(identityMigratorName))); err != nil {
+		return errors.Wrap(err, "could not add identity migrator to manager")
+	}
+
+	if options.Config.ServiceGarbageCollectionPeriod.Duration > 0 {
+		if err := mgr.Add(remedycontroller.NewGarbageCollector(remedycontroller.GarbageCollectorArgs{
+			Client:        options.Client,
+			TargetReader:  mgr.GetAPIReader(),
+			Namespace:     options.Namespace,
+			ListType:      &azurev1alpha1.PublicIPAddressList{},
+			TargetType:    &corev1.Service{},
+			Label:         azure.ServiceLabel,
+			ObjectLabeler: ObjectLabeler,
+			Period:        options.Config.ServiceGarbageCollectionPeriod.Duration,
+			AnomalyGuard:  ptr.To(utils.NewAnomalyGuard(options.Config.AnomalyGuard)),
+		}, log.Log.WithName(garbageCollectorName))); err != nil {
+			return errors.Wrap(err, "could not add garbage collector to manager")
+		}
+	}
+
 	return remedycontroller.Add(mgr, remedycontroller.AddArgs{
 		Actuator:            NewActuator(options.Client, options.Namespace, options.Config.ServiceSyncPeriod.Duration, log.Log.WithName(ActuatorName)),
-		ControllerName:      ControllerName,
+		ControllerName:      controllerName,
 		FinalizerName:       FinalizerName,
 		ControllerOptions:   options.Controller,
 		Type:                &corev1.Service{},
@@ -93,6 +135,7 @@ func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
 				source.Kind[client.Object](options.Manager.GetCache(),
 					&azurev1alpha1.PublicIPAddress{},
 					handler.TypedEnqueueRequestsFromMapFunc(remedycontroller.MapFuncFromMapper(serviceMapper)),
+					remedycontroller.NewNamespacePredicate(options.Namespace),
 					remedycontroller.NewOwnedObjectPredicate(&corev1.Service{}, mgr.GetCache(), serviceMapper, FinalizerName, log.Log.WithName(PublicIPAddressPredicateName)),
 				),
 			)
@@ -0,0 +1,69 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+// Target is a target cluster whose resources are tracked by custom resources in a namespace of the control cluster.
+type Target struct {
+	// Namespace is the namespace of custom resources for the target cluster in the control cluster.
+	// If empty, custom resources in namespaces without a target of their own belong to the target cluster.
+	Namespace string
+	// InfraConfigPath is the path to the infrastructure configuration file containing the Azure credentials for the target cluster.
+	InfraConfigPath string
+	// CredentialsWatcher provides the Azure credentials and clients for the target cluster, which are rebuilt whenever
+	// the infrastructure configuration file changes. If nil, the infrastructure configuration file is read only once.
+	CredentialsWatcher azure.CredentialsWatcher
+	// Recorder is the event recorder for the target cluster, used to emit events on the service or node
+	// the tracked resource belongs to. If nil, no such events are emitted.
+	Recorder record.EventRecorder
+	// Budget is the budget for destructive actions in the target cluster, which should be shared by all controllers
+	// of the target cluster. If nil, destructive actions are not limited.
+	Budget DestructiveActionBudget
+}
+
+// DestructiveActionBudget returns the budget for destructive actions in this target cluster.
+// If no budget is set, it returns a budget that doesn't limit destructive actions.
+func (t Target) DestructiveActionBudget() DestructiveActionBudget {
+	if t.Budget != nil {
+		return t.Budget
+	}
+	return NewDestructiveActionBudget(nil, nil, nil)
+}
+
+// AzureClients returns the Azure clients and credentials for this target cluster.
+func (t Target) AzureClients() (azure.ClientsProvider, *azure.Credentials, error) {
+	if t.CredentialsWatcher != nil {
+		return t.CredentialsWatcher, t.CredentialsWatcher.Credentials(), nil
+	}
+
+	// Read Azure credentials from infrastructure config file
+	credentials, err := azure.ReadConfig(t.InfraConfigPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read Azure credentials from infrastructure configuration file %s", t.InfraConfigPath)
+	}
+
+	// Create Azure clients
+	azureClients, err := azure.NewClients(credentials)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create Azure clients")
+	}
+	return azureClients, credentials, nil
+}
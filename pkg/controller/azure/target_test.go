@@ -0,0 +1,47 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/controller/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("Target", func() {
+	Describe("#DestructiveActionBudget", func() {
+		It("should return the budget of the target cluster", func() {
+			budget := NewDestructiveActionBudget(&config.AzureDestructiveActionBudgetConfiguration{
+				MaxPublicIPAddressDeletions: ptr.To(0),
+			}, utils.TimestamperFunc(metav1.Now), nil)
+			target := Target{Budget: budget}
+			Expect(target.DestructiveActionBudget()).To(BeIdenticalTo(budget))
+		})
+
+		It("should return a budget that doesn't limit destructive actions if the target cluster has no budget", func() {
+			target := Target{}
+			for range 100 {
+				Expect(acquire(target.DestructiveActionBudget(), azurev1alpha1.RemedyActionTypeDeletePublicIPAddress)).To(BeTrue())
+				Expect(acquire(target.DestructiveActionBudget(), azurev1alpha1.RemedyActionTypeReapplyVirtualMachine)).To(BeTrue())
+			}
+		})
+	})
+})
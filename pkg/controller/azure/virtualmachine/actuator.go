@@ -16,20 +16,30 @@ package virtualmachine
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/remedy-controller/pkg/apis/config"
 	"github.com/gardener/remedy-controller/pkg/controller"
+	controllerazure "github.com/gardener/remedy-controller/pkg/controller/azure"
+	"github.com/gardener/remedy-controller/pkg/controller/azure/node"
 	"github.com/gardener/remedy-controller/pkg/utils"
 	"github.com/gardener/remedy-controller/pkg/utils/azure"
 	utilsprometheus "github.com/gardener/remedy-controller/pkg/utils/prometheus"
@@ -42,16 +52,33 @@ const (
 	VMStateFailedWillReapply float64 = 1
 	// VMStateFailed is a constant for a Failed state of an Azure virtual machine.
 	VMStateFailed float64 = 2
+
+	// provisioningStateSucceeded is the provisioning state of a succeeded Azure virtual machine.
+	provisioningStateSucceeded = "Succeeded"
+	// provisioningStateFailed is the provisioning state of a failed Azure virtual machine.
+	provisioningStateFailed = "Failed"
 )
 
+// settings are the parts of an actuator that are derived from its configuration and can be replaced at runtime.
+type settings struct {
+	config            config.AzureFailedVMRemedyConfiguration
+	maintenanceWindow *utils.MaintenanceWindow
+}
+
 type actuator struct {
-	client              client.Client
-	vmUtils             azure.VirtualMachineUtils
-	config              config.AzureFailedVMRemedyConfiguration
-	timestamper         utils.Timestamper
-	logger              logr.Logger
-	reappliedVMsCounter prometheus.Counter
-	vmStatesGaugeVec    utilsprometheus.GaugeVec
+	client                    client.Client
+	vmUtils                   azure.VirtualMachineUtils
+	timestamper               utils.Timestamper
+	recorder                  record.EventRecorder
+	targetRecorder            record.EventRecorder
+	remedyActionRecorder      controllerazure.RemedyActionRecorder
+	budget                    controllerazure.DestructiveActionBudget
+	settings                  atomic.Pointer[settings]
+	notifier                  controller.Notifier
+	logger                    logr.Logger
+	reappliedVMsCounter       prometheus.Counter
+	vmStatesGaugeVec          utilsprometheus.GaugeVec
+	permanentErrorsCounterVec utilsprometheus.CounterVec
 }
 
 // NewActuator creates a new Actuator.
@@ -60,20 +87,67 @@ func NewActuator(
 	vmUtils azure.VirtualMachineUtils,
 	config config.AzureFailedVMRemedyConfiguration,
 	timestamper utils.Timestamper,
+	recorder record.EventRecorder,
+	targetRecorder record.EventRecorder,
+	remedyActionRecorder controllerazure.RemedyActionRecorder,
+	budget controllerazure.DestructiveActionBudget,
+	maintenanceWindow *utils.MaintenanceWindow,
+	notifier controller.Notifier,
 	logger logr.Logger,
 	reappliedVMsCounter prometheus.Counter,
 	vmStatesGaugeVec utilsprometheus.GaugeVec,
-) controller.Actuator {
+	permanentErrorsCounterVec utilsprometheus.CounterVec,
+) controller.ConfigurableActuator[config.AzureFailedVMRemedyConfiguration] {
 	logger.Info("Creating actuator", "config", config)
-	return &actuator{
-		client:              client,
-		vmUtils:             vmUtils,
-		config:              config,
-		timestamper:         timestamper,
-		logger:              logger,
-		reappliedVMsCounter: reappliedVMsCounter,
-		vmStatesGaugeVec:    vmStatesGaugeVec,
+	a := &actuator{
+		client:                    client,
+		vmUtils:                   vmUtils,
+		timestamper:               timestamper,
+		recorder:                  recorder,
+		targetRecorder:            targetRecorder,
+		remedyActionRecorder:      remedyActionRecorder,
+		budget:                    budget,
+		notifier:                  notifier,
+		logger:                    logger,
+		reappliedVMsCounter:       reappliedVMsCounter,
+		vmStatesGaugeVec:          vmStatesGaugeVec,
+		permanentErrorsCounterVec: permanentErrorsCounterVec,
+	}
+	a.settings.Store(&settings{
+		config:            config,
+		maintenanceWindow: maintenanceWindow,
+	})
+	return a
+}
+
+// SetConfig validates the given configuration and, if valid, replaces the current configuration of the actuator with it.
+// Reconciliations that start after this call use the new configuration. Changing the dry-run mode is not supported,
+// since it also determines the Azure utilities and metrics used by the actuator.
+func (a *actuator) SetConfig(config config.AzureFailedVMRemedyConfiguration) error {
+	if config.DryRun != a.config().DryRun {
+		return errors.New("dry-run mode of the failed VM remedy cannot be changed at runtime")
+	}
+	maintenanceWindow, err := utils.NewMaintenanceWindow(config.MaintenanceWindow)
+	if err != nil {
+		return errors.Wrap(err, "could not create maintenance window")
 	}
+
+	a.logger.Info("Updating actuator configuration", "config", config)
+	a.settings.Store(&settings{
+		config:            config,
+		maintenanceWindow: maintenanceWindow,
+	})
+	return nil
+}
+
+// config returns the current configuration of the actuator.
+func (a *actuator) config() config.AzureFailedVMRemedyConfiguration {
+	return a.settings.Load().config
+}
+
+// maintenanceWindow returns the current maintenance window of the actuator.
+func (a *actuator) maintenanceWindow() *utils.MaintenanceWindow {
+	return a.settings.Load().maintenanceWindow
 }
 
 // CreateOrUpdate reconciles object creation or update.
@@ -88,67 +162,102 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 	// Determine VM name
 	vmName := getVirtualMachineName(vm)
 
-	// Initialize failed operations from VirtualMachine status
+	// Initialize failed operations and conditions from VirtualMachine status
 	failedOperations := getFailedOperations(vm)
+	conditions := getConditions(vm)
 
 	// Get the Azure virtual machine
 	azureVM, err := a.getAzureVirtualMachine(ctx, vmName)
 	if err != nil {
-		// Add or update the failed operation
-		failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-			azurev1alpha1.OperationTypeGetVirtualMachine, err.Error(), a.timestamper.Now())
-		a.logger.Error(err, "Getting Azure virtual machine failed", "attempts", failedOperation.Attempts)
+		return a.handleFailedOperation(ctx, vm, azureVM, &failedOperations, &conditions,
+			azurev1alpha1.OperationTypeGetVirtualMachine, err, "Getting Azure virtual machine failed")
+	}
+	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetVirtualMachine)
 
-		// Update resource status
-		if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations); err != nil {
-			return 0, err
-		}
+	// Set the Remedied condition depending on the Azure virtual machine state
+	a.setRemediedCondition(&conditions, vm, azureVM, failedOperations, azurev1alpha1.ConditionReasonNotNeeded, "Azure virtual machine is not in a Failed state")
 
-		// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-		if failedOperation.Attempts < a.config.MaxGetAttempts {
-			return 0, &controllererror.RequeueAfterError{
-				Cause:        err,
-				RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
-			}
-		}
-		return a.config.SyncPeriod.Duration, nil
+	// Reset the PendingApproval condition if the Azure virtual machine is no longer in a Failed state
+	if (azureVM == nil || getProvisioningState(azureVM) != provisioningStateFailed) && meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypePendingApproval) != nil {
+		a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonNotNeeded, "Azure virtual machine is not in a Failed state")
 	}
-	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetVirtualMachine)
 
 	// Update resource status
-	if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations); err != nil {
+	if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations, &conditions); err != nil {
 		return 0, err
 	}
 
 	// Reapply the Azure virtual machine if it's in a Failed state
-	if azureVM != nil && getProvisioningState(azureVM) == compute.ProvisioningStateFailed {
+	if azureVM != nil && getProvisioningState(azureVM) == provisioningStateFailed {
 		// Set VM states gauge to "failed will reapply"
 		a.vmStatesGaugeVec.WithLabelValues(vmName).Set(VMStateFailedWillReapply)
 
-		// Reapply the Azure virtual machine
-		reappliedAzureVM, err := a.reapplyAzureVirtualMachine(ctx, vmName)
-		if err != nil {
-			// Add or update the failed operation
-			failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-				azurev1alpha1.OperationTypeReapplyVirtualMachine, err.Error(), a.timestamper.Now())
-			a.logger.Error(err, "Reapplying Azure virtual machine failed", "attempts", failedOperation.Attempts)
+		// Wait for approval before reapplying the Azure virtual machine, if required
+		if a.requiresApproval() {
+			if !isApproved(vm) {
+				if cond := meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypePendingApproval); cond == nil || cond.Status != metav1.ConditionTrue {
+					a.recordEvent(vm, corev1.EventTypeNormal, controllerazure.EventReasonApprovalRequired,
+						"Reapplying failed Azure virtual machine %s requires approval via the %s=true annotation", vmName, controllerazure.ApprovedAnnotation)
+				}
+				a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionTrue,
+					azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for the "+controllerazure.ApprovedAnnotation+"=true annotation")
+				a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+					azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for approval to reapply the Azure virtual machine")
+
+				// Update resource status
+				if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations, &conditions); err != nil {
+					return 0, err
+				}
+				return a.config().RequeueInterval.Duration, nil
+			}
+			a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonApproved, "Reapplying has been approved")
+		}
+
+		// Defer reapplying the Azure virtual machine until the next maintenance window, if outside of it
+		if until := a.maintenanceWindow().Until(a.timestamper.Now().Time); until > 0 {
+			if cond := meta.FindStatusCondition(conditions, azurev1alpha1.ConditionTypeRemedied); cond == nil || cond.Reason != azurev1alpha1.ConditionReasonOutsideMaintenanceWindow {
+				a.recordEvent(vm, corev1.EventTypeNormal, controllerazure.EventReasonOutsideMaintenanceWindow,
+					"Reapplying failed Azure virtual machine %s deferred by %s until the next maintenance window", vmName, until.Round(time.Second))
+			}
+			a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonOutsideMaintenanceWindow, "Waiting for the next maintenance window to reapply the Azure virtual machine")
 
 			// Update resource status
-			if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations); err != nil {
+			if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations, &conditions); err != nil {
 				return 0, err
 			}
+			return until, nil
+		}
 
-			// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-			if failedOperation.Attempts < a.config.MaxReapplyAttempts {
-				return 0, &controllererror.RequeueAfterError{
-					Cause:        err,
-					RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
-				}
+		// Defer reapplying the Azure virtual machine if the destructive action budget is exhausted
+		if ok, retryAfter := a.acquireBudget(azurev1alpha1.RemedyActionTypeReapplyVirtualMachine); !ok {
+			a.recordEvent(vm, corev1.EventTypeWarning, controllerazure.EventReasonBudgetExhausted,
+				"Reapplying failed Azure virtual machine %s deferred by %s, destructive action budget exhausted", vmName, retryAfter.Round(time.Second))
+			a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonBudgetExhausted, "Destructive action budget is exhausted, reapplying deferred")
+
+			// Update resource status
+			if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations, &conditions); err != nil {
+				return 0, err
 			}
+			return retryAfter, nil
+		}
 
-			// If the configured max attempts has been reached, set VM states gauge to "failed" and return success
-			a.vmStatesGaugeVec.WithLabelValues(vmName).Set(VMStateFailed)
-			return a.config.SyncPeriod.Duration, nil
+		// Reapply the Azure virtual machine
+		reappliedAzureVM, err := a.reapplyAzureVirtualMachine(ctx, vm, azureVM, vmName)
+		if err != nil {
+			a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonReapplyFailed, err.Error())
+			requeueAfter, err = a.handleFailedOperation(ctx, vm, azureVM, &failedOperations, &conditions,
+				azurev1alpha1.OperationTypeReapplyVirtualMachine, err, "Reapplying Azure virtual machine failed")
+
+			// If the configured max attempts has been reached, set VM states gauge to "failed"
+			if err == nil {
+				a.vmStatesGaugeVec.WithLabelValues(vmName).Set(VMStateFailed)
+			}
+			return requeueAfter, err
 		}
 		azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeReapplyVirtualMachine)
 
@@ -158,11 +267,33 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 		// Set VM states gauge to "failed" or "ok" depending on the new Azure virtual machine state
 		a.setVMStatesGauge(reappliedAzureVM, vmName)
 
+		// Set the Remedied condition depending on the new Azure virtual machine state
+		// In dry-run mode, the Azure virtual machine has not actually been reapplied, so record what would have been done
+		if a.config().DryRun {
+			a.recordEvent(vm, corev1.EventTypeNormal, controllerazure.EventReasonVirtualMachineReapplied,
+				"Would have reapplied failed Azure virtual machine %s (dry run)", vmName)
+			a.setCondition(&conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonDryRun, "Azure virtual machine would have been reapplied (dry run)")
+		} else {
+			a.recordEvent(vm, corev1.EventTypeNormal, controllerazure.EventReasonVirtualMachineReapplied,
+				"Reapplied failed Azure virtual machine %s", vmName)
+			a.notify(ctx, vm, azurev1alpha1.OperationTypeReapplyVirtualMachine, controller.NotificationOutcomeSucceeded,
+				"Reapplied failed Azure virtual machine %s", vmName)
+			a.setRemediedCondition(&conditions, vm, reappliedAzureVM, failedOperations, azurev1alpha1.ConditionReasonReapplied, "Azure virtual machine has been reapplied")
+		}
+
 		// Update resource status
-		if err := a.updateVirtualMachineStatus(ctx, vm, reappliedAzureVM, failedOperations); err != nil {
+		if err := a.updateVirtualMachineStatus(ctx, vm, reappliedAzureVM, failedOperations, &conditions); err != nil {
 			return 0, err
 		}
-	} else if azureVM != nil && getProvisioningState(azureVM) != compute.ProvisioningStateFailed {
+
+		// Consume the approval, so that reapplying the Azure virtual machine again requires a new approval
+		if a.requiresApproval() {
+			if err := a.removeApprovedAnnotation(ctx, vm); err != nil {
+				return 0, err
+			}
+		}
+	} else if azureVM != nil && getProvisioningState(azureVM) != provisioningStateFailed {
 		// Set VM states gauge to "ok"
 		a.vmStatesGaugeVec.WithLabelValues(vmName).Set(VMStateOK)
 	} else if azureVM == nil {
@@ -171,9 +302,9 @@ func (a *actuator) CreateOrUpdate(ctx context.Context, obj client.Object) (reque
 	}
 
 	// Requeue if the Azure virtual machine doesn't exist or is in a transient state
-	requeueAfter = a.config.SyncPeriod.Duration
-	if azureVM == nil || (getProvisioningState(azureVM) != compute.ProvisioningStateSucceeded && getProvisioningState(azureVM) != compute.ProvisioningStateFailed) {
-		requeueAfter = a.config.RequeueInterval.Duration
+	requeueAfter = a.config().SyncPeriod.Duration
+	if azureVM == nil || (getProvisioningState(azureVM) != provisioningStateSucceeded && getProvisioningState(azureVM) != provisioningStateFailed) {
+		requeueAfter = a.config().RequeueInterval.Duration
 	}
 
 	return requeueAfter, nil
@@ -191,38 +322,26 @@ func (a *actuator) Delete(ctx context.Context, obj client.Object) (requeueAfter
 	// Determine VM name
 	vmName := getVirtualMachineName(vm)
 
-	// Initialize failed operations from VirtualMachine status
+	// Initialize failed operations and conditions from VirtualMachine status
 	failedOperations := getFailedOperations(vm)
+	conditions := getConditions(vm)
 
 	// Get the Azure virtual machine
 	azureVM, err := a.getAzureVirtualMachine(ctx, vmName)
 	if err != nil {
-		// Add or update the failed operation
-		failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(&failedOperations,
-			azurev1alpha1.OperationTypeGetVirtualMachine, err.Error(), a.timestamper.Now())
-		a.logger.Error(err, "Getting Azure virtual machine failed", "attempts", failedOperation.Attempts)
-
-		// Update resource status
-		if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations); err != nil {
-			return 0, err
-		}
-
-		// If the failed operation has been attempted less than the configured max attempts, requeue with exponential backoff
-		if failedOperation.Attempts < a.config.MaxGetAttempts {
-			return 0, &controllererror.RequeueAfterError{
-				Cause:        err,
-				RequeueAfter: a.config.RequeueInterval.Duration * (1 << (failedOperation.Attempts - 1)),
-			}
-		}
-		return a.config.SyncPeriod.Duration, nil
+		return a.handleFailedOperation(ctx, vm, azureVM, &failedOperations, &conditions,
+			azurev1alpha1.OperationTypeGetVirtualMachine, err, "Getting Azure virtual machine failed")
 	}
 	azurev1alpha1.DeleteFailedOperation(&failedOperations, azurev1alpha1.OperationTypeGetVirtualMachine)
 
+	// Set the Remedied condition depending on the Azure virtual machine state
+	a.setRemediedCondition(&conditions, vm, azureVM, failedOperations, azurev1alpha1.ConditionReasonNotNeeded, "Azure virtual machine is not in a Failed state")
+
 	// Set VM states gauge to "failed" or "ok" depending on the Azure virtual machine state
 	a.setVMStatesGauge(azureVM, vmName)
 
 	// Update resource status
-	return 0, a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations)
+	return 0, a.updateVirtualMachineStatus(ctx, vm, azureVM, failedOperations, &conditions)
 }
 
 // ShouldFinalize returns true if the object should be finalized.
@@ -230,14 +349,21 @@ func (a *actuator) ShouldFinalize(_ context.Context, _ client.Object) (bool, err
 	return true, nil
 }
 
-func (a *actuator) getAzureVirtualMachine(ctx context.Context, name string) (*compute.VirtualMachine, error) {
+func (a *actuator) getAzureVirtualMachine(ctx context.Context, name string) (*armcompute.VirtualMachine, error) {
 	azureVM, err := a.vmUtils.Get(ctx, name)
 	return azureVM, errors.Wrap(err, "could not get Azure virtual machine")
 }
 
-func (a *actuator) reapplyAzureVirtualMachine(ctx context.Context, name string) (*compute.VirtualMachine, error) {
+func (a *actuator) reapplyAzureVirtualMachine(
+	ctx context.Context,
+	vm *azurev1alpha1.VirtualMachine,
+	azureVM *armcompute.VirtualMachine,
+	name string,
+) (*armcompute.VirtualMachine, error) {
 	a.logger.Info("Reapplying Azure virtual machine", "name", name)
-	if err := a.vmUtils.Reapply(ctx, name); err != nil {
+	if err := a.recordRemedyAction(ctx, vm, azureVM, azurev1alpha1.RemedyActionTypeReapplyVirtualMachine, func() error {
+		return a.vmUtils.Reapply(ctx, name)
+	}); err != nil {
 		return nil, errors.Wrap(err, "could not reapply Azure virtual machine")
 	}
 	azureVM, err := a.vmUtils.Get(ctx, name)
@@ -247,11 +373,120 @@ func (a *actuator) reapplyAzureVirtualMachine(ctx context.Context, name string)
 	return azureVM, nil
 }
 
+// recordRemedyAction performs the given action on the Azure virtual machine, recording it as a RemedyAction.
+// In dry-run mode, nothing is actually changed in Azure, so the action is not recorded.
+func (a *actuator) recordRemedyAction(
+	ctx context.Context,
+	vm *azurev1alpha1.VirtualMachine,
+	azureVM *armcompute.VirtualMachine,
+	actionType azurev1alpha1.RemedyActionType,
+	action func() error,
+) error {
+	if a.config().DryRun {
+		return action()
+	}
+	return a.remedyActionRecorder.Record(ctx, vm, actionType, *azureVM.ID, action)
+}
+
+// requiresApproval returns true if reapplying Azure virtual machines requires approval.
+// In dry-run mode, nothing is actually changed in Azure, so no approval is required.
+func (a *actuator) requiresApproval() bool {
+	return a.config().RequireApproval && !a.config().DryRun
+}
+
+// removeApprovedAnnotation removes the approved annotation from the given VirtualMachine.
+func (a *actuator) removeApprovedAnnotation(ctx context.Context, vm *azurev1alpha1.VirtualMachine) error {
+	a.logger.Info("Removing approved annotation from virtualmachine", "name", vm.Name, "namespace", vm.Namespace)
+	patch := client.MergeFrom(vm.DeepCopy())
+	delete(vm.Annotations, controllerazure.ApprovedAnnotation)
+	if err := a.client.Patch(ctx, vm, patch); client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "could not remove approved annotation from virtualmachine")
+	}
+	return nil
+}
+
+// acquireBudget acquires a unit of the destructive action budget for the given action type.
+// In dry-run mode, nothing is actually changed in Azure, so no budget is consumed.
+func (a *actuator) acquireBudget(actionType azurev1alpha1.RemedyActionType) (bool, time.Duration) {
+	if a.config().DryRun {
+		return true, 0
+	}
+	return a.budget.Acquire(actionType)
+}
+
+// handleFailedOperation adds or updates the failed operation of the given type and updates the VirtualMachine status.
+// If the request has been throttled by Azure, it doesn't count the failed attempt and returns a RequeueAfterError
+// with the delay requested by Azure instead.
+// If the operation failed with a permanent error, it gives up until the next sync.
+// If the operation should be retried according to its retry policy, it returns a RequeueAfterError with the appropriate delay.
+// Otherwise, it gives up until the next sync.
+func (a *actuator) handleFailedOperation(
+	ctx context.Context,
+	vm *azurev1alpha1.VirtualMachine,
+	azureVM *armcompute.VirtualMachine,
+	failedOperations *[]azurev1alpha1.FailedOperation,
+	conditions *[]metav1.Condition,
+	opType azurev1alpha1.OperationType,
+	err error,
+	msg string,
+) (time.Duration, error) {
+	// Throttled requests are not counted as failed attempts, instead requeue after the delay requested by Azure
+	if throttledErr, ok := azure.AsThrottledError(err); ok {
+		a.logger.Info(msg+", request throttled by Azure", "retryAfter", throttledErr.RetryAfter, "error", err.Error())
+		return 0, &controllererror.RequeueAfterError{
+			Cause:        err,
+			RequeueAfter: throttledErr.RetryAfter,
+		}
+	}
+
+	retryPolicy := a.getRetryPolicy(opType)
+
+	// Add or update the failed operation, resetting its attempts if the cool-down has elapsed since its last failure
+	now := a.timestamper.Now()
+	if op := azurev1alpha1.GetFailedOperation(*failedOperations, opType); op != nil && retryPolicy.CoolDownElapsed(op.Timestamp.Time, now.Time) {
+		azurev1alpha1.DeleteFailedOperation(failedOperations, opType)
+	}
+	classification := azure.ClassifyError(err)
+	reason := azurev1alpha1.FailureReasonTransient
+	if classification.Permanent {
+		reason = azurev1alpha1.FailureReasonPermanent
+	}
+	failedOperation := azurev1alpha1.AddOrUpdateFailedOperation(failedOperations, azurev1alpha1.FailedOperation{
+		Type:          opType,
+		ErrorMessage:  err.Error(),
+		Reason:        reason,
+		Code:          classification.Code,
+		CorrelationID: classification.CorrelationID,
+		Timestamp:     now,
+	})
+	a.logger.Error(err, msg, "attempts", failedOperation.Attempts, "reason", reason, "code", classification.Code, "correlationID", classification.CorrelationID)
+
+	// Update resource status
+	if err := a.updateVirtualMachineStatus(ctx, vm, azureVM, *failedOperations, conditions); err != nil {
+		return 0, err
+	}
+
+	// If the failed operation failed with a permanent error, don't retry it until the next sync
+	if classification.Permanent {
+		a.permanentErrorsCounterVec.WithLabelValues(string(opType), classification.Code).Inc()
+		a.reportPermanentFailure(ctx, vm, failedOperation)
+		return a.config().SyncPeriod.Duration, nil
+	}
+
+	// If the failed operation should be retried, requeue with exponential backoff
+	if requeueAfterErr := retryPolicy.RequeueAfterError(err, failedOperation.Attempts); requeueAfterErr != nil {
+		return 0, requeueAfterErr
+	}
+	a.reportMaxAttemptsReached(ctx, vm, failedOperation)
+	return a.config().SyncPeriod.Duration, nil
+}
+
 func (a *actuator) updateVirtualMachineStatus(
 	ctx context.Context,
 	vm *azurev1alpha1.VirtualMachine,
-	azureVM *compute.VirtualMachine,
+	azureVM *armcompute.VirtualMachine,
 	failedOperations []azurev1alpha1.FailedOperation,
+	conditions *[]metav1.Condition,
 ) error {
 	// Build status
 	status := azurev1alpha1.VirtualMachineStatus{}
@@ -260,13 +495,18 @@ func (a *actuator) updateVirtualMachineStatus(
 			Exists:            true,
 			ID:                azureVM.ID,
 			Name:              azureVM.Name,
-			ProvisioningState: azureVM.ProvisioningState,
+			ProvisioningState: getProvisioningStatePtr(azureVM),
 		}
 	}
 	if len(failedOperations) > 0 {
 		status.FailedOperations = make([]azurev1alpha1.FailedOperation, len(failedOperations))
 		copy(status.FailedOperations, failedOperations)
 	}
+	a.setConditions(conditions, vm, azureVM != nil, failedOperations)
+	if len(*conditions) > 0 {
+		status.Conditions = make([]metav1.Condition, len(*conditions))
+		copy(status.Conditions, *conditions)
+	}
 
 	// Update resource status
 	a.logger.Info("Updating virtualmachine status", "name", vm.Name, "namespace", vm.Namespace, "status", status)
@@ -280,11 +520,150 @@ func (a *actuator) updateVirtualMachineStatus(
 	return nil
 }
 
-func (a *actuator) setVMStatesGauge(azureVM *compute.VirtualMachine, name string) {
+func (a *actuator) setConditions(
+	conditions *[]metav1.Condition,
+	vm *azurev1alpha1.VirtualMachine,
+	exists bool,
+	failedOperations []azurev1alpha1.FailedOperation,
+) {
+	// Set Tracked and AzureReachable conditions depending on the outcome of getting the Azure virtual machine
+	if getOp := azurev1alpha1.GetFailedOperation(failedOperations, azurev1alpha1.OperationTypeGetVirtualMachine); getOp != nil {
+		a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeTracked, metav1.ConditionUnknown,
+			azurev1alpha1.ConditionReasonRequestFailed, "Could not determine if Azure virtual machine exists")
+		a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonRequestFailed, getOp.ErrorMessage)
+	} else {
+		if exists {
+			a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeTracked, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonFound, "Azure virtual machine exists")
+		} else {
+			a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeTracked, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonNotFound, "Azure virtual machine does not exist")
+		}
+		a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionTrue,
+			azurev1alpha1.ConditionReasonRequestSucceeded, "Azure virtual machine retrieved successfully")
+	}
+
+	// Set RemedyExhausted condition depending on whether any failed operation has reached its max attempts
+	for _, op := range failedOperations {
+		if op.Reason == azurev1alpha1.FailureReasonPermanent {
+			a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonPermanentFailure, fmt.Sprintf("Operation %s failed with a permanent error: %s", op.Type, op.ErrorMessage))
+			return
+		}
+		if a.getRetryPolicy(op.Type).IsExhausted(op.Attempts) {
+			a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue,
+				azurev1alpha1.ConditionReasonMaxAttemptsReached, fmt.Sprintf("Operation %s failed %d times: %s", op.Type, op.Attempts, op.ErrorMessage))
+			return
+		}
+	}
+	a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionFalse,
+		azurev1alpha1.ConditionReasonAttemptsRemaining, "No operation has reached its max attempts")
+}
+
+func (a *actuator) setRemediedCondition(
+	conditions *[]metav1.Condition,
+	vm *azurev1alpha1.VirtualMachine,
+	azureVM *armcompute.VirtualMachine,
+	failedOperations []azurev1alpha1.FailedOperation,
+	okReason, okMessage string,
+) {
 	switch {
-	case azureVM != nil && getProvisioningState(azureVM) == compute.ProvisioningStateFailed:
+	case azureVM != nil && getProvisioningState(azureVM) == provisioningStateFailed:
+		if reapplyOp := azurev1alpha1.GetFailedOperation(failedOperations, azurev1alpha1.OperationTypeReapplyVirtualMachine); reapplyOp != nil {
+			a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+				azurev1alpha1.ConditionReasonReapplyFailed, reapplyOp.ErrorMessage)
+			return
+		}
+		a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse,
+			azurev1alpha1.ConditionReasonProvisioningFailed, "Azure virtual machine is in a Failed state")
+	case azureVM != nil:
+		a.setCondition(conditions, vm, azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, okReason, okMessage)
+	}
+}
+
+func (a *actuator) setCondition(
+	conditions *[]metav1.Condition,
+	vm *azurev1alpha1.VirtualMachine,
+	condType string,
+	status metav1.ConditionStatus,
+	reason, message string,
+) {
+	azurev1alpha1.SetCondition(conditions, condType, status, reason, message, vm.Generation, a.timestamper.Now())
+}
+
+func (a *actuator) reportMaxAttemptsReached(ctx context.Context, vm *azurev1alpha1.VirtualMachine, failedOperation *azurev1alpha1.FailedOperation) {
+	a.recordEvent(vm, corev1.EventTypeWarning, controllerazure.EventReasonMaxAttemptsReached,
+		"Operation %s failed %d times, giving up until next sync: %s", failedOperation.Type, failedOperation.Attempts, failedOperation.ErrorMessage)
+	a.notify(ctx, vm, failedOperation.Type, controller.NotificationOutcomeGaveUp,
+		"Operation %s failed %d times, giving up until next sync: %s", failedOperation.Type, failedOperation.Attempts, failedOperation.ErrorMessage)
+}
+
+func (a *actuator) reportPermanentFailure(ctx context.Context, vm *azurev1alpha1.VirtualMachine, failedOperation *azurev1alpha1.FailedOperation) {
+	a.recordEvent(vm, corev1.EventTypeWarning, controllerazure.EventReasonPermanentFailure,
+		"Operation %s failed with a permanent error, giving up until next sync: %s", failedOperation.Type, failedOperation.ErrorMessage)
+	a.notify(ctx, vm, failedOperation.Type, controller.NotificationOutcomeGaveUp,
+		"Operation %s failed with a permanent error, giving up until next sync: %s", failedOperation.Type, failedOperation.ErrorMessage)
+}
+
+// notify sends a notification about the outcome of the given operation on the given VirtualMachine.
+// Sending the notification may fail without affecting the remedy, so errors are only logged.
+func (a *actuator) notify(
+	ctx context.Context,
+	vm *azurev1alpha1.VirtualMachine,
+	opType azurev1alpha1.OperationType,
+	outcome controller.NotificationOutcome,
+	messageFmt string,
+	args ...interface{},
+) {
+	notification := controller.Notification{
+		Resource: controller.NotificationResource{
+			Kind:      "VirtualMachine",
+			Namespace: vm.Namespace,
+			Name:      vm.Name,
+		},
+		Action:    string(opType),
+		Outcome:   outcome,
+		Message:   fmt.Sprintf(messageFmt, args...),
+		Timestamp: a.timestamper.Now(),
+	}
+	if vm.Status.ID != nil {
+		notification.Resource.ProviderID = *vm.Status.ID
+	}
+	if err := a.notifier.Notify(ctx, notification); err != nil {
+		a.logger.Error(err, "Could not send notification", "name", vm.Name, "namespace", vm.Namespace)
+	}
+}
+
+// recordEvent records an event on the given VirtualMachine and on the node it belongs to, if known.
+func (a *actuator) recordEvent(vm *azurev1alpha1.VirtualMachine, eventType, reason, messageFmt string, args ...interface{}) {
+	a.recorder.Eventf(vm, eventType, reason, messageFmt, args...)
+	if a.targetRecorder == nil {
+		return
+	}
+	if nodeName := node.ObjectLabeler.GetNamespacedName(controller.GetIdentity(vm, controllerazure.NodeLabel)); nodeName.Name != "" {
+		a.targetRecorder.Eventf(&corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       nodeName.Name,
+			// Use the node name as UID, the same way the kubelet does, so that events are shown for the node
+			UID: types.UID(nodeName.Name),
+		}, eventType, reason, messageFmt, args...)
+	}
+}
+
+func (a *actuator) getRetryPolicy(opType azurev1alpha1.OperationType) controller.RetryPolicy {
+	if opType == azurev1alpha1.OperationTypeReapplyVirtualMachine {
+		return controller.NewRetryPolicy(a.config().ReapplyRetryPolicy, a.config().RequeueInterval.Duration, a.config().MaxReapplyAttempts)
+	}
+	return controller.NewRetryPolicy(a.config().GetRetryPolicy, a.config().RequeueInterval.Duration, a.config().MaxGetAttempts)
+}
+
+func (a *actuator) setVMStatesGauge(azureVM *armcompute.VirtualMachine, name string) {
+	switch {
+	case azureVM != nil && getProvisioningState(azureVM) == provisioningStateFailed:
 		a.vmStatesGaugeVec.WithLabelValues(name).Set(VMStateFailed)
-	case azureVM != nil && getProvisioningState(azureVM) != compute.ProvisioningStateFailed:
+	case azureVM != nil && getProvisioningState(azureVM) != provisioningStateFailed:
 		a.vmStatesGaugeVec.WithLabelValues(name).Set(VMStateOK)
 	case azureVM == nil:
 		a.vmStatesGaugeVec.DeleteLabelValues(name)
@@ -311,9 +690,29 @@ func getFailedOperations(vm *azurev1alpha1.VirtualMachine) []azurev1alpha1.Faile
 	return failedOperations
 }
 
-func getProvisioningState(azureVM *compute.VirtualMachine) compute.ProvisioningState {
-	if azureVM.ProvisioningState == nil {
-		return ""
+func getConditions(vm *azurev1alpha1.VirtualMachine) []metav1.Condition {
+	var conditions []metav1.Condition
+	if len(vm.Status.Conditions) > 0 {
+		conditions = make([]metav1.Condition, len(vm.Status.Conditions))
+		copy(conditions, vm.Status.Conditions)
+	}
+	return conditions
+}
+
+func isApproved(vm *azurev1alpha1.VirtualMachine) bool {
+	return vm.Annotations[controllerazure.ApprovedAnnotation] == strconv.FormatBool(true)
+}
+
+func getProvisioningState(azureVM *armcompute.VirtualMachine) string {
+	if provisioningState := getProvisioningStatePtr(azureVM); provisioningState != nil {
+		return *provisioningState
+	}
+	return ""
+}
+
+func getProvisioningStatePtr(azureVM *armcompute.VirtualMachine) *string {
+	if azureVM.Properties == nil {
+		return nil
 	}
-	return compute.ProvisioningState(*azureVM.ProvisioningState)
+	return azureVM.Properties.ProvisioningState
 }
@@ -16,9 +16,11 @@ package virtualmachine_test
 
 import (
 	"context"
+	"net/http"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -33,9 +36,12 @@ import (
 	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/remedy-controller/pkg/apis/config"
 	"github.com/gardener/remedy-controller/pkg/controller"
+	controllerazure "github.com/gardener/remedy-controller/pkg/controller/azure"
 	"github.com/gardener/remedy-controller/pkg/controller/azure/virtualmachine"
 	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
 	mockprometheus "github.com/gardener/remedy-controller/pkg/mock/prometheus"
+	mockcontroller "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller"
+	mockcontrollerazure "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller/azure"
 	mockutilsazure "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/utils/azure"
 	mockutilsprometheus "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/utils/prometheus"
 	"github.com/gardener/remedy-controller/pkg/utils"
@@ -50,6 +56,9 @@ var _ = Describe("Actuator", func() {
 		azureVirtualMachineID   = "/subscriptions/xxx/resourceGroups/shoot--dev--test/providers/Microsoft.Compute/virtualMachines/shoot--dev--test-vm1"
 		azureVirtualMachineName = "shoot--dev--test-vm1"
 
+		provisioningStateSucceeded = "Succeeded"
+		provisioningStateFailed    = "Failed"
+
 		requeueInterval = 1 * time.Second
 		syncPeriod      = 1 * time.Minute
 	)
@@ -58,22 +67,35 @@ var _ = Describe("Actuator", func() {
 		ctrl *gomock.Controller
 		ctx  context.Context
 
-		c                   *mockclient.MockClient
-		sw                  *mockclient.MockStatusWriter
-		vmUtils             *mockutilsazure.MockVirtualMachineUtils
-		reappliedVMsCounter *mockprometheus.MockCounter
-		vmStatesGaugeVec    *mockutilsprometheus.MockGaugeVec
-		vmStatesGauge       *mockprometheus.MockGauge
-
-		cfg         config.AzureFailedVMRemedyConfiguration
-		now         metav1.Time
-		timestamper utils.Timestamper
-		logger      logr.Logger
-		actuator    controller.Actuator
-
-		newVM                  func(bool, bool, compute.ProvisioningState, []azurev1alpha1.FailedOperation) *azurev1alpha1.VirtualMachine
-		newAzureVirtualMachine func(compute.ProvisioningState) *compute.VirtualMachine
+		c                         *mockclient.MockClient
+		sw                        *mockclient.MockStatusWriter
+		vmUtils                   *mockutilsazure.MockVirtualMachineUtils
+		remedyActionRecorder      *mockcontrollerazure.MockRemedyActionRecorder
+		budget                    controllerazure.DestructiveActionBudget
+		notifier                  controller.Notifier
+		reappliedVMsCounter       *mockprometheus.MockCounter
+		vmStatesGaugeVec          *mockutilsprometheus.MockGaugeVec
+		permanentErrorsCounterVec *mockutilsprometheus.MockCounterVec
+		permanentErrorsCounter    *mockprometheus.MockCounter
+		vmStatesGauge             *mockprometheus.MockGauge
+
+		cfg            config.AzureFailedVMRemedyConfiguration
+		now            metav1.Time
+		timestamper    utils.Timestamper
+		recorder       *record.FakeRecorder
+		targetRecorder *record.FakeRecorder
+		logger         logr.Logger
+		actuator       controller.ConfigurableActuator[config.AzureFailedVMRemedyConfiguration]
+
+		newVM                  func(bool, bool, string, []azurev1alpha1.FailedOperation) *azurev1alpha1.VirtualMachine
+		newAzureVirtualMachine func(string) *armcompute.VirtualMachine
 		expectPatchStatus      func(vm, vmUpdated *azurev1alpha1.VirtualMachine) *gomock.Call
+		expectRecordReapply    func()
+		withConditions         func(vm *azurev1alpha1.VirtualMachine, conditions ...metav1.Condition) *azurev1alpha1.VirtualMachine
+		newCondition           func(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition
+
+		trackedFound, trackedNotFound, trackedUnknown, reachable, notExhausted metav1.Condition
+		remediedNotNeeded, remediedReapplied, remediedProvisioningFailed       metav1.Condition
 	)
 
 	BeforeEach(func() {
@@ -84,8 +106,13 @@ var _ = Describe("Actuator", func() {
 		sw = mockclient.NewMockStatusWriter(ctrl)
 		c.EXPECT().Status().Return(sw).AnyTimes()
 		vmUtils = mockutilsazure.NewMockVirtualMachineUtils(ctrl)
+		remedyActionRecorder = mockcontrollerazure.NewMockRemedyActionRecorder(ctrl)
+		budget = controllerazure.NewDestructiveActionBudget(nil, nil, nil)
+		notifier = controller.NewNotifier(nil, log.Log)
 		reappliedVMsCounter = mockprometheus.NewMockCounter(ctrl)
 		vmStatesGaugeVec = mockutilsprometheus.NewMockGaugeVec(ctrl)
+		permanentErrorsCounterVec = mockutilsprometheus.NewMockCounterVec(ctrl)
+		permanentErrorsCounter = mockprometheus.NewMockCounter(ctrl)
 		vmStatesGauge = mockprometheus.NewMockGauge(ctrl)
 
 		cfg = config.AzureFailedVMRemedyConfiguration{
@@ -96,17 +123,19 @@ var _ = Describe("Actuator", func() {
 		}
 		now = metav1.Now()
 		timestamper = utils.TimestamperFunc(func() metav1.Time { return now })
+		recorder = record.NewFakeRecorder(10)
+		targetRecorder = record.NewFakeRecorder(10)
 		logger = log.Log.WithName("test")
-		actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, logger, reappliedVMsCounter, vmStatesGaugeVec)
+		actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
 
-		newVM = func(notReadyOrUnreachable, withStatus bool, provisioningState compute.ProvisioningState, failedOperations []azurev1alpha1.FailedOperation) *azurev1alpha1.VirtualMachine {
+		newVM = func(notReadyOrUnreachable, withStatus bool, provisioningState string, failedOperations []azurev1alpha1.FailedOperation) *azurev1alpha1.VirtualMachine {
 			var status azurev1alpha1.VirtualMachineStatus
 			if withStatus {
 				status = azurev1alpha1.VirtualMachineStatus{
 					Exists:            true,
 					ID:                ptr.To(azureVirtualMachineID),
 					Name:              ptr.To(azureVirtualMachineName),
-					ProvisioningState: ptr.To(string(provisioningState)),
+					ProvisioningState: ptr.To(provisioningState),
 				}
 			}
 			status.FailedOperations = failedOperations
@@ -114,6 +143,7 @@ var _ = Describe("Actuator", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      nodeName,
 					Namespace: namespace,
+					Labels:    map[string]string{controllerazure.NodeLabel: nodeName},
 				},
 				Spec: azurev1alpha1.VirtualMachineSpec{
 					Hostname:              hostname,
@@ -123,19 +153,46 @@ var _ = Describe("Actuator", func() {
 				Status: status,
 			}
 		}
-		newAzureVirtualMachine = func(provisioningState compute.ProvisioningState) *compute.VirtualMachine {
-			return &compute.VirtualMachine{
+		newAzureVirtualMachine = func(provisioningState string) *armcompute.VirtualMachine {
+			return &armcompute.VirtualMachine{
 				ID:   ptr.To(azureVirtualMachineID),
 				Name: ptr.To(azureVirtualMachineName),
-				VirtualMachineProperties: &compute.VirtualMachineProperties{
-					ProvisioningState: ptr.To(string(provisioningState)),
+				Properties: &armcompute.VirtualMachineProperties{
+					ProvisioningState: ptr.To(provisioningState),
 				},
 			}
 		}
+		withConditions = func(vm *azurev1alpha1.VirtualMachine, conditions ...metav1.Condition) *azurev1alpha1.VirtualMachine {
+			vm.Status.Conditions = conditions
+			return vm
+		}
+		newCondition = func(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+			return metav1.Condition{
+				Type:               condType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+			}
+		}
+		trackedFound = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionTrue, azurev1alpha1.ConditionReasonFound, "Azure virtual machine exists")
+		trackedNotFound = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionFalse, azurev1alpha1.ConditionReasonNotFound, "Azure virtual machine does not exist")
+		trackedUnknown = newCondition(azurev1alpha1.ConditionTypeTracked, metav1.ConditionUnknown, azurev1alpha1.ConditionReasonRequestFailed, "Could not determine if Azure virtual machine exists")
+		reachable = newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionTrue, azurev1alpha1.ConditionReasonRequestSucceeded, "Azure virtual machine retrieved successfully")
+		notExhausted = newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionFalse, azurev1alpha1.ConditionReasonAttemptsRemaining, "No operation has reached its max attempts")
+		remediedNotNeeded = newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonNotNeeded, "Azure virtual machine is not in a Failed state")
+		remediedReapplied = newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionTrue, azurev1alpha1.ConditionReasonReapplied, "Azure virtual machine has been reapplied")
+		remediedProvisioningFailed = newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonProvisioningFailed, "Azure virtual machine is in a Failed state")
 		expectPatchStatus = func(vm, vmUpdated *azurev1alpha1.VirtualMachine) *gomock.Call {
 			c.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: namespace, Name: azureVirtualMachineName}, vm).Return(nil)
 			return sw.EXPECT().Patch(gomock.Any(), vmUpdated, gomock.Any())
 		}
+		expectRecordReapply = func() {
+			remedyActionRecorder.EXPECT().Record(ctx, gomock.Any(), azurev1alpha1.RemedyActionTypeReapplyVirtualMachine, azureVirtualMachineID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ client.Object, _ azurev1alpha1.RemedyActionType, _ string, action func() error) error {
+					return action()
+				})
+		}
 	})
 
 	AfterEach(func() {
@@ -145,8 +202,8 @@ var _ = Describe("Actuator", func() {
 	Describe("#CreateOrUpdate", func() {
 		It("should update the VirtualMachine object status if the VM is found", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
@@ -158,11 +215,13 @@ var _ = Describe("Actuator", func() {
 			Expect(requeueAfter).To(Equal(syncPeriod))
 		})
 
-		It("should not update the VirtualMachine object status if the VM is not found", func() {
+		It("should only update the VirtualMachine object conditions if the VM is not found", func() {
 			vm := newVM(false, false, "", nil)
+			vmWithConditions := withConditions(newVM(false, false, "", nil), trackedNotFound, reachable, notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, nil)
 			vmStatesGaugeVec.EXPECT().DeleteLabelValues(azureVirtualMachineName)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: nodeName}, vm).Return(nil)
+
+			expectPatchStatus(vm, vmWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
@@ -170,8 +229,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should not update the VirtualMachine object status if the VM is found and the status is already initialized", func() {
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: nodeName}, vmWithStatus).Return(nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
@@ -183,8 +242,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the VirtualMachine object status if the VM is not found and the status is already initialized", func() {
-			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
+			vm := withConditions(newVM(false, false, "", nil), remediedNotNeeded, trackedNotFound, reachable, notExhausted)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, nil)
 			vmStatesGaugeVec.EXPECT().DeleteLabelValues(azureVirtualMachineName)
 
@@ -196,17 +255,153 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should reapply the Azure VM if it's in a failed state", func() {
+			mockNotifier := mockcontroller.NewMockNotifier(ctrl)
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, mockNotifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vm := newVM(true, false, "", nil)
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmWithStatus2 := withConditions(newVM(true, true, provisioningStateSucceeded, nil), remediedReapplied, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			azureVirtualMachine2 := newAzureVirtualMachine(provisioningStateSucceeded)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			expectRecordReapply()
+			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(nil)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine2, nil)
+			reappliedVMsCounter.EXPECT().Inc()
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
+
+			mockNotifier.EXPECT().Notify(ctx, controller.Notification{
+				Resource: controller.NotificationResource{
+					Kind:       "VirtualMachine",
+					Namespace:  namespace,
+					Name:       nodeName,
+					ProviderID: azureVirtualMachineID,
+				},
+				Action:    string(azurev1alpha1.OperationTypeReapplyVirtualMachine),
+				Outcome:   controller.NotificationOutcomeSucceeded,
+				Message:   "Reapplied failed Azure virtual machine " + azureVirtualMachineName,
+				Timestamp: now,
+			}).Return(nil)
+
+			expectPatchStatus(vmWithStatus, vmWithStatus2).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Normal VirtualMachineReapplied Reapplied failed Azure virtual machine " + azureVirtualMachineName)))
+			Expect(targetRecorder.Events).To(Receive(Equal("Normal VirtualMachineReapplied Reapplied failed Azure virtual machine " + azureVirtualMachineName)))
+		})
+
+		It("should defer reapplying the Azure VM until the next maintenance window if outside of it", func() {
+			maintenanceWindow, err := utils.NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{
+					Begin: now.UTC().Add(2 * time.Hour).Format("15:04"),
+					End:   now.UTC().Add(3 * time.Hour).Format("15:04"),
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			until := now.Add(2 * time.Hour).Truncate(time.Minute).Sub(now.Time)
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, maintenanceWindow, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vm := newVM(true, false, "", nil)
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmDeferred := withConditions(newVM(true, true, provisioningStateFailed, nil),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonOutsideMaintenanceWindow, "Waiting for the next maintenance window to reapply the Azure virtual machine"),
+				trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+
+			expectPatchStatus(vmWithStatus, vmDeferred).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(until))
+			Expect(recorder.Events).To(Receive(Equal("Normal OutsideMaintenanceWindow Reapplying failed Azure virtual machine " + azureVirtualMachineName +
+				" deferred by " + until.Round(time.Second).String() + " until the next maintenance window")))
+		})
+
+		It("should defer reapplying the Azure VM if the destructive action budget is exhausted", func() {
+			exhaustedBudget := mockcontrollerazure.NewMockDestructiveActionBudget(ctrl)
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, exhaustedBudget, nil, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vm := newVM(true, false, "", nil)
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmDeferred := withConditions(newVM(true, true, provisioningStateFailed, nil),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonBudgetExhausted, "Destructive action budget is exhausted, reapplying deferred"),
+				trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			exhaustedBudget.EXPECT().Acquire(azurev1alpha1.RemedyActionTypeReapplyVirtualMachine).Return(false, 10*time.Minute)
+
+			expectPatchStatus(vmWithStatus, vmDeferred).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(10 * time.Minute))
+			Expect(recorder.Events).To(Receive(Equal("Warning BudgetExhausted Reapplying failed Azure virtual machine " + azureVirtualMachineName + " deferred by 10m0s, destructive action budget exhausted")))
+		})
+
+		It("should wait for approval before reapplying the Azure VM if approval is required", func() {
+			cfg.RequireApproval = true
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vm := newVM(true, false, "", nil)
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmPendingApproval := withConditions(newVM(true, true, provisioningStateFailed, nil),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for approval to reapply the Azure virtual machine"),
+				trackedFound, reachable, notExhausted,
+				newCondition(azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionTrue, azurev1alpha1.ConditionReasonApprovalRequired, "Waiting for the azure.remedy.gardener.cloud/approved=true annotation"))
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+
+			expectPatchStatus(vmWithStatus, vmPendingApproval).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(requeueInterval))
+			Expect(recorder.Events).To(Receive(Equal("Normal ApprovalRequired Reapplying failed Azure virtual machine " + azureVirtualMachineName +
+				" requires approval via the azure.remedy.gardener.cloud/approved=true annotation")))
+		})
+
+		It("should reapply the Azure VM and remove the approved annotation if approval is required and it has the approved annotation", func() {
+			cfg.RequireApproval = true
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			approved := map[string]string{controllerazure.ApprovedAnnotation: "true"}
 			vm := newVM(true, false, "", nil)
-			vmWithStatus := newVM(true, true, compute.ProvisioningStateFailed, nil)
-			vmWithStatus2 := newVM(true, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateFailed)
-			azureVirtualMachine2 := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vm.Annotations = approved
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmWithStatus.Annotations = approved
+			approvedCondition := newCondition(azurev1alpha1.ConditionTypePendingApproval, metav1.ConditionFalse, azurev1alpha1.ConditionReasonApproved, "Reapplying has been approved")
+			vmWithStatus2 := withConditions(newVM(true, true, provisioningStateSucceeded, nil), remediedReapplied, trackedFound, reachable, notExhausted, approvedCondition)
+			vmWithStatus2.Annotations = approved
+			vmWithoutApproval := withConditions(newVM(true, true, provisioningStateSucceeded, nil), remediedReapplied, trackedFound, reachable, notExhausted, approvedCondition)
+			vmWithoutApproval.Annotations = map[string]string{}
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			azureVirtualMachine2 := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 
 			expectPatchStatus(vm, vmWithStatus).Return(nil)
 
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			expectRecordReapply()
 			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(nil)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine2, nil)
 			reappliedVMsCounter.EXPECT().Inc()
@@ -214,22 +409,55 @@ var _ = Describe("Actuator", func() {
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
 
 			expectPatchStatus(vmWithStatus, vmWithStatus2).Return(nil)
+			c.EXPECT().Patch(ctx, vmWithoutApproval, gomock.Any()).Return(nil)
 
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Normal VirtualMachineReapplied Reapplied failed Azure virtual machine " + azureVirtualMachineName)))
+		})
+
+		It("should only record that the Azure VM would have been reapplied in dry-run mode", func() {
+			cfg.DryRun = true
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, notifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vm := newVM(true, false, "", nil)
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmWithStatus2 := withConditions(newVM(true, true, provisioningStateFailed, nil),
+				newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonDryRun, "Azure virtual machine would have been reapplied (dry run)"),
+				trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil).Times(2)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(nil)
+			reappliedVMsCounter.EXPECT().Inc()
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailed)
+
+			expectPatchStatus(vmWithStatus, vmWithStatus2).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Normal VirtualMachineReapplied Would have reapplied failed Azure virtual machine " + azureVirtualMachineName + " (dry run)")))
 		})
 
 		It("should fail if getting the Azure VM fails", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithFailedOps := newVM(false, false, "", []azurev1alpha1.FailedOperation{
+			vmWithFailedOps := withConditions(newVM(false, false, "", []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeGetVirtualMachine,
 					Attempts:     1,
 					ErrorMessage: "could not get Azure virtual machine: test",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
+			}), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure virtual machine: test"),
+				notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, errors.New("test"))
 			expectPatchStatus(vm, vmWithFailedOps).Return(nil)
 
@@ -240,10 +468,36 @@ var _ = Describe("Actuator", func() {
 			Expect(re.RequeueAfter).To(Equal(requeueInterval))
 		})
 
+		It("should not retry if getting the Azure VM fails with a permanent error", func() {
+			vm := newVM(false, false, "", nil)
+			permanentErr := &azcore.ResponseError{StatusCode: http.StatusForbidden}
+			errorMessage := "could not get Azure virtual machine: " + permanentErr.Error()
+			vmWithFailedOps := withConditions(newVM(false, false, "", []azurev1alpha1.FailedOperation{
+				{
+					Type:         azurev1alpha1.OperationTypeGetVirtualMachine,
+					Attempts:     1,
+					ErrorMessage: errorMessage,
+					Reason:       azurev1alpha1.FailureReasonPermanent,
+					Timestamp:    now,
+				},
+			}), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, errorMessage),
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonPermanentFailure, "Operation GetVirtualMachine failed with a permanent error: "+errorMessage))
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, permanentErr)
+			permanentErrorsCounterVec.EXPECT().WithLabelValues(string(azurev1alpha1.OperationTypeGetVirtualMachine), "").Return(permanentErrorsCounter)
+			permanentErrorsCounter.EXPECT().Inc()
+			expectPatchStatus(vm, vmWithFailedOps).Return(nil)
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Warning PermanentFailure Operation GetVirtualMachine failed with a permanent error, giving up until next sync: " + errorMessage)))
+		})
+
 		It("should fail if updating the VirtualMachine object status fails", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 
 			expectPatchStatus(vm, vmWithStatus).Return(errors.New("test"))
@@ -254,22 +508,25 @@ var _ = Describe("Actuator", func() {
 
 		It("should fail if reapplying the Azure VM fails", func() {
 			vm := newVM(true, false, "", nil)
-			vmWithStatus := newVM(true, true, compute.ProvisioningStateFailed, nil)
-			vmWithFailedOps := newVM(true, true, compute.ProvisioningStateFailed, []azurev1alpha1.FailedOperation{
+			vmWithStatus := withConditions(newVM(true, true, provisioningStateFailed, nil), remediedProvisioningFailed, trackedFound, reachable, notExhausted)
+			vmWithFailedOps := withConditions(newVM(true, true, provisioningStateFailed, []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeReapplyVirtualMachine,
 					Attempts:     1,
 					ErrorMessage: "could not reapply Azure virtual machine: test",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateFailed)
+			}), newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonReapplyFailed, "could not reapply Azure virtual machine: test"),
+				trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 
 			expectPatchStatus(vm, vmWithStatus).Return(nil)
 
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			expectRecordReapply()
 			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(errors.New("test"))
 
 			expectPatchStatus(vmWithStatus, vmWithFailedOps).Return(nil)
@@ -282,54 +539,79 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should not fail if reapplying the Azure VM fails and max attempts have been reached", func() {
-			vmWithFailedOps := newVM(true, true, compute.ProvisioningStateFailed, []azurev1alpha1.FailedOperation{
+			mockNotifier := mockcontroller.NewMockNotifier(ctrl)
+			actuator = virtualmachine.NewActuator(c, vmUtils, cfg, timestamper, recorder, targetRecorder, remedyActionRecorder, budget, nil, mockNotifier, logger, reappliedVMsCounter, vmStatesGaugeVec, permanentErrorsCounterVec)
+			vmWithFailedOps := withConditions(newVM(true, true, provisioningStateFailed, []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeReapplyVirtualMachine,
 					Attempts:     1,
 					ErrorMessage: "could not reapply Azure virtual machine: unknown",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
-			vmWithFailedOps2 := newVM(true, true, compute.ProvisioningStateFailed, []azurev1alpha1.FailedOperation{
+			}), newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonReapplyFailed, "could not reapply Azure virtual machine: unknown"),
+				trackedFound, reachable, notExhausted)
+			vmWithFailedOps2 := withConditions(newVM(true, true, provisioningStateFailed, []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeReapplyVirtualMachine,
 					Attempts:     2,
 					ErrorMessage: "could not reapply Azure virtual machine: test",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateFailed)
+			}), newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonReapplyFailed, "could not reapply Azure virtual machine: test"),
+				trackedFound, reachable,
+				newCondition(azurev1alpha1.ConditionTypeRemedyExhausted, metav1.ConditionTrue, azurev1alpha1.ConditionReasonMaxAttemptsReached, "Operation ReapplyVirtualMachine failed 2 times: could not reapply Azure virtual machine: test"))
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: nodeName}, vmWithFailedOps).Return(nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			expectRecordReapply()
 			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(errors.New("test"))
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailed)
 
 			expectPatchStatus(vmWithFailedOps, vmWithFailedOps2).Return(nil)
+			mockNotifier.EXPECT().Notify(ctx, controller.Notification{
+				Resource: controller.NotificationResource{
+					Kind:       "VirtualMachine",
+					Namespace:  namespace,
+					Name:       nodeName,
+					ProviderID: azureVirtualMachineID,
+				},
+				Action:    string(azurev1alpha1.OperationTypeReapplyVirtualMachine),
+				Outcome:   controller.NotificationOutcomeGaveUp,
+				Message:   "Operation ReapplyVirtualMachine failed 2 times, giving up until next sync: could not reapply Azure virtual machine: test",
+				Timestamp: now,
+			}).Return(errors.New("test"))
 
 			requeueAfter, err := actuator.CreateOrUpdate(ctx, vmWithFailedOps.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(requeueAfter).To(Equal(syncPeriod))
+			Expect(recorder.Events).To(Receive(Equal("Warning MaxAttemptsReached Operation ReapplyVirtualMachine failed 2 times, giving up until next sync: could not reapply Azure virtual machine: test")))
+			Expect(targetRecorder.Events).To(Receive(Equal("Warning MaxAttemptsReached Operation ReapplyVirtualMachine failed 2 times, giving up until next sync: could not reapply Azure virtual machine: test")))
 		})
 
 		It("should clear failed operations if reapplying the Azure VM eventually succeeds", func() {
-			vmWithFailedOps := newVM(true, true, compute.ProvisioningStateFailed, []azurev1alpha1.FailedOperation{
+			vmWithFailedOps := withConditions(newVM(true, true, provisioningStateFailed, []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeReapplyVirtualMachine,
 					Attempts:     1,
 					ErrorMessage: "could not reapply Azure virtual machine: unknown",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
-			vm := newVM(true, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateFailed)
-			azureVirtualMachine2 := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			}), newCondition(azurev1alpha1.ConditionTypeRemedied, metav1.ConditionFalse, azurev1alpha1.ConditionReasonReapplyFailed, "could not reapply Azure virtual machine: unknown"),
+				trackedFound, reachable, notExhausted)
+			vm := withConditions(newVM(true, true, provisioningStateSucceeded, nil), remediedReapplied, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateFailed)
+			azureVirtualMachine2 := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: nodeName}, vmWithFailedOps).Return(nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateFailedWillReapply)
+			expectRecordReapply()
 			vmUtils.EXPECT().Reapply(ctx, azureVirtualMachineName).Return(nil)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine2, nil)
 			reappliedVMsCounter.EXPECT().Inc()
@@ -344,11 +626,46 @@ var _ = Describe("Actuator", func() {
 		})
 	})
 
+	Describe("#SetConfig", func() {
+		It("should use the new configuration for subsequent reconciliations", func() {
+			vm := newVM(false, false, "", nil)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
+			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
+			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
+			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
+
+			expectPatchStatus(vm, vmWithStatus).Return(nil)
+
+			newCfg := cfg
+			newCfg.SyncPeriod = metav1.Duration{Duration: 2 * syncPeriod}
+			Expect(actuator.SetConfig(newCfg)).To(Succeed())
+
+			requeueAfter, err := actuator.CreateOrUpdate(ctx, vm.DeepCopyObject().(client.Object))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(2 * syncPeriod))
+		})
+
+		It("should fail if the dry-run mode is changed", func() {
+			newCfg := cfg
+			newCfg.DryRun = true
+			Expect(actuator.SetConfig(newCfg)).To(MatchError(ContainSubstring("dry-run mode")))
+		})
+
+		It("should fail if the maintenance window is invalid", func() {
+			newCfg := cfg
+			newCfg.MaintenanceWindow = &config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{Begin: "invalid", End: "04:00"}},
+			}
+			Expect(actuator.SetConfig(newCfg)).To(MatchError(ContainSubstring("could not create maintenance window")))
+		})
+	})
+
 	Describe("#Delete", func() {
 		It("should update the VirtualMachine object status if the VM is found", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
@@ -360,11 +677,13 @@ var _ = Describe("Actuator", func() {
 			Expect(requeueAfter).To(Equal(time.Duration(0)))
 		})
 
-		It("should not update the VirtualMachine object status if the VM is not found", func() {
+		It("should only update the VirtualMachine object conditions if the VM is not found", func() {
 			vm := newVM(false, false, "", nil)
+			vmWithConditions := withConditions(newVM(false, false, "", nil), trackedNotFound, reachable, notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, nil)
 			vmStatesGaugeVec.EXPECT().DeleteLabelValues(azureVirtualMachineName)
-			c.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: nodeName}, vm).Return(nil)
+
+			expectPatchStatus(vm, vmWithConditions).Return(nil)
 
 			requeueAfter, err := actuator.Delete(ctx, vm.DeepCopyObject().(client.Object))
 			Expect(err).NotTo(HaveOccurred())
@@ -372,8 +691,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should not update the VirtualMachine object status if the VM is found and the status is already initialized", func() {
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
@@ -385,8 +704,8 @@ var _ = Describe("Actuator", func() {
 		})
 
 		It("should update the VirtualMachine object status if the VM is not found and the status is already initialized", func() {
-			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
+			vm := withConditions(newVM(false, false, "", nil), remediedNotNeeded, trackedNotFound, reachable, notExhausted)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, nil)
 			vmStatesGaugeVec.EXPECT().DeleteLabelValues(azureVirtualMachineName)
 
@@ -399,14 +718,17 @@ var _ = Describe("Actuator", func() {
 
 		It("should fail if getting the Azure VM fails", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithFailedOps := newVM(false, false, "", []azurev1alpha1.FailedOperation{
+			vmWithFailedOps := withConditions(newVM(false, false, "", []azurev1alpha1.FailedOperation{
 				{
 					Type:         azurev1alpha1.OperationTypeGetVirtualMachine,
 					Attempts:     1,
 					ErrorMessage: "could not get Azure virtual machine: test",
+					Reason:       azurev1alpha1.FailureReasonTransient,
 					Timestamp:    now,
 				},
-			})
+			}), trackedUnknown,
+				newCondition(azurev1alpha1.ConditionTypeAzureReachable, metav1.ConditionFalse, azurev1alpha1.ConditionReasonRequestFailed, "could not get Azure virtual machine: test"),
+				notExhausted)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(nil, errors.New("test"))
 			expectPatchStatus(vm, vmWithFailedOps).Return(nil)
 
@@ -419,8 +741,8 @@ var _ = Describe("Actuator", func() {
 
 		It("should fail if updating the VirtualMachine object status fails", func() {
 			vm := newVM(false, false, "", nil)
-			vmWithStatus := newVM(false, true, compute.ProvisioningStateSucceeded, nil)
-			azureVirtualMachine := newAzureVirtualMachine(compute.ProvisioningStateSucceeded)
+			vmWithStatus := withConditions(newVM(false, true, provisioningStateSucceeded, nil), remediedNotNeeded, trackedFound, reachable, notExhausted)
+			azureVirtualMachine := newAzureVirtualMachine(provisioningStateSucceeded)
 			vmUtils.EXPECT().Get(ctx, azureVirtualMachineName).Return(azureVirtualMachine, nil)
 			vmStatesGaugeVec.EXPECT().WithLabelValues(azureVirtualMachineName).Return(vmStatesGauge)
 			vmStatesGauge.EXPECT().Set(virtualmachine.VMStateOK)
@@ -0,0 +1,104 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"reflect"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ConfigSource holds a configuration of type T that can be changed at runtime, e.g. when the configuration file is reloaded.
+type ConfigSource[T any] interface {
+	// Get returns the current configuration.
+	Get() T
+	// Set replaces the current configuration with the given one. If it differs from the current configuration,
+	// all subscribers are called with the old and the new configuration, in the order they subscribed.
+	// If any subscriber rejects the new configuration, the old configuration is restored and the errors are returned.
+	Set(config T) error
+	// Subscribe registers the given function to be called whenever the configuration changes.
+	// The function should return an error if it rejects the new configuration.
+	Subscribe(fn func(oldConfig, newConfig T) error)
+}
+
+type configSource[T any] struct {
+	config      T
+	subscribers []func(oldConfig, newConfig T) error
+	mutex       sync.RWMutex
+	// setMutex serializes calls to Set, so that subscribers are called in the order of the changes
+	setMutex sync.Mutex
+}
+
+// NewConfigSource creates a new ConfigSource with the given initial configuration.
+func NewConfigSource[T any](config T) ConfigSource[T] {
+	return &configSource[T]{
+		config: config,
+	}
+}
+
+// Get returns the current configuration.
+func (s *configSource[T]) Get() T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.config
+}
+
+// Set replaces the current configuration with the given one. If it differs from the current configuration,
+// all subscribers are called with the old and the new configuration, in the order they subscribed.
+// If any subscriber rejects the new configuration, the old configuration is restored and the errors are returned,
+// so that setting the same configuration again is not ignored as unchanged.
+func (s *configSource[T]) Set(config T) error {
+	s.setMutex.Lock()
+	defer s.setMutex.Unlock()
+
+	s.mutex.Lock()
+	if reflect.DeepEqual(s.config, config) {
+		s.mutex.Unlock()
+		return nil
+	}
+	oldConfig := s.config
+	s.config = config
+	subscribers := append([]func(oldConfig, newConfig T) error{}, s.subscribers...)
+	s.mutex.Unlock()
+
+	var errs []error
+	for _, fn := range subscribers {
+		if err := fn(oldConfig, config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		s.mutex.Lock()
+		s.config = oldConfig
+		s.mutex.Unlock()
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Subscribe registers the given function to be called whenever the configuration changes.
+// The function should return an error if it rejects the new configuration.
+func (s *configSource[T]) Subscribe(fn func(oldConfig, newConfig T) error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// ConfigurableActuator is an Actuator whose configuration of type T can be changed at runtime.
+type ConfigurableActuator[T any] interface {
+	Actuator
+	// SetConfig validates the given configuration and, if valid, replaces the current configuration of the actuator with it.
+	SetConfig(config T) error
+}
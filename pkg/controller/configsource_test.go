@@ -0,0 +1,92 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/remedy-controller/pkg/controller"
+)
+
+var _ = Describe("ConfigSource", func() {
+	type testConfig struct {
+		Value  int
+		Values []string
+	}
+
+	var (
+		source  ConfigSource[testConfig]
+		changes [][2]testConfig
+	)
+
+	BeforeEach(func() {
+		source = NewConfigSource(testConfig{Value: 1, Values: []string{"a"}})
+		changes = nil
+		source.Subscribe(func(oldConfig, newConfig testConfig) error {
+			Expect(source.Get()).To(Equal(newConfig))
+			changes = append(changes, [2]testConfig{oldConfig, newConfig})
+			return nil
+		})
+	})
+
+	Describe("#Get", func() {
+		It("should return the initial configuration", func() {
+			Expect(source.Get()).To(Equal(testConfig{Value: 1, Values: []string{"a"}}))
+		})
+	})
+
+	Describe("#Set", func() {
+		It("should replace the configuration and call subscribers if it has changed", func() {
+			Expect(source.Set(testConfig{Value: 2, Values: []string{"a"}})).To(Succeed())
+			Expect(source.Get()).To(Equal(testConfig{Value: 2, Values: []string{"a"}}))
+			Expect(changes).To(Equal([][2]testConfig{
+				{{Value: 1, Values: []string{"a"}}, {Value: 2, Values: []string{"a"}}},
+			}))
+		})
+
+		It("should not call subscribers if the configuration has not changed", func() {
+			Expect(source.Set(testConfig{Value: 1, Values: []string{"a"}})).To(Succeed())
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("should call all subscribers in the order they subscribed", func() {
+			var order []int
+			source.Subscribe(func(_, _ testConfig) error { order = append(order, 2); return nil })
+			source.Subscribe(func(_, _ testConfig) error { order = append(order, 3); return nil })
+			Expect(source.Set(testConfig{Value: 2})).To(Succeed())
+			Expect(changes).To(HaveLen(1))
+			Expect(order).To(Equal([]int{2, 3}))
+		})
+
+		It("should restore the old configuration and return the errors if a subscriber rejects the new one", func() {
+			source.Subscribe(func(_, newConfig testConfig) error {
+				if newConfig.Value == 2 {
+					return errors.New("test")
+				}
+				return nil
+			})
+
+			Expect(source.Set(testConfig{Value: 2})).To(MatchError("test"))
+			Expect(source.Get()).To(Equal(testConfig{Value: 1, Values: []string{"a"}}))
+
+			// Setting the same configuration again is not ignored as unchanged
+			Expect(source.Set(testConfig{Value: 2})).To(MatchError("test"))
+			Expect(changes).To(HaveLen(2))
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -46,6 +47,7 @@ type AddArgs struct {
 	// ControllerName is the name of the controller.
 	ControllerName string
 	// FinalizerName is the finalizer name.
+	// If empty, no finalizers are used, and all objects are reconciled regardless of whether they should be finalized.
 	FinalizerName string
 	// ControllerOptions are the controller options to use when creating a controller.
 	// The Reconciler field is always overridden with a reconciler created from the given actuator.
@@ -58,6 +60,9 @@ type AddArgs struct {
 	Predicates []predicate.Predicate
 	// WatchBuilder defines additional watches that should be set up.
 	WatchBuilder extensionscontroller.WatchBuilder
+	// Recorder is the event recorder to use for emitting events on reconciled objects.
+	// If nil, an event recorder for the controller name is obtained from the manager.
+	Recorder record.EventRecorder
 }
 
 // DefaultPredicates returns the default predicates for a reconciler.
@@ -67,7 +72,10 @@ func DefaultPredicates() []predicate.Predicate {
 
 // Add creates a new controller and adds it to the given manager using the given args.
 func Add(mgr manager.Manager, args AddArgs) error {
-	args.ControllerOptions.Reconciler = NewReconciler(args.Actuator, args.ControllerName, args.FinalizerName, args.Type, args.ShouldEnsureDeleted, mgr.GetClient(), mgr.GetAPIReader(), log.Log.WithName(args.ControllerName))
+	if args.Recorder == nil {
+		args.Recorder = mgr.GetEventRecorderFor(args.ControllerName)
+	}
+	args.ControllerOptions.Reconciler = NewReconciler(args.Actuator, args.ControllerName, args.FinalizerName, args.Type, args.ShouldEnsureDeleted, mgr.GetClient(), mgr.GetAPIReader(), args.Recorder, log.Log.WithName(args.ControllerName))
 	return add(mgr, args)
 }
 
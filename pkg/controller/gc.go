@@ -0,0 +1,157 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+// GarbageCollectorArgs are arguments for creating a garbage collector.
+type GarbageCollectorArgs struct {
+	// Client is the Kubernetes client for the control cluster.
+	Client client.Client
+	// TargetReader is used to read objects from the target cluster. It should read directly from the API server,
+	// so that tracking resources are not deleted due to a stale cache.
+	TargetReader client.Reader
+	// Namespace is the namespace of the tracking resources in the control cluster.
+	Namespace string
+	// ListType is the list type of the tracking resources, e.g. PublicIPAddressList.
+	ListType client.ObjectList
+	// TargetType is the type of the target objects the tracking resources belong to, e.g. Service.
+	TargetType client.Object
+	// Label is the label and annotation on the tracking resources that identify the target object.
+	Label string
+	// ObjectLabeler is used to get the name of the target object from its identity.
+	ObjectLabeler ObjectLabeler
+	// Period is the period at which garbage collection is performed.
+	Period time.Duration
+	// AnomalyGuard is used to refuse garbage collection if all or most target objects appear to be missing,
+	// e.g. because the target reader reads from the wrong or an empty cluster. If nil, the default values are used.
+	AnomalyGuard *utils.AnomalyGuard
+}
+
+// GarbageCollector periodically deletes tracking resources whose target objects no longer exist.
+// This is needed since deletions of target objects that happened while the controller was down are not observed.
+// Tracking resources that are paused, or in a paused namespace, are not deleted.
+type GarbageCollector interface {
+	manager.Runnable
+	// Collect deletes all tracking resources whose target objects no longer exist.
+	Collect(ctx context.Context) error
+}
+
+// NewGarbageCollector creates a new GarbageCollector.
+func NewGarbageCollector(args GarbageCollectorArgs, logger logr.Logger) GarbageCollector {
+	anomalyGuard := utils.NewAnomalyGuard(nil)
+	if args.AnomalyGuard != nil {
+		anomalyGuard = *args.AnomalyGuard
+	}
+	return &garbageCollector{
+		args:         args,
+		anomalyGuard: anomalyGuard,
+		logger:       logger,
+	}
+}
+
+type garbageCollector struct {
+	args         GarbageCollectorArgs
+	anomalyGuard utils.AnomalyGuard
+	logger       logr.Logger
+}
+
+// Start performs garbage collection periodically until the given context is cancelled.
+func (gc *garbageCollector) Start(ctx context.Context) error {
+	gc.logger.Info("Starting garbage collector", "period", gc.args.Period)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := gc.Collect(ctx); err != nil {
+			gc.logger.Error(err, "Could not collect garbage")
+		}
+	}, gc.args.Period)
+	return nil
+}
+
+// Collect deletes all tracking resources whose target objects no longer exist.
+func (gc *garbageCollector) Collect(ctx context.Context) error {
+	paused, err := isNamespacePaused(ctx, gc.args.Client, gc.args.Namespace)
+	if err != nil {
+		return err
+	}
+	if paused {
+		gc.logger.Info("Skipping garbage collection in paused namespace", "namespace", gc.args.Namespace)
+		return nil
+	}
+
+	list := gc.args.ListType.DeepCopyObject().(client.ObjectList)
+	if err := gc.args.Client.List(ctx, list, client.InNamespace(gc.args.Namespace), client.HasLabels{gc.args.Label}); err != nil {
+		return errors.Wrap(err, "could not list tracking resources")
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return errors.Wrap(err, "could not extract tracking resources from list")
+	}
+
+	var orphans []client.Object
+	var known int
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || obj.GetDeletionTimestamp() != nil || hasPausedAnnotation(obj) {
+			continue
+		}
+		targetName := gc.targetName(obj)
+		if targetName.Name == "" {
+			continue
+		}
+
+		// Check if the target object still exists
+		target := gc.args.TargetType.DeepCopyObject().(client.Object)
+		if err := gc.args.TargetReader.Get(ctx, targetName, target); err == nil {
+			known++
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "could not get target object")
+		}
+		orphans = append(orphans, obj)
+	}
+
+	// Refuse to delete tracking resources if all or most target objects appear to be missing
+	if err := gc.anomalyGuard.Check(len(orphans), known); err != nil {
+		return errors.Wrap(err, "refusing to delete orphaned tracking resources")
+	}
+
+	for _, obj := range orphans {
+		// Delete the tracking resource, triggering its normal deletion
+		gc.logger.Info("Deleting orphaned tracking resource", "name", obj.GetName(), "namespace", obj.GetNamespace(), "target", gc.targetName(obj).String())
+		if err := client.IgnoreNotFound(gc.args.Client.Delete(ctx, obj)); err != nil {
+			return errors.Wrap(err, "could not delete tracking resource")
+		}
+	}
+
+	return nil
+}
+
+func (gc *garbageCollector) targetName(obj client.Object) types.NamespacedName {
+	return gc.args.ObjectLabeler.GetNamespacedName(GetIdentity(obj, gc.args.Label))
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/controller"
+	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("GarbageCollector", func() {
+	const (
+		label        = "azure.remedy.gardener.cloud/node"
+		nodeName     = "test-node"
+		otherNode    = "other-node"
+		vmName       = "test-vm"
+		otherVMName  = "other-vm"
+		vmsNamespace = "test-namespace"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		c            *mockclient.MockClient
+		targetReader *mockclient.MockReader
+
+		gc controller.GarbageCollector
+
+		vm      *azurev1alpha1.VirtualMachine
+		otherVM *azurev1alpha1.VirtualMachine
+
+		namespaceAnnotations map[string]string
+		notFoundError        error
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		c = mockclient.NewMockClient(ctrl)
+		targetReader = mockclient.NewMockReader(ctrl)
+
+		gc = controller.NewGarbageCollector(controller.GarbageCollectorArgs{
+			Client:        c,
+			TargetReader:  targetReader,
+			Namespace:     vmsNamespace,
+			ListType:      &azurev1alpha1.VirtualMachineList{},
+			TargetType:    &corev1.Node{},
+			Label:         label,
+			ObjectLabeler: controller.NewClusterObjectLabeler(),
+		}, log.Log.WithName("test"))
+
+		vm = &azurev1alpha1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: vmsNamespace,
+				Labels:    map[string]string{label: nodeName},
+			},
+		}
+		otherVM = &azurev1alpha1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      otherVMName,
+				Namespace: vmsNamespace,
+				Labels:    map[string]string{label: otherNode},
+			},
+		}
+
+		namespaceAnnotations = nil
+		notFoundError = apierrors.NewNotFound(schema.GroupResource{}, nodeName)
+
+		c.EXPECT().Get(ctx, client.ObjectKey{Name: vmsNamespace}, gomock.AssignableToTypeOf(&corev1.Namespace{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, ns *corev1.Namespace, _ ...client.GetOption) error {
+			ns.Annotations = namespaceAnnotations
+			return nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	expectList := func(vms ...azurev1alpha1.VirtualMachine) {
+		c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.VirtualMachineList{}), client.InNamespace(vmsNamespace), client.HasLabels{label}).
+			DoAndReturn(func(_ context.Context, list *azurev1alpha1.VirtualMachineList, _ ...client.ListOption) error {
+				list.Items = vms
+				return nil
+			})
+	}
+
+	Describe("#Collect", func() {
+		It("should delete tracking resources whose target objects no longer exist", func() {
+			expectList(*vm, *otherVM)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(notFoundError)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: otherNode}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(nil)
+			c.EXPECT().Delete(ctx, vm).Return(nil)
+
+			Expect(gc.Collect(ctx)).To(Succeed())
+		})
+
+		It("should not delete tracking resources that are already being deleted", func() {
+			vm.DeletionTimestamp = &metav1.Time{}
+			expectList(*vm)
+
+			Expect(gc.Collect(ctx)).To(Succeed())
+		})
+
+		It("should fail if listing tracking resources fails", func() {
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.VirtualMachineList{}), client.InNamespace(vmsNamespace), client.HasLabels{label}).Return(errors.New("test"))
+
+			Expect(gc.Collect(ctx)).To(MatchError("could not list tracking resources: test"))
+		})
+
+		It("should fail and not delete anything if getting a target object fails", func() {
+			expectList(*vm)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(errors.New("test"))
+
+			Expect(gc.Collect(ctx)).To(MatchError("could not get target object: test"))
+		})
+
+		It("should fail if deleting a tracking resource fails", func() {
+			expectList(*vm, *otherVM)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(notFoundError)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: otherNode}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(nil)
+			c.EXPECT().Delete(ctx, vm).Return(errors.New("test"))
+
+			Expect(gc.Collect(ctx)).To(MatchError("could not delete tracking resource: test"))
+		})
+
+		It("should not delete tracking resources that are paused", func() {
+			vm.Annotations = map[string]string{controller.PausedAnnotation: "true"}
+			expectList(*vm, *otherVM)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: otherNode}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(nil)
+
+			Expect(gc.Collect(ctx)).To(Succeed())
+		})
+
+		It("should not delete any tracking resources if their namespace is paused", func() {
+			namespaceAnnotations = map[string]string{controller.PausedAnnotation: "true"}
+
+			Expect(gc.Collect(ctx)).To(Succeed())
+		})
+
+		It("should delete a tracking resource if its target object is the last one and missing", func() {
+			expectList(*vm)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(notFoundError)
+			c.EXPECT().Delete(ctx, vm).Return(nil)
+
+			Expect(gc.Collect(ctx)).To(Succeed())
+		})
+
+		It("should refuse to delete tracking resources if all target objects appear to be missing", func() {
+			gc = controller.NewGarbageCollector(controller.GarbageCollectorArgs{
+				Client:        c,
+				TargetReader:  targetReader,
+				Namespace:     vmsNamespace,
+				ListType:      &azurev1alpha1.VirtualMachineList{},
+				TargetType:    &corev1.Node{},
+				Label:         label,
+				ObjectLabeler: controller.NewClusterObjectLabeler(),
+				AnomalyGuard:  &utils.AnomalyGuard{MaxOrphanRatio: 1, MinOrphans: 1},
+			}, log.Log.WithName("test"))
+			expectList(*vm)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(notFoundError)
+
+			Expect(gc.Collect(ctx)).To(MatchError("refusing to delete orphaned tracking resources: all 1 resources appear to be orphaned, the target cluster appears to be empty"))
+		})
+
+		It("should refuse to delete tracking resources if most target objects appear to be missing", func() {
+			gc = controller.NewGarbageCollector(controller.GarbageCollectorArgs{
+				Client:        c,
+				TargetReader:  targetReader,
+				Namespace:     vmsNamespace,
+				ListType:      &azurev1alpha1.VirtualMachineList{},
+				TargetType:    &corev1.Node{},
+				Label:         label,
+				ObjectLabeler: controller.NewClusterObjectLabeler(),
+				AnomalyGuard:  &utils.AnomalyGuard{MaxOrphanRatio: 0.5, MinOrphans: 1},
+			}, log.Log.WithName("test"))
+			expectList(*vm, *otherVM)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: nodeName}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(notFoundError)
+			targetReader.EXPECT().Get(ctx, types.NamespacedName{Name: otherNode}, gomock.AssignableToTypeOf(&corev1.Node{})).Return(nil)
+
+			Expect(gc.Collect(ctx)).To(MatchError("refusing to delete orphaned tracking resources: ratio of orphaned to known resources 1/1 exceeds the max ratio 0.50"))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+const (
+	// migrationRetryInterval is the interval at which a failed migration is retried.
+	migrationRetryInterval = 30 * time.Second
+)
+
+// IdentityMigratorArgs are arguments for creating an identity migrator.
+type IdentityMigratorArgs struct {
+	// Client is the Kubernetes client for the control cluster.
+	Client client.Client
+	// Namespace is the namespace of the tracking resources in the control cluster.
+	Namespace string
+	// ListType is the list type of the tracking resources, e.g. PublicIPAddressList.
+	ListType client.ObjectList
+	// Label is the label and annotation on the tracking resources that identify the target object.
+	Label string
+}
+
+// IdentityMigrator migrates tracking resources created before the full identity of their target objects was stored
+// in an annotation. It adds the annotation with the full identity taken from the label, and shortens the label value if needed.
+// Migration is performed once on start and retried until it succeeds.
+type IdentityMigrator interface {
+	manager.Runnable
+	// Migrate migrates all tracking resources that don't have the annotation yet.
+	Migrate(ctx context.Context) error
+}
+
+// NewIdentityMigrator creates a new IdentityMigrator.
+func NewIdentityMigrator(args IdentityMigratorArgs, logger logr.Logger) IdentityMigrator {
+	return &identityMigrator{
+		args:   args,
+		logger: logger,
+	}
+}
+
+type identityMigrator struct {
+	args   IdentityMigratorArgs
+	logger logr.Logger
+}
+
+// Start performs the migration, retrying until it succeeds or the given context is cancelled.
+func (m *identityMigrator) Start(ctx context.Context) error {
+	// The condition never returns an error, so an error is only returned if the context is cancelled
+	_ = wait.PollUntilContextCancel(ctx, migrationRetryInterval, true, func(ctx context.Context) (bool, error) {
+		if err := m.Migrate(ctx); err != nil {
+			m.logger.Error(err, "Could not migrate tracking resources, will retry")
+			return false, nil
+		}
+		return true, nil
+	})
+	return nil
+}
+
+// Migrate migrates all tracking resources that don't have the annotation yet.
+func (m *identityMigrator) Migrate(ctx context.Context) error {
+	list := m.args.ListType.DeepCopyObject().(client.ObjectList)
+	if err := m.args.Client.List(ctx, list, client.InNamespace(m.args.Namespace), client.HasLabels{m.args.Label}); err != nil {
+		return errors.Wrap(err, "could not list tracking resources")
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return errors.Wrap(err, "could not extract tracking resources from list")
+	}
+
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || obj.GetAnnotations()[m.args.Label] != "" {
+			continue
+		}
+
+		// Store the full identity from the label in the annotation, and shorten the label value if needed
+		identity := obj.GetLabels()[m.args.Label]
+		m.logger.Info("Migrating tracking resource", "name", obj.GetName(), "namespace", obj.GetNamespace(), "identity", identity)
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		obj.SetAnnotations(utils.Add(obj.GetAnnotations(), m.args.Label, identity))
+		obj.SetLabels(utils.Add(obj.GetLabels(), m.args.Label, ShortenLabelValue(identity)))
+		if err := client.IgnoreNotFound(m.args.Client.Patch(ctx, obj, patch)); err != nil {
+			return errors.Wrap(err, "could not patch tracking resource")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/controller"
+	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
+)
+
+var _ = Describe("IdentityMigrator", func() {
+	const (
+		serviceLabel    = "azure.remedy.gardener.cloud/service"
+		pubipName       = "test-pubip"
+		pubipsNamespace = "test-namespace"
+		identity        = "test.service"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		c *mockclient.MockClient
+
+		migrator controller.IdentityMigrator
+
+		pubip         *azurev1alpha1.PublicIPAddress
+		migratedPubip *azurev1alpha1.PublicIPAddress
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		c = mockclient.NewMockClient(ctrl)
+
+		migrator = controller.NewIdentityMigrator(controller.IdentityMigratorArgs{
+			Client:    c,
+			Namespace: pubipsNamespace,
+			ListType:  &azurev1alpha1.PublicIPAddressList{},
+			Label:     serviceLabel,
+		}, log.Log.WithName("test"))
+
+		pubip = &azurev1alpha1.PublicIPAddress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pubipName,
+				Namespace: pubipsNamespace,
+				Labels:    map[string]string{serviceLabel: identity},
+			},
+		}
+		migratedPubip = &azurev1alpha1.PublicIPAddress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        pubipName,
+				Namespace:   pubipsNamespace,
+				Labels:      map[string]string{serviceLabel: identity},
+				Annotations: map[string]string{serviceLabel: identity},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	expectList := func(pubips ...azurev1alpha1.PublicIPAddress) {
+		c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddressList{}), client.InNamespace(pubipsNamespace), client.HasLabels{serviceLabel}).
+			DoAndReturn(func(_ context.Context, list *azurev1alpha1.PublicIPAddressList, _ ...client.ListOption) error {
+				list.Items = pubips
+				return nil
+			})
+	}
+
+	Describe("#Migrate", func() {
+		It("should add the annotation to tracking resources that don't have it", func() {
+			expectList(*pubip, *migratedPubip)
+			c.EXPECT().Patch(ctx, migratedPubip, gomock.Any()).Return(nil)
+
+			Expect(migrator.Migrate(ctx)).To(Succeed())
+		})
+
+		It("should fail if listing tracking resources fails", func() {
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddressList{}), client.InNamespace(pubipsNamespace), client.HasLabels{serviceLabel}).Return(errors.New("test"))
+
+			Expect(migrator.Migrate(ctx)).To(MatchError("could not list tracking resources: test"))
+		})
+
+		It("should fail if patching a tracking resource fails", func() {
+			expectList(*pubip)
+			c.EXPECT().Patch(ctx, migratedPubip, gomock.Any()).Return(errors.New("test"))
+
+			Expect(migrator.Migrate(ctx)).To(MatchError("could not patch tracking resource: test"))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewNamespacedActuator creates an Actuator that delegates to the given actuators by the namespace of the reconciled object.
+// An actuator mapped to the empty namespace is used for objects in namespaces without an actuator of their own.
+func NewNamespacedActuator(actuators map[string]Actuator) Actuator {
+	return &namespacedActuator{
+		actuators: actuators,
+	}
+}
+
+type namespacedActuator struct {
+	actuators map[string]Actuator
+}
+
+// CreateOrUpdate reconciles object creation or update.
+func (a *namespacedActuator) CreateOrUpdate(ctx context.Context, obj client.Object) (time.Duration, error) {
+	actuator, err := a.getActuator(obj)
+	if err != nil {
+		return 0, err
+	}
+	return actuator.CreateOrUpdate(ctx, obj)
+}
+
+// Delete reconciles object deletion.
+func (a *namespacedActuator) Delete(ctx context.Context, obj client.Object) (time.Duration, error) {
+	actuator, err := a.getActuator(obj)
+	if err != nil {
+		return 0, err
+	}
+	return actuator.Delete(ctx, obj)
+}
+
+// ShouldFinalize returns true if the object should be finalized.
+func (a *namespacedActuator) ShouldFinalize(ctx context.Context, obj client.Object) (bool, error) {
+	actuator, err := a.getActuator(obj)
+	if err != nil {
+		return false, err
+	}
+	return actuator.ShouldFinalize(ctx, obj)
+}
+
+func (a *namespacedActuator) getActuator(obj client.Object) (Actuator, error) {
+	if actuator, ok := a.actuators[obj.GetNamespace()]; ok {
+		return actuator, nil
+	}
+	if actuator, ok := a.actuators[""]; ok {
+		return actuator, nil
+	}
+	return nil, errors.Errorf("no actuator found for namespace %s", obj.GetNamespace())
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/remedy-controller/pkg/controller"
+	mockcontroller "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller"
+)
+
+var _ = Describe("NamespacedActuator", func() {
+	const (
+		namespace1 = "shoot--dev--test1"
+		namespace2 = "shoot--dev--test2"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		a1 *mockcontroller.MockActuator
+		a2 *mockcontroller.MockActuator
+
+		obj1 *azurev1alpha1.PublicIPAddress
+		obj2 *azurev1alpha1.PublicIPAddress
+		obj3 *azurev1alpha1.PublicIPAddress
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		a1 = mockcontroller.NewMockActuator(ctrl)
+		a2 = mockcontroller.NewMockActuator(ctrl)
+
+		obj1 = &azurev1alpha1.PublicIPAddress{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: namespace1}}
+		obj2 = &azurev1alpha1.PublicIPAddress{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: namespace2}}
+		obj3 = &azurev1alpha1.PublicIPAddress{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "other"}}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should delegate to the actuator for the namespace of the object", func() {
+		actuator := controller.NewNamespacedActuator(map[string]controller.Actuator{namespace1: a1, namespace2: a2})
+		a1.EXPECT().CreateOrUpdate(ctx, obj1).Return(time.Minute, nil)
+		a2.EXPECT().Delete(ctx, obj2).Return(time.Duration(0), nil)
+		a2.EXPECT().ShouldFinalize(ctx, obj2).Return(true, nil)
+
+		Expect(actuator.CreateOrUpdate(ctx, obj1)).To(Equal(time.Minute))
+		Expect(actuator.Delete(ctx, obj2)).To(Equal(time.Duration(0)))
+		Expect(actuator.ShouldFinalize(ctx, obj2)).To(BeTrue())
+	})
+
+	It("should delegate to the actuator for the empty namespace if there is no actuator for the namespace of the object", func() {
+		actuator := controller.NewNamespacedActuator(map[string]controller.Actuator{namespace1: a1, "": a2})
+		a2.EXPECT().CreateOrUpdate(ctx, obj3).Return(time.Minute, nil)
+
+		Expect(actuator.CreateOrUpdate(ctx, obj3)).To(Equal(time.Minute))
+	})
+
+	It("should fail if there is no actuator for the namespace of the object", func() {
+		actuator := controller.NewNamespacedActuator(map[string]controller.Actuator{namespace1: a1, namespace2: a2})
+
+		_, err := actuator.CreateOrUpdate(ctx, obj3)
+		Expect(err).To(MatchError("no actuator found for namespace other"))
+	})
+})
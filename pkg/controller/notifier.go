@@ -0,0 +1,244 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+const (
+	// DefaultWebhookTimeout is the default timeout of a single webhook request.
+	DefaultWebhookTimeout = 10 * time.Second
+	// DefaultWebhookMaxAttempts is the default max attempts to send a notification to a webhook.
+	DefaultWebhookMaxAttempts = 3
+	// DefaultWebhookRetryInterval is the default interval between attempts to send a notification to a webhook.
+	DefaultWebhookRetryInterval = 1 * time.Second
+	// DefaultNotificationQueueSize is the default max number of notifications waiting to be sent asynchronously.
+	DefaultNotificationQueueSize = 100
+)
+
+// NotificationOutcome is the outcome of a remedy that is notified.
+type NotificationOutcome string
+
+const (
+	// NotificationOutcomeSucceeded indicates that a remedy has been applied successfully.
+	NotificationOutcomeSucceeded NotificationOutcome = "Succeeded"
+	// NotificationOutcomeGaveUp indicates that a remedy has given up, either because an operation has reached
+	// its max attempts or because it failed with a permanent error.
+	NotificationOutcomeGaveUp NotificationOutcome = "GaveUp"
+)
+
+// Notification is a notification about the outcome of a remedy.
+type Notification struct {
+	// Cluster is the name of the cluster.
+	Cluster string `json:"cluster"`
+	// Resource is the remedied resource.
+	Resource NotificationResource `json:"resource"`
+	// Action is the action of the remedy, e.g. the operation type.
+	Action string `json:"action"`
+	// Outcome is the outcome of the remedy.
+	Outcome NotificationOutcome `json:"outcome"`
+	// Message is a human-readable message describing the outcome.
+	Message string `json:"message,omitempty"`
+	// Timestamp is the time of the outcome.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// NotificationResource identifies a remedied resource.
+type NotificationResource struct {
+	// Kind is the kind of the resource, e.g. PublicIPAddress.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// ProviderID is the ID of the corresponding cloud provider resource, if known.
+	ProviderID string `json:"providerID,omitempty"`
+}
+
+// Notifier sends notifications about the outcomes of remedies.
+type Notifier interface {
+	// Notify sends the given notification.
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NewNotifier creates a new Notifier from the given configuration.
+// If no notifications are configured, the returned Notifier does nothing.
+func NewNotifier(cfg *config.NotificationsConfiguration, logger logr.Logger) Notifier {
+	if cfg == nil || cfg.Webhook == nil {
+		return nopNotifier{}
+	}
+	return NewWebhookNotifier(cfg.Webhook, cfg.ClusterName, &http.Client{}, logger)
+}
+
+type nopNotifier struct{}
+
+// Notify does nothing.
+func (nopNotifier) Notify(_ context.Context, _ Notification) error {
+	return nil
+}
+
+// AsyncNotifier is a Notifier that queues notifications and sends them in the background, so that sending them
+// doesn't delay remedies. It must be started to send the queued notifications.
+type AsyncNotifier interface {
+	Notifier
+	manager.Runnable
+}
+
+type asyncNotifier struct {
+	notifier Notifier
+	queue    chan Notification
+	logger   logr.Logger
+}
+
+// NewAsyncNotifier creates a new AsyncNotifier that sends notifications with the given notifier,
+// queueing up to the given number of notifications.
+func NewAsyncNotifier(notifier Notifier, queueSize int, logger logr.Logger) AsyncNotifier {
+	return &asyncNotifier{
+		notifier: notifier,
+		queue:    make(chan Notification, queueSize),
+		logger:   logger,
+	}
+}
+
+// Notify queues the given notification without waiting for it to be sent.
+// If the queue is full, the notification is dropped and an error is returned.
+func (n *asyncNotifier) Notify(_ context.Context, notification Notification) error {
+	select {
+	case n.queue <- notification:
+		return nil
+	default:
+		return errors.New("notification queue is full, dropping notification")
+	}
+}
+
+// Start sends the queued notifications until the given context is cancelled.
+func (n *asyncNotifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.queue:
+			if err := n.notifier.Notify(ctx, notification); err != nil {
+				n.logger.Error(err, "Could not send notification", "name", notification.Resource.Name, "namespace", notification.Resource.Namespace)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, since notifications that have already been queued should be sent
+// whether or not this instance is still the leader.
+func (n *asyncNotifier) NeedLeaderElection() bool {
+	return false
+}
+
+type webhookNotifier struct {
+	url           string
+	clusterName   string
+	timeout       time.Duration
+	maxAttempts   int
+	retryInterval time.Duration
+	httpClient    *http.Client
+	logger        logr.Logger
+}
+
+// NewWebhookNotifier creates a new Notifier that posts notifications as JSON to the configured webhook URL,
+// retrying failed requests. If the given cluster name is empty, the namespace of the resource is used instead.
+func NewWebhookNotifier(cfg *config.WebhookNotifierConfiguration, clusterName string, httpClient *http.Client, logger logr.Logger) Notifier {
+	n := &webhookNotifier{
+		url:           cfg.URL,
+		clusterName:   clusterName,
+		timeout:       DefaultWebhookTimeout,
+		maxAttempts:   DefaultWebhookMaxAttempts,
+		retryInterval: DefaultWebhookRetryInterval,
+		httpClient:    httpClient,
+		logger:        logger,
+	}
+	if cfg.Timeout != nil {
+		n.timeout = cfg.Timeout.Duration
+	}
+	if cfg.MaxAttempts != nil {
+		n.maxAttempts = *cfg.MaxAttempts
+	}
+	if cfg.RetryInterval != nil {
+		n.retryInterval = cfg.RetryInterval.Duration
+	}
+	return n
+}
+
+// Notify posts the given notification to the webhook URL, retrying up to the max attempts.
+func (n *webhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	if notification.Cluster == "" {
+		notification.Cluster = n.clusterName
+	}
+	if notification.Cluster == "" {
+		notification.Cluster = notification.Resource.Namespace
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal notification")
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = n.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= n.maxAttempts {
+			return errors.Wrapf(err, "could not send notification to webhook after %d attempts", attempt)
+		}
+		n.logger.Info("Sending notification to webhook failed, retrying", "attempt", attempt, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "could not send notification to webhook")
+		case <-time.After(n.retryInterval):
+		}
+	}
+}
+
+func (n *webhookNotifier) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post to webhook")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
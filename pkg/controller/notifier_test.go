@@ -0,0 +1,190 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/controller"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		ctx          context.Context
+		notification Notification
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		notification = Notification{
+			Resource: NotificationResource{
+				Kind:      "PublicIPAddress",
+				Namespace: "shoot--dev--test",
+				Name:      "test",
+			},
+			Action:    "CleanPublicIPAddress",
+			Outcome:   NotificationOutcomeSucceeded,
+			Message:   "Cleaned orphaned Azure public IP address 1.2.3.4",
+			Timestamp: metav1.NewTime(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC).Local()),
+		}
+	})
+
+	Describe("#NewNotifier", func() {
+		It("should return a notifier that does nothing if no notifications are configured", func() {
+			Expect(NewNotifier(nil, log.Log).Notify(ctx, notification)).To(Succeed())
+			Expect(NewNotifier(&config.NotificationsConfiguration{}, log.Log).Notify(ctx, notification)).To(Succeed())
+		})
+	})
+
+	Describe("#AsyncNotifier", func() {
+		var (
+			notifications chan Notification
+			release       chan struct{}
+			notifier      AsyncNotifier
+		)
+
+		BeforeEach(func() {
+			notifications = make(chan Notification, 10)
+			release = make(chan struct{})
+			notifier = NewAsyncNotifier(notifierFunc(func(_ context.Context, n Notification) error {
+				<-release
+				notifications <- n
+				return nil
+			}), 1, log.Log)
+		})
+
+		It("should queue the notification without waiting for it to be sent", func() {
+			Expect(notifier.Notify(ctx, notification)).To(Succeed())
+			Consistently(notifications).ShouldNot(Receive())
+
+			startCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(notifier.Start(startCtx)).To(Succeed())
+			}()
+			close(release)
+			Eventually(notifications).Should(Receive(Equal(notification)))
+		})
+
+		It("should drop the notification if the queue is full", func() {
+			Expect(notifier.Notify(ctx, notification)).To(Succeed())
+			Expect(notifier.Notify(ctx, notification)).To(MatchError("notification queue is full, dropping notification"))
+		})
+	})
+
+	Describe("#WebhookNotifier", func() {
+		var (
+			server       *httptest.Server
+			mutex        sync.Mutex
+			statusCodes  []int
+			requests     int
+			received     []Notification
+			contentTypes []string
+			cfg          *config.WebhookNotifierConfiguration
+		)
+
+		BeforeEach(func() {
+			statusCodes = nil
+			requests = 0
+			received = nil
+			contentTypes = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				requests++
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				var n Notification
+				Expect(json.Unmarshal(body, &n)).To(Succeed())
+				received = append(received, n)
+				contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+				statusCode := http.StatusOK
+				if len(statusCodes) > 0 {
+					statusCode, statusCodes = statusCodes[0], statusCodes[1:]
+				}
+				w.WriteHeader(statusCode)
+			}))
+			cfg = &config.WebhookNotifierConfiguration{
+				URL:           server.URL,
+				RetryInterval: &metav1.Duration{Duration: time.Millisecond},
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should post the notification as JSON, using the namespace as cluster name if not configured", func() {
+			notifier := NewWebhookNotifier(cfg, "", server.Client(), log.Log)
+			Expect(notifier.Notify(ctx, notification)).To(Succeed())
+
+			expected := notification
+			expected.Cluster = "shoot--dev--test"
+			Expect(requests).To(Equal(1))
+			Expect(received).To(ConsistOf(expected))
+			Expect(contentTypes).To(ConsistOf("application/json"))
+		})
+
+		It("should use the configured cluster name", func() {
+			notifier := NewWebhookNotifier(cfg, "dev-test", server.Client(), log.Log)
+			Expect(notifier.Notify(ctx, notification)).To(Succeed())
+
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].Cluster).To(Equal("dev-test"))
+		})
+
+		It("should retry if the webhook responds with an error", func() {
+			statusCodes = []int{http.StatusServiceUnavailable, http.StatusInternalServerError}
+			notifier := NewWebhookNotifier(cfg, "", server.Client(), log.Log)
+			Expect(notifier.Notify(ctx, notification)).To(Succeed())
+			Expect(requests).To(Equal(3))
+		})
+
+		It("should fail if the webhook responds with an error and max attempts have been reached", func() {
+			statusCodes = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+			cfg.MaxAttempts = ptr.To(2)
+			notifier := NewWebhookNotifier(cfg, "", server.Client(), log.Log)
+			Expect(notifier.Notify(ctx, notification)).To(MatchError(ContainSubstring("after 2 attempts")))
+			Expect(requests).To(Equal(2))
+		})
+
+		It("should fail if the webhook can't be reached", func() {
+			cfg.MaxAttempts = ptr.To(1)
+			server.Close()
+			notifier := NewWebhookNotifier(cfg, "", http.DefaultClient, log.Log)
+			Expect(notifier.Notify(ctx, notification)).To(HaveOccurred())
+		})
+	})
+})
+
+type notifierFunc func(ctx context.Context, notification Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, notification Notification) error {
+	return f(ctx, notification)
+}
@@ -132,3 +132,11 @@ func (p *ownedObjectPredicate) getOwner(key client.ObjectKey) (client.Object, er
 	}
 	return obj, nil
 }
+
+// NewNamespacePredicate creates a new predicate that filters only events for objects in the given namespace.
+// If the namespace is empty, events for objects in all namespaces are processed.
+func NewNamespacePredicate(namespace string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return namespace == "" || obj.GetNamespace() == namespace
+	})
+}
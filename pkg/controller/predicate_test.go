@@ -263,3 +263,29 @@ var _ = Describe("OwnedObjectPredicate", func() {
 		})
 	})
 })
+
+var _ = Describe("NamespacePredicate", func() {
+	var (
+		obj      *corev1.Pod
+		otherObj *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		obj = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		otherObj = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "other"}}
+	})
+
+	It("should process events only for objects in the given namespace", func() {
+		p := controller.NewNamespacePredicate(namespace)
+		Expect(p.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: otherObj})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: otherObj, ObjectNew: otherObj})).To(BeFalse())
+		Expect(p.Delete(event.DeleteEvent{Object: otherObj})).To(BeFalse())
+	})
+
+	It("should process events for objects in all namespaces if the namespace is empty", func() {
+		p := controller.NewNamespacePredicate("")
+		Expect(p.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: otherObj})).To(BeTrue())
+	})
+})
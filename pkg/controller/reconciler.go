@@ -16,18 +16,50 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gardener/gardener/pkg/controllerutils"
 	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// EventReasonReconcileFailed is the reason of events emitted when reconciling an object fails.
+	EventReasonReconcileFailed = "ReconcileFailed"
+
+	// PausedAnnotation is an annotation that can be put on a reconciled object, or on its namespace,
+	// to pause its reconciliation. While paused, the actuator is not called and finalizers are left intact.
+	PausedAnnotation = "remedy.gardener.cloud/paused"
+	// PausedRequeueInterval is the interval after which paused objects are requeued to check if they are still paused.
+	PausedRequeueInterval = 1 * time.Minute
+
+	// ConditionTypePaused indicates whether the reconciliation of the object is paused.
+	ConditionTypePaused = "Paused"
+	// ConditionReasonPaused is the reason of the Paused condition if the reconciliation is paused.
+	ConditionReasonPaused = "Paused"
+	// ConditionReasonResumed is the reason of the Paused condition if the reconciliation has been resumed.
+	ConditionReasonResumed = "Resumed"
+)
+
+// ConditionsObject is an object that reports conditions in its status.
+type ConditionsObject interface {
+	client.Object
+	// GetConditions returns the conditions in the object status.
+	GetConditions() []metav1.Condition
+	// SetConditions sets the conditions in the object status.
+	SetConditions([]metav1.Condition)
+}
+
 type reconciler struct {
 	actuator            Actuator
 	controllerName      string
@@ -36,11 +68,12 @@ type reconciler struct {
 	shouldEnsureDeleted bool
 	client              client.Client
 	reader              client.Reader
+	recorder            record.EventRecorder
 	logger              logr.Logger
 }
 
 // NewReconciler creates a new generic Reconciler.
-func NewReconciler(actuator Actuator, controllerName, finalizerName string, typ client.Object, shouldEnsureDeleted bool, client client.Client, reader client.Reader, logger logr.Logger) reconcile.Reconciler {
+func NewReconciler(actuator Actuator, controllerName, finalizerName string, typ client.Object, shouldEnsureDeleted bool, client client.Client, reader client.Reader, recorder record.EventRecorder, logger logr.Logger) reconcile.Reconciler {
 	logger.Info("Creating reconciler", "controllerName", controllerName)
 	return &reconciler{
 		actuator:            actuator,
@@ -50,6 +83,7 @@ func NewReconciler(actuator Actuator, controllerName, finalizerName string, typ
 		shouldEnsureDeleted: shouldEnsureDeleted,
 		client:              client,
 		reader:              reader,
+		recorder:            recorder,
 		logger:              logger,
 	}
 }
@@ -63,6 +97,14 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err := r.client.Get(ctx, request.NamespacedName, obj); err != nil {
 		if apierrors.IsNotFound(err) {
 			if r.shouldEnsureDeleted {
+				paused, message, err := r.isNamespacePaused(ctx, obj.GetNamespace())
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				if paused {
+					logger.Info("Skipping reconciliation of paused object", "reason", message)
+					return reconcile.Result{RequeueAfter: PausedRequeueInterval}, nil
+				}
 				return r.ensureDeleted(ctx, obj, logger)
 			}
 			return reconcile.Result{}, nil
@@ -70,6 +112,18 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, errors.Wrap(err, "could not get object")
 	}
 
+	paused, message, err := r.isPaused(ctx, obj)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.updatePausedCondition(ctx, obj, paused, message); err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		logger.Info("Skipping reconciliation of paused object", "reason", message)
+		return reconcile.Result{RequeueAfter: PausedRequeueInterval}, nil
+	}
+
 	switch {
 	case obj.GetDeletionTimestamp() != nil:
 		return r.delete(ctx, obj, logger)
@@ -79,31 +133,36 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 }
 
 func (r *reconciler) createOrUpdate(ctx context.Context, obj client.Object, logger logr.Logger) (reconcile.Result, error) {
+	// If no finalizer name is specified, objects are reconciled without finalizers
+	usesFinalizer := r.finalizerName != ""
+
 	shouldFinalize, err := r.actuator.ShouldFinalize(ctx, obj)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "could not check if the object should be finalized")
 	}
-	if shouldFinalize {
-		if err := controllerutils.AddFinalizers(ctx, r.client, obj, r.finalizerName); err != nil {
-			if apierrors.IsNotFound(err) {
+	if usesFinalizer {
+		if shouldFinalize {
+			if err := controllerutils.AddFinalizers(ctx, r.client, obj, r.finalizerName); err != nil {
+				if apierrors.IsNotFound(err) {
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, errors.Wrap(err, "could not ensure finalizer")
+			}
+		} else {
+			if !controllerutil.ContainsFinalizer(obj, r.finalizerName) {
 				return reconcile.Result{}, nil
 			}
-			return reconcile.Result{}, errors.Wrap(err, "could not ensure finalizer")
-		}
-	} else {
-		if !controllerutil.ContainsFinalizer(obj, r.finalizerName) {
-			return reconcile.Result{}, nil
 		}
 	}
 
 	logger.Info("Reconciling object creation or update")
 	requeueAfter, err := r.actuator.CreateOrUpdate(ctx, obj)
 	if err != nil {
-		return reconcileErr(errors.Wrap(err, "could not reconcile object creation or update"))
+		return r.reconcileErr(obj, errors.Wrap(err, "could not reconcile object creation or update"))
 	}
 	logger.Info("Successfully reconciled object creation or update")
 
-	if !shouldFinalize {
+	if usesFinalizer && !shouldFinalize {
 		logger.Info("Removing finalizer")
 		if err := controllerutils.RemoveFinalizers(ctx, r.client, obj, r.finalizerName); client.IgnoreNotFound(err) != nil {
 			return reconcile.Result{}, errors.Wrap(err, "could not remove finalizer")
@@ -125,7 +184,7 @@ func (r *reconciler) delete(ctx context.Context, obj client.Object, logger logr.
 	logger.Info("Reconciling object deletion")
 	requeueAfter, err := r.actuator.Delete(ctx, obj)
 	if err != nil {
-		return reconcileErr(errors.Wrap(err, "could not reconcile object deletion"))
+		return r.reconcileErr(obj, errors.Wrap(err, "could not reconcile object deletion"))
 	}
 	logger.Info("Successfully reconciled object deletion")
 
@@ -150,6 +209,91 @@ func (r *reconciler) ensureDeleted(ctx context.Context, obj client.Object, logge
 	return reconcile.Result{}, nil
 }
 
+// isPaused checks if the reconciliation of the given object is paused, either by an annotation
+// on the object itself or on its namespace. If it is paused, a message describing why is also returned.
+func (r *reconciler) isPaused(ctx context.Context, obj client.Object) (bool, string, error) {
+	if hasPausedAnnotation(obj) {
+		return true, fmt.Sprintf("Reconciliation is paused by the %s annotation on the object", PausedAnnotation), nil
+	}
+	return r.isNamespacePaused(ctx, obj.GetNamespace())
+}
+
+// isNamespacePaused checks if the reconciliation of objects in the given namespace is paused by an annotation
+// on the namespace. If it is paused, a message describing why is also returned.
+func (r *reconciler) isNamespacePaused(ctx context.Context, namespace string) (bool, string, error) {
+	// Namespaces are read from the cache to avoid an additional API server request on every reconciliation
+	paused, err := isNamespacePaused(ctx, r.client, namespace)
+	if err != nil || !paused {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("Reconciliation is paused by the %s annotation on namespace %s", PausedAnnotation, namespace), nil
+}
+
+// updatePausedCondition updates the Paused condition of the given object, if it reports conditions in its status.
+// The condition is only added if the object is paused, and only updated afterwards.
+func (r *reconciler) updatePausedCondition(ctx context.Context, obj client.Object, paused bool, message string) error {
+	condObj, ok := obj.(ConditionsObject)
+	if !ok {
+		return nil
+	}
+
+	conditions := make([]metav1.Condition, len(condObj.GetConditions()))
+	copy(conditions, condObj.GetConditions())
+	condition := metav1.Condition{
+		Type:               ConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             ConditionReasonPaused,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	if !paused {
+		if meta.FindStatusCondition(conditions, ConditionTypePaused) == nil {
+			return nil
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ConditionReasonResumed
+		condition.Message = "Reconciliation has been resumed"
+	}
+	if !meta.SetStatusCondition(&conditions, condition) {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	condObj.SetConditions(conditions)
+	if err := r.client.Status().Patch(ctx, obj, patch); err != nil {
+		return errors.Wrap(err, "could not update paused condition")
+	}
+	return nil
+}
+
+func hasPausedAnnotation(obj client.Object) bool {
+	return obj.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// isNamespacePaused checks if the given namespace is annotated with the paused annotation.
+func isNamespacePaused(ctx context.Context, reader client.Reader, namespace string) (bool, error) {
+	if namespace == "" {
+		return false, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "could not get namespace")
+	}
+	return hasPausedAnnotation(ns), nil
+}
+
+// reconcileErr returns a reconcile.Result or an error, depending on whether the error is a
+// RequeueAfterError or not. If it's not, a warning event is emitted on the given object.
+func (r *reconciler) reconcileErr(obj client.Object, err error) (reconcile.Result, error) {
+	if _, ok := errors.Cause(err).(*controllererror.RequeueAfterError); !ok {
+		r.recorder.Event(obj, corev1.EventTypeWarning, EventReasonReconcileFailed, err.Error())
+	}
+	return reconcileErr(err)
+}
+
 // reconcileErr returns a reconcile.Result or an error, depending on whether the error is a
 // RequeueAfterError or not.
 func reconcileErr(err error) (reconcile.Result, error) {
@@ -28,12 +28,15 @@ import (
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	azurev1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/remedy-controller/pkg/controller"
 	mockclient "github.com/gardener/remedy-controller/pkg/mock/controller-runtime/client"
 	mockcontroller "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/controller"
@@ -71,10 +74,12 @@ var _ = Describe("Controller", func() {
 	var (
 		ctrl *gomock.Controller
 
-		a *mockcontroller.MockActuator
-		c *mockclient.MockClient
+		a  *mockcontroller.MockActuator
+		c  *mockclient.MockClient
+		sw *mockclient.MockStatusWriter
 
 		ctx        context.Context
+		recorder   *record.FakeRecorder
 		logger     logr.Logger
 		reconciler reconcile.Reconciler
 
@@ -85,6 +90,7 @@ var _ = Describe("Controller", func() {
 		objWithFinalizer                     client.Object
 		objWithDeletionTimestampAndFinalizer client.Object
 		notFoundError                        apierrors.APIStatus
+		namespaceAnnotations                 map[string]string
 	)
 
 	BeforeEach(func() {
@@ -92,10 +98,12 @@ var _ = Describe("Controller", func() {
 
 		a = mockcontroller.NewMockActuator(ctrl)
 		c = mockclient.NewMockClient(ctrl)
+		sw = mockclient.NewMockStatusWriter(ctrl)
 
 		ctx = context.TODO()
+		recorder = record.NewFakeRecorder(10)
 		logger = log.Log.WithName("test")
-		reconciler = controller.NewReconciler(a, "test-controller", "test-finalizer", &corev1.Pod{}, true, c, c, logger)
+		reconciler = controller.NewReconciler(a, "test-controller", "test-finalizer", &corev1.Pod{}, true, c, c, recorder, logger)
 
 		ts = metav1.Now()
 		request = reconcile.Request{
@@ -137,6 +145,12 @@ var _ = Describe("Controller", func() {
 				Reason: metav1.StatusReasonNotFound,
 			},
 		}
+		namespaceAnnotations = nil
+
+		c.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: namespace}, gomock.AssignableToTypeOf(&corev1.Namespace{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, ns *corev1.Namespace, _ ...client.GetOption) error {
+			ns.Annotations = namespaceAnnotations
+			return nil
+		}).AnyTimes()
 	})
 
 	AfterEach(func() {
@@ -184,6 +198,17 @@ var _ = Describe("Controller", func() {
 			Expect(result).To(Equal(reconcile.Result{}))
 		})
 
+		It("should create or update an object without a finalizer if no finalizer name is specified", func() {
+			reconciler = controller.NewReconciler(a, "test-controller", "", &corev1.Pod{}, true, c, c, recorder, logger)
+			c.EXPECT().Get(ctx, request.NamespacedName, obj).Return(nil)
+			a.EXPECT().ShouldFinalize(ctx, obj).Return(false, nil)
+			a.EXPECT().CreateOrUpdate(ctx, obj).Return(requeueAfter, nil)
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: requeueAfter}))
+		})
+
 		It("should delete an object that has a finalizer", func() {
 			c.EXPECT().Get(ctx, request.NamespacedName, obj).DoAndReturn(func(_ context.Context, _ client.ObjectKey, pod *corev1.Pod, _ ...client.GetOption) error {
 				pod.DeletionTimestamp = &ts
@@ -239,6 +264,7 @@ var _ = Describe("Controller", func() {
 
 			_, err := reconciler.Reconcile(ctx, request)
 			Expect(err).To(MatchError("could not reconcile object creation or update: test"))
+			Expect(recorder.Events).To(Receive(Equal("Warning ReconcileFailed could not reconcile object creation or update: test")))
 		})
 
 		It("should fail if the actuator fails to delete", func() {
@@ -251,6 +277,7 @@ var _ = Describe("Controller", func() {
 
 			_, err := reconciler.Reconcile(ctx, request)
 			Expect(err).To(MatchError("could not reconcile object deletion: test"))
+			Expect(recorder.Events).To(Receive(Equal("Warning ReconcileFailed could not reconcile object deletion: test")))
 		})
 
 		It("should fail if the actuator fails to delete when ensuring the deletion", func() {
@@ -260,5 +287,95 @@ var _ = Describe("Controller", func() {
 			_, err := reconciler.Reconcile(ctx, request)
 			Expect(err).To(MatchError("could not ensure object deletion: test"))
 		})
+
+		It("should not reconcile an object that is paused", func() {
+			c.EXPECT().Get(ctx, request.NamespacedName, obj).DoAndReturn(func(_ context.Context, _ client.ObjectKey, pod *corev1.Pod, _ ...client.GetOption) error {
+				pod.Annotations = map[string]string{controller.PausedAnnotation: "true"}
+				pod.Finalizers = []string{"test-finalizer"}
+				return nil
+			})
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: controller.PausedRequeueInterval}))
+		})
+
+		It("should not delete an object or remove its finalizer if its namespace is paused", func() {
+			namespaceAnnotations = map[string]string{controller.PausedAnnotation: "true"}
+			c.EXPECT().Get(ctx, request.NamespacedName, obj).DoAndReturn(func(_ context.Context, _ client.ObjectKey, pod *corev1.Pod, _ ...client.GetOption) error {
+				pod.DeletionTimestamp = &ts
+				pod.Finalizers = []string{"test-finalizer"}
+				return nil
+			})
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: controller.PausedRequeueInterval}))
+		})
+
+		It("should not ensure the deletion if the namespace is paused", func() {
+			namespaceAnnotations = map[string]string{controller.PausedAnnotation: "true"}
+			c.EXPECT().Get(ctx, request.NamespacedName, obj).Return(notFoundError)
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: controller.PausedRequeueInterval}))
+		})
+
+		Context("object with conditions", func() {
+			var pubip *azurev1alpha1.PublicIPAddress
+
+			BeforeEach(func() {
+				reconciler = controller.NewReconciler(a, "test-controller", "test-finalizer", &azurev1alpha1.PublicIPAddress{}, true, c, c, recorder, logger)
+				pubip = &azurev1alpha1.PublicIPAddress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  namespace,
+						Generation: 1,
+						Finalizers: []string{"test-finalizer"},
+					},
+				}
+			})
+
+			It("should report the paused state in the status", func() {
+				pubip.Annotations = map[string]string{controller.PausedAnnotation: "true"}
+				c.EXPECT().Get(ctx, request.NamespacedName, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddress{})).SetArg(2, *pubip).Return(nil)
+				c.EXPECT().Status().Return(sw)
+				sw.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddress{}), gomock.Any()).DoAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					cond := meta.FindStatusCondition(obj.(*azurev1alpha1.PublicIPAddress).Status.Conditions, controller.ConditionTypePaused)
+					Expect(cond).NotTo(BeNil())
+					Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+					Expect(cond.Reason).To(Equal(controller.ConditionReasonPaused))
+					Expect(cond.ObservedGeneration).To(Equal(int64(1)))
+					return nil
+				})
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: controller.PausedRequeueInterval}))
+			})
+
+			It("should report the resumed state in the status and reconcile the object", func() {
+				pubip.Status.Conditions = []metav1.Condition{
+					{Type: controller.ConditionTypePaused, Status: metav1.ConditionTrue, Reason: controller.ConditionReasonPaused, ObservedGeneration: 1},
+				}
+				c.EXPECT().Get(ctx, request.NamespacedName, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddress{})).SetArg(2, *pubip).Return(nil)
+				c.EXPECT().Status().Return(sw)
+				sw.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddress{}), gomock.Any()).DoAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					cond := meta.FindStatusCondition(obj.(*azurev1alpha1.PublicIPAddress).Status.Conditions, controller.ConditionTypePaused)
+					Expect(cond).NotTo(BeNil())
+					Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(controller.ConditionReasonResumed))
+					return nil
+				})
+				a.EXPECT().ShouldFinalize(ctx, gomock.Any()).Return(true, nil)
+				c.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&azurev1alpha1.PublicIPAddress{}), gomock.Any()).Return(nil)
+				a.EXPECT().CreateOrUpdate(ctx, gomock.Any()).Return(requeueAfter, nil)
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: requeueAfter}))
+			})
+		})
 	})
 })
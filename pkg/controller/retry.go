@@ -0,0 +1,124 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+// maxBackoffShift limits the exponent of the exponential backoff.
+const maxBackoffShift = 30
+
+// maxDuration is the max representable delay, used instead of delays that would overflow.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// RetryPolicy determines how a failed operation is retried.
+type RetryPolicy struct {
+	// BaseDelay is the delay before the first retry. Each subsequent retry is delayed twice as long as the previous one.
+	BaseDelay time.Duration
+	// MaxDelay is the max delay between retries. If zero, the delay is not capped.
+	MaxDelay time.Duration
+	// Jitter is the max fraction of the delay that is randomly added to it, e.g. 0.1 for up to 10%.
+	Jitter float64
+	// MaxAttempts is the max attempts of the operation.
+	MaxAttempts int
+	// CoolDown is the period after the last failure of the operation after which its attempts are reset.
+	// If zero, the attempts are never reset.
+	CoolDown time.Duration
+}
+
+// NewRetryPolicy creates a new RetryPolicy from the given configuration, using the given base delay and max attempts
+// if they are not specified in the configuration.
+func NewRetryPolicy(cfg *config.RetryPolicyConfiguration, baseDelay time.Duration, maxAttempts int) RetryPolicy {
+	policy := RetryPolicy{
+		BaseDelay:   baseDelay,
+		MaxAttempts: maxAttempts,
+	}
+	if cfg == nil {
+		return policy
+	}
+	if cfg.BaseDelay != nil {
+		policy.BaseDelay = cfg.BaseDelay.Duration
+	}
+	if cfg.MaxDelay != nil {
+		policy.MaxDelay = cfg.MaxDelay.Duration
+	}
+	if cfg.Jitter != nil {
+		policy.Jitter = *cfg.Jitter
+	}
+	if cfg.MaxAttempts != nil {
+		policy.MaxAttempts = *cfg.MaxAttempts
+	}
+	if cfg.CoolDown != nil {
+		policy.CoolDown = cfg.CoolDown.Duration
+	}
+	return policy
+}
+
+// Delay returns the delay before retrying an operation that has been attempted the given number of times.
+// The delay including jitter never exceeds the max delay, if specified, and never overflows.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	shift := min(max(attempts-1, 0), maxBackoffShift)
+	delay := maxDuration
+	if p.BaseDelay <= maxDuration>>shift {
+		delay = p.BaseDelay << shift
+	}
+	if p.Jitter > 0 {
+		jitter := rand.Float64() * p.Jitter * float64(delay) // #nosec G404 -- jitter doesn't need a secure random number
+		if jitter >= float64(maxDuration-delay) {
+			delay = maxDuration
+		} else {
+			delay += time.Duration(jitter)
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// ShouldRetry returns true if an operation that has been attempted the given number of times should be retried.
+func (p RetryPolicy) ShouldRetry(attempts int) bool {
+	return attempts < p.MaxAttempts
+}
+
+// IsExhausted returns true if an operation that has been attempted the given number of times has reached its max attempts.
+func (p RetryPolicy) IsExhausted(attempts int) bool {
+	return !p.ShouldRetry(attempts)
+}
+
+// CoolDownElapsed returns true if the cool-down has elapsed between the given last failure and now,
+// meaning that the attempts of the failed operation should be reset.
+func (p RetryPolicy) CoolDownElapsed(lastFailure, now time.Time) bool {
+	return p.CoolDown > 0 && !now.Before(lastFailure.Add(p.CoolDown))
+}
+
+// RequeueAfterError returns a RequeueAfterError with the given cause and the appropriate delay if an operation
+// that has been attempted the given number of times should be retried, or nil otherwise.
+func (p RetryPolicy) RequeueAfterError(cause error, attempts int) error {
+	if !p.ShouldRetry(attempts) {
+		return nil
+	}
+	return &controllererror.RequeueAfterError{
+		Cause:        cause,
+		RequeueAfter: p.Delay(attempts),
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	controllererror "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/controller"
+)
+
+var _ = Describe("RetryPolicy", func() {
+	Describe("#NewRetryPolicy", func() {
+		It("should use the given base delay and max attempts if there is no configuration", func() {
+			Expect(NewRetryPolicy(nil, time.Minute, 5)).To(Equal(RetryPolicy{
+				BaseDelay:   time.Minute,
+				MaxAttempts: 5,
+			}))
+		})
+
+		It("should use the values from the configuration if specified", func() {
+			Expect(NewRetryPolicy(&config.RetryPolicyConfiguration{
+				BaseDelay:   &metav1.Duration{Duration: 10 * time.Second},
+				MaxDelay:    &metav1.Duration{Duration: 5 * time.Minute},
+				Jitter:      ptr.To(0.1),
+				MaxAttempts: ptr.To(3),
+				CoolDown:    &metav1.Duration{Duration: time.Hour},
+			}, time.Minute, 5)).To(Equal(RetryPolicy{
+				BaseDelay:   10 * time.Second,
+				MaxDelay:    5 * time.Minute,
+				Jitter:      0.1,
+				MaxAttempts: 3,
+				CoolDown:    time.Hour,
+			}))
+		})
+	})
+
+	Describe("#Delay", func() {
+		It("should increase the delay exponentially", func() {
+			policy := RetryPolicy{BaseDelay: time.Second}
+			Expect(policy.Delay(1)).To(Equal(time.Second))
+			Expect(policy.Delay(2)).To(Equal(2 * time.Second))
+			Expect(policy.Delay(3)).To(Equal(4 * time.Second))
+		})
+
+		It("should cap the delay at the max delay", func() {
+			policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+			Expect(policy.Delay(2)).To(Equal(2 * time.Second))
+			Expect(policy.Delay(3)).To(Equal(3 * time.Second))
+			Expect(policy.Delay(100)).To(Equal(3 * time.Second))
+		})
+
+		It("should add jitter to the delay", func() {
+			policy := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+			for i := 0; i < 10; i++ {
+				Expect(policy.Delay(1)).To(BeNumerically(">=", time.Second))
+				Expect(policy.Delay(1)).To(BeNumerically("<=", 1500*time.Millisecond))
+			}
+		})
+
+		It("should not exceed the max delay with jitter", func() {
+			policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second, Jitter: 0.5}
+			for i := 0; i < 10; i++ {
+				Expect(policy.Delay(3)).To(Equal(3 * time.Second))
+			}
+		})
+
+		It("should not overflow if the max delay is not specified", func() {
+			policy := RetryPolicy{BaseDelay: time.Hour, Jitter: 0.5}
+			Expect(policy.Delay(31)).To(Equal(time.Duration(math.MaxInt64)))
+			Expect(policy.Delay(1000)).To(Equal(time.Duration(math.MaxInt64)))
+		})
+	})
+
+	Describe("#ShouldRetry", func() {
+		It("should return true only if the max attempts have not been reached", func() {
+			policy := RetryPolicy{MaxAttempts: 2}
+			Expect(policy.ShouldRetry(1)).To(BeTrue())
+			Expect(policy.ShouldRetry(2)).To(BeFalse())
+			Expect(policy.IsExhausted(2)).To(BeTrue())
+		})
+	})
+
+	Describe("#CoolDownElapsed", func() {
+		var now = time.Now()
+
+		It("should return true only if the cool-down has elapsed", func() {
+			policy := RetryPolicy{CoolDown: time.Hour}
+			Expect(policy.CoolDownElapsed(now.Add(-2*time.Hour), now)).To(BeTrue())
+			Expect(policy.CoolDownElapsed(now.Add(-time.Minute), now)).To(BeFalse())
+		})
+
+		It("should return false if there is no cool-down", func() {
+			Expect(RetryPolicy{}.CoolDownElapsed(now.Add(-2*time.Hour), now)).To(BeFalse())
+		})
+	})
+
+	Describe("#RequeueAfterError", func() {
+		It("should return a RequeueAfterError if the operation should be retried", func() {
+			policy := RetryPolicy{BaseDelay: time.Second, MaxAttempts: 3}
+			err := policy.RequeueAfterError(errors.New("test"), 2)
+			Expect(err).To(Equal(&controllererror.RequeueAfterError{Cause: errors.New("test"), RequeueAfter: 2 * time.Second}))
+		})
+
+		It("should return nil if the max attempts have been reached", func() {
+			policy := RetryPolicy{BaseDelay: time.Second, MaxAttempts: 3}
+			Expect(policy.RequeueAfterError(errors.New("test"), 3)).To(BeNil())
+		})
+	})
+})
@@ -16,25 +16,44 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// hashLength is the number of hex characters of the hash appended to shortened label values and names.
+	hashLength = 10
+)
+
+var (
+	invalidNameCharsRegexp = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
 // ObjectLabeler provides methods for creating a label value that uniquely identifies an object,
-// as well as creating a namespaced name from such a label value.
+// as well as creating a namespaced name from the full identity of an object.
+//
+// Since the full identity of an object may exceed the maximum length of a label value, it should be stored
+// in an annotation in addition to the label. The label value should only be used for selecting objects.
 type ObjectLabeler interface {
 	// GetLabelValue returns a label value that uniquely identifies the given object.
+	// If the identity of the object is not a valid label value, it is shortened and a hash of it is appended.
 	GetLabelValue(obj client.Object) string
-	// GetNamespacedName returns types.NamespacedName from the given label value.
-	GetNamespacedName(labelValue string) types.NamespacedName
+	// GetIdentity returns a value that contains the full identity of the given object.
+	GetIdentity(obj client.Object) string
+	// GetNamespacedName returns types.NamespacedName from the given identity.
+	GetNamespacedName(identity string) types.NamespacedName
 }
 
 // NewClusterObjectLabeler creates an ObjectLabeler that is appropriate for cluster objects.
-// It uses the object name as the label value.
+// It uses the object name as the identity.
 func NewClusterObjectLabeler() ObjectLabeler {
 	return &clusterObjectLabeler{}
 }
@@ -43,16 +62,21 @@ type clusterObjectLabeler struct{}
 
 // GetLabelValue returns a label value that uniquely identifies the given object.
 func (l *clusterObjectLabeler) GetLabelValue(obj client.Object) string {
+	return ShortenLabelValue(l.GetIdentity(obj))
+}
+
+// GetIdentity returns a value that contains the full identity of the given object.
+func (l *clusterObjectLabeler) GetIdentity(obj client.Object) string {
 	return obj.GetName()
 }
 
-// GetNamespacedName returns types.NamespacedName from the given label value.
-func (l *clusterObjectLabeler) GetNamespacedName(labelValue string) types.NamespacedName {
-	return types.NamespacedName{Name: labelValue}
+// GetNamespacedName returns types.NamespacedName from the given identity.
+func (l *clusterObjectLabeler) GetNamespacedName(identity string) types.NamespacedName {
+	return types.NamespacedName{Name: identity}
 }
 
 // NewNamespacedObjectLabeler creates an ObjectLabeler that is appropriate for namespaced objects.
-// It uses the object namespace and name separated by the given separator as the label value.
+// It uses the object namespace and name separated by the given separator as the identity.
 func NewNamespacedObjectLabeler(separator string) ObjectLabeler {
 	return &namespacedObjectLabeler{
 		separator: separator,
@@ -65,20 +89,69 @@ type namespacedObjectLabeler struct {
 
 // GetLabelValue returns a label value that uniquely identifies the given object.
 func (l *namespacedObjectLabeler) GetLabelValue(obj client.Object) string {
+	return ShortenLabelValue(l.GetIdentity(obj))
+}
+
+// GetIdentity returns a value that contains the full identity of the given object.
+func (l *namespacedObjectLabeler) GetIdentity(obj client.Object) string {
 	if obj.GetName() != "" {
 		return obj.GetNamespace() + l.separator + obj.GetName()
 	}
 	return ""
 }
 
-// GetNamespacedName returns types.NamespacedName from the given label value.
-func (l *namespacedObjectLabeler) GetNamespacedName(labelValue string) types.NamespacedName {
-	if parts := strings.Split(labelValue, l.separator); len(parts) == 2 {
+// GetNamespacedName returns types.NamespacedName from the given identity.
+func (l *namespacedObjectLabeler) GetNamespacedName(identity string) types.NamespacedName {
+	if parts := strings.Split(identity, l.separator); len(parts) == 2 {
 		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}
 	}
 	return types.NamespacedName{}
 }
 
+// GetIdentity returns the identity of the object the given object belongs to, as stored with the given key.
+// The identity is taken from the annotation with the given key. Objects created before the annotation was introduced
+// only have a label with the given key, which contains the full identity, so the label value is used as a fallback.
+func GetIdentity(obj client.Object, key string) string {
+	if identity := obj.GetAnnotations()[key]; identity != "" {
+		return identity
+	}
+	return obj.GetLabels()[key]
+}
+
+// ShortenLabelValue returns the given value if it is a valid label value. Otherwise, it returns a valid label value
+// consisting of a prefix of the given value and a hash of the whole value.
+func ShortenLabelValue(value string) string {
+	if len(validation.IsValidLabelValue(value)) == 0 {
+		return value
+	}
+	return shorten(value, validation.LabelValueMaxLength)
+}
+
+// ShortenName returns the given name if it is a valid object name. Otherwise, it returns a valid object name
+// consisting of a prefix of the given name and a hash of the whole name.
+func ShortenName(name string) string {
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	return shorten(name, validation.DNS1123SubdomainMaxLength)
+}
+
+func shorten(s string, maxLength int) string {
+	prefix := invalidNameCharsRegexp.ReplaceAllString(strings.ToLower(s), "-")
+	if len(prefix) > maxLength-hashLength-1 {
+		prefix = prefix[:maxLength-hashLength-1]
+	}
+	if prefix = strings.Trim(prefix, "-"); prefix == "" {
+		return hash(s)
+	}
+	return prefix + "-" + hash(s)
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
 // Mapper maps an object to the object key of a different object.
 type Mapper interface {
 	// Map maps the given object to the object key of a different object.
@@ -99,20 +172,21 @@ func MapFuncFromMapper(mapper Mapper) handler.MapFunc {
 	}
 }
 
-// NewLabelMapper creates a mapper that uses GetNamespacedName of the given ObjectLabeler with the given label.
-func NewLabelMapper(objectLabeler ObjectLabeler, label string) Mapper {
+// NewLabelMapper creates a mapper that uses GetNamespacedName of the given ObjectLabeler
+// with the identity stored with the given key, see GetIdentity.
+func NewLabelMapper(objectLabeler ObjectLabeler, key string) Mapper {
 	return &labelMapper{
 		objectLabeler: objectLabeler,
-		label:         label,
+		key:           key,
 	}
 }
 
 type labelMapper struct {
 	objectLabeler ObjectLabeler
-	label         string
+	key           string
 }
 
 // Map maps the given object to the object key of a different object.
 func (m *labelMapper) Map(obj client.Object) client.ObjectKey {
-	return m.objectLabeler.GetNamespacedName(obj.GetLabels()[m.label])
+	return m.objectLabeler.GetNamespacedName(GetIdentity(obj, m.key))
 }
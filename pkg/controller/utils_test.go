@@ -15,11 +15,14 @@
 package controller_test
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -30,6 +33,11 @@ const (
 	label = "test"
 )
 
+var (
+	longName     = strings.Repeat("a", 60)
+	longNodeName = strings.Repeat("node-", 20) + "0"
+)
+
 var _ = Describe("Utils", func() {
 	Describe("ClusterObjectLabeler", func() {
 		var (
@@ -46,6 +54,16 @@ var _ = Describe("Utils", func() {
 				Expect(labeler.GetLabelValue(obj)).To(Equal(labelValue))
 			},
 			Entry("node with name", node, name),
+			Entry("node with long name", &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: longNodeName}}, ShortenLabelValue(longNodeName)),
+			Entry("node without name", &corev1.Node{}, ""),
+		)
+
+		DescribeTable("#GetIdentity",
+			func(obj client.Object, identity string) {
+				Expect(labeler.GetIdentity(obj)).To(Equal(identity))
+			},
+			Entry("node with name", node, name),
+			Entry("node with long name", &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: longNodeName}}, longNodeName),
 			Entry("node without name", &corev1.Node{}, ""),
 		)
 
@@ -74,6 +92,16 @@ var _ = Describe("Utils", func() {
 				Expect(labeler.GetLabelValue(obj)).To(Equal(labelValue))
 			},
 			Entry("svc with name", svc, namespace+"."+name),
+			Entry("svc with long name", &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: longName}}, ShortenLabelValue(namespace+"."+longName)),
+			Entry("svc without name", &corev1.Service{}, ""),
+		)
+
+		DescribeTable("#GetIdentity",
+			func(obj client.Object, identity string) {
+				Expect(labeler.GetIdentity(obj)).To(Equal(identity))
+			},
+			Entry("svc with name", svc, namespace+"."+name),
+			Entry("svc with long name", &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: longName}}, namespace+"."+longName),
 			Entry("svc without name", &corev1.Service{}, ""),
 		)
 
@@ -86,6 +114,52 @@ var _ = Describe("Utils", func() {
 		)
 	})
 
+	DescribeTable("#GetIdentity",
+		func(obj client.Object, identity string) {
+			Expect(GetIdentity(obj, label)).To(Equal(identity))
+		},
+		Entry("annotated object", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{label: ShortenLabelValue(namespace + "." + longName)},
+			Annotations: map[string]string{label: namespace + "." + longName},
+		}}, namespace+"."+longName),
+		Entry("object with label only", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{label: namespace + "." + name},
+		}}, namespace+"."+name),
+		Entry("object without label or annotation", &corev1.Pod{}, ""),
+	)
+
+	Describe("#ShortenLabelValue", func() {
+		It("should return valid label values unchanged", func() {
+			Expect(ShortenLabelValue(namespace + "." + name)).To(Equal(namespace + "." + name))
+			Expect(ShortenLabelValue("")).To(Equal(""))
+		})
+
+		It("should shorten label values that are too long", func() {
+			shortened := ShortenLabelValue(namespace + "." + longName)
+			Expect(validation.IsValidLabelValue(shortened)).To(BeEmpty())
+			Expect(shortened).To(HavePrefix(namespace + "-aaa"))
+			Expect(shortened).NotTo(Equal(ShortenLabelValue(namespace + "." + longName + "b")))
+		})
+	})
+
+	Describe("#ShortenName", func() {
+		It("should return valid names unchanged", func() {
+			Expect(ShortenName(namespace + "-" + name + "-1.2.3.4")).To(Equal(namespace + "-" + name + "-1.2.3.4"))
+		})
+
+		It("should shorten names that are invalid", func() {
+			shortened := ShortenName(namespace + "-" + name + "-2001:db8::1")
+			Expect(validation.IsDNS1123Subdomain(shortened)).To(BeEmpty())
+			Expect(shortened).To(HavePrefix(namespace + "-" + name + "-2001-db8-1-"))
+			Expect(shortened).NotTo(Equal(ShortenName(namespace + "-" + name + "-2001:db8::2")))
+		})
+
+		It("should shorten names that are too long", func() {
+			shortened := ShortenName(strings.Repeat(longName+".", 5))
+			Expect(validation.IsDNS1123Subdomain(shortened)).To(BeEmpty())
+		})
+	})
+
 	Describe("LabelMapper", func() {
 		var (
 			mapper = NewLabelMapper(NewNamespacedObjectLabeler("."), label)
@@ -103,6 +177,10 @@ var _ = Describe("Utils", func() {
 				Expect(mapper.Map(obj)).To(Equal(objectKey))
 			},
 			Entry("labeled object", pod, client.ObjectKey{Namespace: namespace, Name: name}),
+			Entry("annotated object", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{label: ShortenLabelValue(namespace + "." + longName)},
+				Annotations: map[string]string{label: namespace + "." + longName},
+			}}, client.ObjectKey{Namespace: namespace, Name: longName}),
 			Entry("unlabeled object", &corev1.Pod{}, client.ObjectKey{}),
 		)
 
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/remedy-controller/pkg/client/azure (interfaces: Future,PublicIPAddressesClient,LoadBalancersClient,VirtualMachinesClient)
+// Source: github.com/gardener/remedy-controller/pkg/client/azure (interfaces: Poller,PublicIPAddressesClient,LoadBalancersClient,VirtualMachinesClient,ClientsProvider)
 //
 // Generated by this command:
 //
-//	mockgen -package azure -destination=mocks.go github.com/gardener/remedy-controller/pkg/client/azure Future,PublicIPAddressesClient,LoadBalancersClient,VirtualMachinesClient
+//	mockgen -package azure -destination=mocks.go github.com/gardener/remedy-controller/pkg/client/azure Poller,PublicIPAddressesClient,LoadBalancersClient,VirtualMachinesClient,ClientsProvider
 //
 
 // Package azure is a generated GoMock package.
@@ -11,51 +11,81 @@ package azure
 
 import (
 	context "context"
+	http "net/http"
 	reflect "reflect"
 
-	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
-	autorest "github.com/Azure/go-autorest/autorest"
+	runtime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	armcompute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	azure "github.com/gardener/remedy-controller/pkg/client/azure"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockFuture is a mock of Future interface.
-type MockFuture struct {
+// MockPoller is a mock of Poller interface.
+type MockPoller struct {
 	ctrl     *gomock.Controller
-	recorder *MockFutureMockRecorder
+	recorder *MockPollerMockRecorder
 	isgomock struct{}
 }
 
-// MockFutureMockRecorder is the mock recorder for MockFuture.
-type MockFutureMockRecorder struct {
-	mock *MockFuture
+// MockPollerMockRecorder is the mock recorder for MockPoller.
+type MockPollerMockRecorder struct {
+	mock *MockPoller
 }
 
-// NewMockFuture creates a new mock instance.
-func NewMockFuture(ctrl *gomock.Controller) *MockFuture {
-	mock := &MockFuture{ctrl: ctrl}
-	mock.recorder = &MockFutureMockRecorder{mock}
+// NewMockPoller creates a new mock instance.
+func NewMockPoller(ctrl *gomock.Controller) *MockPoller {
+	mock := &MockPoller{ctrl: ctrl}
+	mock.recorder = &MockPollerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockFuture) EXPECT() *MockFutureMockRecorder {
+func (m *MockPoller) EXPECT() *MockPollerMockRecorder {
 	return m.recorder
 }
 
-// WaitForCompletionRef mocks base method.
-func (m *MockFuture) WaitForCompletionRef(arg0 context.Context, arg1 autorest.Client) error {
+// Done mocks base method.
+func (m *MockPoller) Done() bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForCompletionRef", arg0, arg1)
+	ret := m.ctrl.Call(m, "Done")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Done indicates an expected call of Done.
+func (mr *MockPollerMockRecorder) Done() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockPoller)(nil).Done))
+}
+
+// Poll mocks base method.
+func (m *MockPoller) Poll(arg0 context.Context) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Poll", arg0)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Poll indicates an expected call of Poll.
+func (mr *MockPollerMockRecorder) Poll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Poll", reflect.TypeOf((*MockPoller)(nil).Poll), arg0)
+}
+
+// Result mocks base method.
+func (m *MockPoller) Result(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Result", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WaitForCompletionRef indicates an expected call of WaitForCompletionRef.
-func (mr *MockFutureMockRecorder) WaitForCompletionRef(arg0, arg1 any) *gomock.Call {
+// Result indicates an expected call of Result.
+func (mr *MockPollerMockRecorder) Result(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCompletionRef", reflect.TypeOf((*MockFuture)(nil).WaitForCompletionRef), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Result", reflect.TypeOf((*MockPoller)(nil).Result), arg0)
 }
 
 // MockPublicIPAddressesClient is a mock of PublicIPAddressesClient interface.
@@ -82,40 +112,26 @@ func (m *MockPublicIPAddressesClient) EXPECT() *MockPublicIPAddressesClientMockR
 	return m.recorder
 }
 
-// Client mocks base method.
-func (m *MockPublicIPAddressesClient) Client() autorest.Client {
+// BeginDelete mocks base method.
+func (m *MockPublicIPAddressesClient) BeginDelete(arg0 context.Context, arg1, arg2 string) (azure.Poller, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Client")
-	ret0, _ := ret[0].(autorest.Client)
-	return ret0
-}
-
-// Client indicates an expected call of Client.
-func (mr *MockPublicIPAddressesClientMockRecorder) Client() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).Client))
-}
-
-// Delete mocks base method.
-func (m *MockPublicIPAddressesClient) Delete(arg0 context.Context, arg1, arg2 string) (azure.Future, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
-	ret0, _ := ret[0].(azure.Future)
+	ret := m.ctrl.Call(m, "BeginDelete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azure.Poller)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockPublicIPAddressesClientMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
+// BeginDelete indicates an expected call of BeginDelete.
+func (mr *MockPublicIPAddressesClientMockRecorder) BeginDelete(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).Delete), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginDelete", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).BeginDelete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockPublicIPAddressesClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.PublicIPAddress, error) {
+func (m *MockPublicIPAddressesClient) Get(arg0 context.Context, arg1, arg2 string, arg3 *armnetwork.PublicIPAddressesClientGetOptions) (armnetwork.PublicIPAddressesClientGetResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(network.PublicIPAddress)
+	ret0, _ := ret[0].(armnetwork.PublicIPAddressesClientGetResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -126,19 +142,18 @@ func (mr *MockPublicIPAddressesClientMockRecorder) Get(arg0, arg1, arg2, arg3 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
-// List mocks base method.
-func (m *MockPublicIPAddressesClient) List(arg0 context.Context, arg1 string) (network.PublicIPAddressListResultPage, error) {
+// NewListPager mocks base method.
+func (m *MockPublicIPAddressesClient) NewListPager(arg0 string, arg1 *armnetwork.PublicIPAddressesClientListOptions) *runtime.Pager[armnetwork.PublicIPAddressesClientListResponse] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].(network.PublicIPAddressListResultPage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "NewListPager", arg0, arg1)
+	ret0, _ := ret[0].(*runtime.Pager[armnetwork.PublicIPAddressesClientListResponse])
+	return ret0
 }
 
-// List indicates an expected call of List.
-func (mr *MockPublicIPAddressesClientMockRecorder) List(arg0, arg1 any) *gomock.Call {
+// NewListPager indicates an expected call of NewListPager.
+func (mr *MockPublicIPAddressesClientMockRecorder) NewListPager(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).List), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewListPager", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).NewListPager), arg0, arg1)
 }
 
 // MockLoadBalancersClient is a mock of LoadBalancersClient interface.
@@ -165,40 +180,26 @@ func (m *MockLoadBalancersClient) EXPECT() *MockLoadBalancersClientMockRecorder
 	return m.recorder
 }
 
-// Client mocks base method.
-func (m *MockLoadBalancersClient) Client() autorest.Client {
+// BeginCreateOrUpdate mocks base method.
+func (m *MockLoadBalancersClient) BeginCreateOrUpdate(arg0 context.Context, arg1, arg2 string, arg3 armnetwork.LoadBalancer) (azure.Poller, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Client")
-	ret0, _ := ret[0].(autorest.Client)
-	return ret0
-}
-
-// Client indicates an expected call of Client.
-func (mr *MockLoadBalancersClientMockRecorder) Client() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockLoadBalancersClient)(nil).Client))
-}
-
-// CreateOrUpdate mocks base method.
-func (m *MockLoadBalancersClient) CreateOrUpdate(arg0 context.Context, arg1, arg2 string, arg3 network.LoadBalancer) (azure.Future, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(azure.Future)
+	ret := m.ctrl.Call(m, "BeginCreateOrUpdate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(azure.Poller)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrUpdate indicates an expected call of CreateOrUpdate.
-func (mr *MockLoadBalancersClientMockRecorder) CreateOrUpdate(arg0, arg1, arg2, arg3 any) *gomock.Call {
+// BeginCreateOrUpdate indicates an expected call of BeginCreateOrUpdate.
+func (mr *MockLoadBalancersClientMockRecorder) BeginCreateOrUpdate(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockLoadBalancersClient)(nil).CreateOrUpdate), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginCreateOrUpdate", reflect.TypeOf((*MockLoadBalancersClient)(nil).BeginCreateOrUpdate), arg0, arg1, arg2, arg3)
 }
 
 // Get mocks base method.
-func (m *MockLoadBalancersClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.LoadBalancer, error) {
+func (m *MockLoadBalancersClient) Get(arg0 context.Context, arg1, arg2 string, arg3 *armnetwork.LoadBalancersClientGetOptions) (armnetwork.LoadBalancersClientGetResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(network.LoadBalancer)
+	ret0, _ := ret[0].(armnetwork.LoadBalancersClientGetResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -233,25 +234,26 @@ func (m *MockVirtualMachinesClient) EXPECT() *MockVirtualMachinesClientMockRecor
 	return m.recorder
 }
 
-// Client mocks base method.
-func (m *MockVirtualMachinesClient) Client() autorest.Client {
+// BeginReapply mocks base method.
+func (m *MockVirtualMachinesClient) BeginReapply(arg0 context.Context, arg1, arg2 string) (azure.Poller, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Client")
-	ret0, _ := ret[0].(autorest.Client)
-	return ret0
+	ret := m.ctrl.Call(m, "BeginReapply", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azure.Poller)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Client indicates an expected call of Client.
-func (mr *MockVirtualMachinesClientMockRecorder) Client() *gomock.Call {
+// BeginReapply indicates an expected call of BeginReapply.
+func (mr *MockVirtualMachinesClientMockRecorder) BeginReapply(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockVirtualMachinesClient)(nil).Client))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginReapply", reflect.TypeOf((*MockVirtualMachinesClient)(nil).BeginReapply), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockVirtualMachinesClient) Get(arg0 context.Context, arg1, arg2 string, arg3 compute.InstanceViewTypes) (compute.VirtualMachine, error) {
+func (m *MockVirtualMachinesClient) Get(arg0 context.Context, arg1, arg2 string, arg3 *armcompute.VirtualMachinesClientGetOptions) (armcompute.VirtualMachinesClientGetResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(compute.VirtualMachine)
+	ret0, _ := ret[0].(armcompute.VirtualMachinesClientGetResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -262,17 +264,40 @@ func (mr *MockVirtualMachinesClientMockRecorder) Get(arg0, arg1, arg2, arg3 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVirtualMachinesClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
-// Reapply mocks base method.
-func (m *MockVirtualMachinesClient) Reapply(arg0 context.Context, arg1, arg2 string) (azure.Future, error) {
+// MockClientsProvider is a mock of ClientsProvider interface.
+type MockClientsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientsProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockClientsProviderMockRecorder is the mock recorder for MockClientsProvider.
+type MockClientsProviderMockRecorder struct {
+	mock *MockClientsProvider
+}
+
+// NewMockClientsProvider creates a new mock instance.
+func NewMockClientsProvider(ctrl *gomock.Controller) *MockClientsProvider {
+	mock := &MockClientsProvider{ctrl: ctrl}
+	mock.recorder = &MockClientsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientsProvider) EXPECT() *MockClientsProviderMockRecorder {
+	return m.recorder
+}
+
+// Clients mocks base method.
+func (m *MockClientsProvider) Clients() *azure.Clients {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Reapply", arg0, arg1, arg2)
-	ret0, _ := ret[0].(azure.Future)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Clients")
+	ret0, _ := ret[0].(*azure.Clients)
+	return ret0
 }
 
-// Reapply indicates an expected call of Reapply.
-func (mr *MockVirtualMachinesClientMockRecorder) Reapply(arg0, arg1, arg2 any) *gomock.Call {
+// Clients indicates an expected call of Clients.
+func (mr *MockClientsProviderMockRecorder) Clients() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reapply", reflect.TypeOf((*MockVirtualMachinesClient)(nil).Reapply), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clients", reflect.TypeOf((*MockClientsProvider)(nil).Clients))
 }
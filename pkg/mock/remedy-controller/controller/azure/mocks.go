@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gardener/remedy-controller/pkg/controller/azure (interfaces: RemedyActionRecorder,DestructiveActionBudget)
+//
+// Generated by this command:
+//
+//	mockgen -package azure -destination=mocks.go github.com/gardener/remedy-controller/pkg/controller/azure RemedyActionRecorder,DestructiveActionBudget
+//
+
+// Package azure is a generated GoMock package.
+package azure
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	v1alpha1 "github.com/gardener/remedy-controller/pkg/apis/azure/v1alpha1"
+	gomock "go.uber.org/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockRemedyActionRecorder is a mock of RemedyActionRecorder interface.
+type MockRemedyActionRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemedyActionRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRemedyActionRecorderMockRecorder is the mock recorder for MockRemedyActionRecorder.
+type MockRemedyActionRecorderMockRecorder struct {
+	mock *MockRemedyActionRecorder
+}
+
+// NewMockRemedyActionRecorder creates a new mock instance.
+func NewMockRemedyActionRecorder(ctrl *gomock.Controller) *MockRemedyActionRecorder {
+	mock := &MockRemedyActionRecorder{ctrl: ctrl}
+	mock.recorder = &MockRemedyActionRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemedyActionRecorder) EXPECT() *MockRemedyActionRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRemedyActionRecorder) Record(ctx context.Context, triggeredBy client.Object, actionType v1alpha1.RemedyActionType, azureResourceID string, action func() error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, triggeredBy, actionType, azureResourceID, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRemedyActionRecorderMockRecorder) Record(ctx, triggeredBy, actionType, azureResourceID, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRemedyActionRecorder)(nil).Record), ctx, triggeredBy, actionType, azureResourceID, action)
+}
+
+// MockDestructiveActionBudget is a mock of DestructiveActionBudget interface.
+type MockDestructiveActionBudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestructiveActionBudgetMockRecorder
+	isgomock struct{}
+}
+
+// MockDestructiveActionBudgetMockRecorder is the mock recorder for MockDestructiveActionBudget.
+type MockDestructiveActionBudgetMockRecorder struct {
+	mock *MockDestructiveActionBudget
+}
+
+// NewMockDestructiveActionBudget creates a new mock instance.
+func NewMockDestructiveActionBudget(ctrl *gomock.Controller) *MockDestructiveActionBudget {
+	mock := &MockDestructiveActionBudget{ctrl: ctrl}
+	mock.recorder = &MockDestructiveActionBudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestructiveActionBudget) EXPECT() *MockDestructiveActionBudgetMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockDestructiveActionBudget) Acquire(actionType v1alpha1.RemedyActionType) (bool, time.Duration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", actionType)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockDestructiveActionBudgetMockRecorder) Acquire(actionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockDestructiveActionBudget)(nil).Acquire), actionType)
+}
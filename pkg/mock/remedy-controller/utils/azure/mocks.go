@@ -13,8 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
-	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
+	armcompute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -57,10 +57,10 @@ func (mr *MockPublicIPAddressUtilsMockRecorder) Delete(ctx, name any) *gomock.Ca
 }
 
 // GetAll mocks base method.
-func (m *MockPublicIPAddressUtils) GetAll(ctx context.Context) ([]network.PublicIPAddress, error) {
+func (m *MockPublicIPAddressUtils) GetAll(ctx context.Context) ([]*armnetwork.PublicIPAddress, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]network.PublicIPAddress)
+	ret0, _ := ret[0].([]*armnetwork.PublicIPAddress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -72,10 +72,10 @@ func (mr *MockPublicIPAddressUtilsMockRecorder) GetAll(ctx any) *gomock.Call {
 }
 
 // GetByIP mocks base method.
-func (m *MockPublicIPAddressUtils) GetByIP(ctx context.Context, ip string) (*network.PublicIPAddress, error) {
+func (m *MockPublicIPAddressUtils) GetByIP(ctx context.Context, ip string) (*armnetwork.PublicIPAddress, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByIP", ctx, ip)
-	ret0, _ := ret[0].(*network.PublicIPAddress)
+	ret0, _ := ret[0].(*armnetwork.PublicIPAddress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -87,10 +87,10 @@ func (mr *MockPublicIPAddressUtilsMockRecorder) GetByIP(ctx, ip any) *gomock.Cal
 }
 
 // GetByName mocks base method.
-func (m *MockPublicIPAddressUtils) GetByName(ctx context.Context, name string) (*network.PublicIPAddress, error) {
+func (m *MockPublicIPAddressUtils) GetByName(ctx context.Context, name string) (*armnetwork.PublicIPAddress, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByName", ctx, name)
-	ret0, _ := ret[0].(*network.PublicIPAddress)
+	ret0, _ := ret[0].(*armnetwork.PublicIPAddress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -140,10 +140,10 @@ func (m *MockVirtualMachineUtils) EXPECT() *MockVirtualMachineUtilsMockRecorder
 }
 
 // Get mocks base method.
-func (m *MockVirtualMachineUtils) Get(ctx context.Context, name string) (*compute.VirtualMachine, error) {
+func (m *MockVirtualMachineUtils) Get(ctx context.Context, name string) (*armcompute.VirtualMachine, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, name)
-	ret0, _ := ret[0].(*compute.VirtualMachine)
+	ret0, _ := ret[0].(*armcompute.VirtualMachine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
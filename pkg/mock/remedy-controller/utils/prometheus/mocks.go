@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/remedy-controller/pkg/utils/prometheus (interfaces: GaugeVec)
+// Source: github.com/gardener/remedy-controller/pkg/utils/prometheus (interfaces: GaugeVec,CounterVec)
 //
 // Generated by this command:
 //
-//	mockgen -package prometheus -destination=mocks.go github.com/gardener/remedy-controller/pkg/utils/prometheus GaugeVec
+//	mockgen -package prometheus -destination=mocks.go github.com/gardener/remedy-controller/pkg/utils/prometheus GaugeVec,CounterVec
 //
 
 // Package prometheus is a generated GoMock package.
@@ -75,3 +75,45 @@ func (mr *MockGaugeVecMockRecorder) WithLabelValues(lvs ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLabelValues", reflect.TypeOf((*MockGaugeVec)(nil).WithLabelValues), lvs...)
 }
+
+// MockCounterVec is a mock of CounterVec interface.
+type MockCounterVec struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterVecMockRecorder
+	isgomock struct{}
+}
+
+// MockCounterVecMockRecorder is the mock recorder for MockCounterVec.
+type MockCounterVecMockRecorder struct {
+	mock *MockCounterVec
+}
+
+// NewMockCounterVec creates a new mock instance.
+func NewMockCounterVec(ctrl *gomock.Controller) *MockCounterVec {
+	mock := &MockCounterVec{ctrl: ctrl}
+	mock.recorder = &MockCounterVecMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterVec) EXPECT() *MockCounterVecMockRecorder {
+	return m.recorder
+}
+
+// WithLabelValues mocks base method.
+func (m *MockCounterVec) WithLabelValues(lvs ...string) prometheus.Counter {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range lvs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithLabelValues", varargs...)
+	ret0, _ := ret[0].(prometheus.Counter)
+	return ret0
+}
+
+// WithLabelValues indicates an expected call of WithLabelValues.
+func (mr *MockCounterVecMockRecorder) WithLabelValues(lvs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLabelValues", reflect.TypeOf((*MockCounterVec)(nil).WithLabelValues), lvs...)
+}
@@ -19,21 +19,23 @@ import (
 	"strings"
 	"time"
 
-	aznetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/gardener/remedy-controller/pkg/utils"
 	"github.com/gardener/remedy-controller/pkg/utils/azure"
 )
 
 // CleanPublicIps detects and cleans Azure orphan public ips.
-func CleanPublicIps(ctx context.Context, k8sClientSet *kubernetes.Clientset, pubipUtils azure.PublicIPAddressUtils, shootName string) {
+// Cleaning is refused if the given anomaly guard considers the number of orphan public ips anomalous.
+func CleanPublicIps(ctx context.Context, k8sClientSet *kubernetes.Clientset, pubipUtils azure.PublicIPAddressUtils, shootName string, anomalyGuard utils.AnomalyGuard) {
 	var retry int
 	for {
-		if err := clean(ctx, k8sClientSet, pubipUtils, shootName); err != nil {
+		if err := clean(ctx, k8sClientSet, pubipUtils, shootName, anomalyGuard); err != nil {
 			log.Error(err.Error())
 			time.Sleep(backoff(retry))
 			retry++
@@ -50,7 +52,7 @@ func CleanPublicIps(ctx context.Context, k8sClientSet *kubernetes.Clientset, pub
 	}
 }
 
-func clean(ctx context.Context, k8sClientSet *kubernetes.Clientset, pubipUtils azure.PublicIPAddressUtils, shootName string) error {
+func clean(ctx context.Context, k8sClientSet *kubernetes.Clientset, pubipUtils azure.PublicIPAddressUtils, shootName string, anomalyGuard utils.AnomalyGuard) error {
 	// Determine the ips known to Kubernetes
 	k8sIps, err := getKnownK8sIps(ctx, k8sClientSet)
 	if err != nil {
@@ -70,6 +72,11 @@ func clean(ctx context.Context, k8sClientSet *kubernetes.Clientset, pubipUtils a
 	log.Infof("Count orphan public IPs: %d", len(orphanIps))
 	log.Infof("Count known public IPs: %d", len(knownIps))
 
+	// Refuse to clean if the number of orphan public ips is anomalous, e.g. because the kubeconfig points to the wrong cluster
+	if err := anomalyGuard.Check(len(orphanIps), len(knownIps)); err != nil {
+		return errors.Wrap(err, "refusing to clean orphan public IPs, please check that the kubeconfig points to the right cluster")
+	}
+
 	// Collect the ids of the orphan public ips
 	var orphanIpIds []string
 	for _, ip := range orphanIps {
@@ -110,15 +117,15 @@ func getKnownK8sIps(ctx context.Context, k8sClientSet *kubernetes.Clientset) ([]
 	return ips, nil
 }
 
-func separateOrphanAndKnownIps(k8sIps []string, azIps []aznetwork.PublicIPAddress, shootName string) ([]aznetwork.PublicIPAddress, []aznetwork.PublicIPAddress) {
-	var orphanIps, knownIps []aznetwork.PublicIPAddress
+func separateOrphanAndKnownIps(k8sIps []string, azIps []*armnetwork.PublicIPAddress, shootName string) ([]*armnetwork.PublicIPAddress, []*armnetwork.PublicIPAddress) {
+	var orphanIps, knownIps []*armnetwork.PublicIPAddress
 	for _, azip := range azIps {
 		if !strings.Contains(*azip.Name, shootName) {
 			continue
 		}
 
 		// If an ip resource has no ip assigned then it is invalid and orphan
-		if azip.IPAddress == nil {
+		if azip.Properties == nil || azip.Properties.IPAddress == nil {
 			log.Debugf("Found orphan public IP: %s", *azip.Name)
 			orphanIps = append(orphanIps, azip)
 			continue
@@ -127,7 +134,7 @@ func separateOrphanAndKnownIps(k8sIps []string, azIps []aznetwork.PublicIPAddres
 		// Check if the public ip is also known by Kubernetes
 		foundKnownIP := false
 		for _, k8sip := range k8sIps {
-			if *azip.IPAddress == k8sip {
+			if *azip.Properties.IPAddress == k8sip {
 				log.Debugf("Found known public IP: %s", *azip.Name)
 				knownIps = append(knownIps, azip)
 				foundKnownIP = true
@@ -0,0 +1,74 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+const (
+	// DefaultMaxOrphanRatio is the default max ratio of orphaned to known resources.
+	DefaultMaxOrphanRatio = 1.0
+	// DefaultMinOrphans is the default min number of orphaned resources for which the ratio is checked.
+	DefaultMinOrphans = 3
+)
+
+// AnomalyGuard determines if cleaning orphaned resources should be refused as anomalous, e.g. because the
+// target cluster appears to be empty or the wrong cluster, and therefore almost all resources appear to be orphaned.
+type AnomalyGuard struct {
+	// Disabled specifies that cleaning is never refused.
+	Disabled bool
+	// MaxOrphanRatio is the max ratio of orphaned to known resources.
+	MaxOrphanRatio float64
+	// MinOrphans is the min number of orphaned resources for which the ratio is checked.
+	MinOrphans int
+}
+
+// NewAnomalyGuard creates a new AnomalyGuard from the given configuration, using the default values
+// if they are not specified in the configuration.
+func NewAnomalyGuard(cfg *config.AnomalyGuardConfiguration) AnomalyGuard {
+	guard := AnomalyGuard{
+		MaxOrphanRatio: DefaultMaxOrphanRatio,
+		MinOrphans:     DefaultMinOrphans,
+	}
+	if cfg == nil {
+		return guard
+	}
+	guard.Disabled = cfg.Disabled
+	if cfg.MaxOrphanRatio != nil {
+		guard.MaxOrphanRatio = *cfg.MaxOrphanRatio
+	}
+	if cfg.MinOrphans != nil {
+		guard.MinOrphans = *cfg.MinOrphans
+	}
+	return guard
+}
+
+// Check returns an error describing the anomaly if cleaning the given number of orphaned resources should be refused,
+// given the number of known resources, or nil otherwise.
+func (g AnomalyGuard) Check(orphans, known int) error {
+	if g.Disabled || orphans == 0 || orphans < g.MinOrphans {
+		return nil
+	}
+	if known == 0 {
+		return errors.Errorf("all %d resources appear to be orphaned, the target cluster appears to be empty", orphans)
+	}
+	if ratio := float64(orphans) / float64(known); ratio > g.MaxOrphanRatio {
+		return errors.Errorf("ratio of orphaned to known resources %d/%d exceeds the max ratio %.2f", orphans, known, g.MaxOrphanRatio)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("AnomalyGuard", func() {
+	Describe("#NewAnomalyGuard", func() {
+		It("should use the default values if there is no configuration", func() {
+			Expect(NewAnomalyGuard(nil)).To(Equal(AnomalyGuard{
+				MaxOrphanRatio: DefaultMaxOrphanRatio,
+				MinOrphans:     DefaultMinOrphans,
+			}))
+		})
+
+		It("should use the values from the configuration if specified", func() {
+			Expect(NewAnomalyGuard(&config.AnomalyGuardConfiguration{
+				Disabled:       true,
+				MaxOrphanRatio: ptr.To(0.5),
+				MinOrphans:     ptr.To(10),
+			})).To(Equal(AnomalyGuard{
+				Disabled:       true,
+				MaxOrphanRatio: 0.5,
+				MinOrphans:     10,
+			}))
+		})
+	})
+
+	Describe("#Check", func() {
+		var guard = AnomalyGuard{MaxOrphanRatio: 1, MinOrphans: 3}
+
+		It("should not refuse if the ratio is not exceeded", func() {
+			Expect(guard.Check(0, 0)).To(Succeed())
+			Expect(guard.Check(3, 3)).To(Succeed())
+			Expect(guard.Check(5, 10)).To(Succeed())
+		})
+
+		It("should not refuse if there are fewer orphans than the min", func() {
+			Expect(guard.Check(2, 1)).To(Succeed())
+		})
+
+		It("should refuse if the target cluster appears to be empty", func() {
+			Expect(guard.Check(3, 0)).To(MatchError("all 3 resources appear to be orphaned, the target cluster appears to be empty"))
+		})
+
+		It("should not refuse if the target cluster appears to be empty but there are fewer orphans than the min", func() {
+			Expect(guard.Check(1, 0)).To(Succeed())
+			Expect(guard.Check(2, 0)).To(Succeed())
+		})
+
+		It("should refuse if the ratio is exceeded", func() {
+			Expect(guard.Check(4, 3)).To(MatchError("ratio of orphaned to known resources 4/3 exceeds the max ratio 1.00"))
+		})
+
+		It("should not refuse if disabled", func() {
+			guard := AnomalyGuard{Disabled: true, MaxOrphanRatio: 1, MinOrphans: 3}
+			Expect(guard.Check(3, 0)).To(Succeed())
+			Expect(guard.Check(4, 3)).To(Succeed())
+		})
+	})
+})
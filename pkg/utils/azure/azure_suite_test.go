@@ -15,13 +15,57 @@
 package azure_test
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
 )
 
 func TestAzure(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Azure Suite")
 }
+
+// newPublicIPAddressListPager returns a pager over the given pages of PublicIPAddresses.
+// If the given error is not nil, getting the page after the last one fails with it.
+func newPublicIPAddressListPager(err error, pages ...[]*armnetwork.PublicIPAddress) *runtime.Pager[armnetwork.PublicIPAddressesClientListResponse] {
+	var index int
+	return runtime.NewPager(runtime.PagingHandler[armnetwork.PublicIPAddressesClientListResponse]{
+		More: func(page armnetwork.PublicIPAddressesClientListResponse) bool {
+			return page.NextLink != nil
+		},
+		Fetcher: func(_ context.Context, _ *armnetwork.PublicIPAddressesClientListResponse) (armnetwork.PublicIPAddressesClientListResponse, error) {
+			if index == len(pages) {
+				return armnetwork.PublicIPAddressesClientListResponse{}, err
+			}
+			page := armnetwork.PublicIPAddressesClientListResponse{
+				PublicIPAddressListResult: armnetwork.PublicIPAddressListResult{Value: pages[index]},
+			}
+			index++
+			if index < len(pages) || err != nil {
+				page.NextLink = ptr.To("next")
+			}
+			return page, nil
+		},
+	})
+}
+
+// newResponseError returns an error as returned by an Azure request that failed with the given status code, header, and error code.
+func newResponseError(statusCode int, header http.Header, code string) error {
+	if header == nil {
+		header = http.Header{}
+	}
+	return runtime.NewResponseError(&http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"` + code + `","message":"test"}}`)),
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gardener/remedy-controller/pkg/client/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
+)
+
+const (
+	// DefaultConnectivityCheckCacheTTL is the default period for which the result of a connectivity check is cached.
+	DefaultConnectivityCheckCacheTTL = 1 * time.Minute
+	// DefaultConnectivityCheckTimeout is the default timeout for the read request performed by a connectivity check.
+	DefaultConnectivityCheckTimeout = 10 * time.Second
+)
+
+// ConnectivityChecker checks whether Azure can be reached with the configured credentials
+// by performing a cheap authenticated read request. The result is cached for a certain period
+// so that frequent probes don't result in excessive Azure API requests.
+type ConnectivityChecker struct {
+	azureClients        azure.ClientsProvider
+	resourceGroup       string
+	readRequestsCounter prometheus.Counter
+	timestamper         utils.Timestamper
+	cacheTTL            time.Duration
+	timeout             time.Duration
+
+	mutex     sync.Mutex
+	checkedAt *time.Time
+	lastErr   error
+}
+
+// NewConnectivityChecker creates a new ConnectivityChecker.
+func NewConnectivityChecker(
+	azureClients azure.ClientsProvider,
+	resourceGroup string,
+	readRequestsCounter prometheus.Counter,
+	timestamper utils.Timestamper,
+	cacheTTL time.Duration,
+	timeout time.Duration,
+) *ConnectivityChecker {
+	return &ConnectivityChecker{
+		azureClients:        azureClients,
+		resourceGroup:       resourceGroup,
+		readRequestsCounter: readRequestsCounter,
+		timestamper:         timestamper,
+		cacheTTL:            cacheTTL,
+		timeout:             timeout,
+	}
+}
+
+// Check returns the result of the last connectivity check if it is not older than the cache TTL,
+// otherwise it performs a new check. It can be used as a healthz.Checker.
+func (c *ConnectivityChecker) Check(req *http.Request) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.timestamper.Now().Time
+	if c.checkedAt != nil && now.Sub(*c.checkedAt) < c.cacheTTL {
+		return c.lastErr
+	}
+
+	c.lastErr = c.check(req.Context())
+	c.checkedAt = &now
+	return c.lastErr
+}
+
+func (c *ConnectivityChecker) check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Only the first page of PublicIPAddresses is requested, which is sufficient to verify
+	// that the credentials are valid and the Azure API is reachable
+	c.readRequestsCounter.Inc()
+	pager := c.azureClients.Clients().PublicIPAddressesClient.NewListPager(c.resourceGroup, nil)
+	if _, err := pager.NextPage(ctx); err != nil {
+		return wrapError(err, "could not list Azure PublicIPAddresses")
+	}
+	return nil
+}
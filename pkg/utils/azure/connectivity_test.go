@@ -0,0 +1,108 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clientazure "github.com/gardener/remedy-controller/pkg/client/azure"
+	mockprometheus "github.com/gardener/remedy-controller/pkg/mock/prometheus"
+	mockclientazure "github.com/gardener/remedy-controller/pkg/mock/remedy-controller/client/azure"
+	"github.com/gardener/remedy-controller/pkg/utils"
+	"github.com/gardener/remedy-controller/pkg/utils/azure"
+)
+
+var _ = Describe("ConnectivityChecker", func() {
+	const (
+		resourceGroup = "shoot--dev--test"
+		cacheTTL      = 1 * time.Minute
+	)
+
+	var (
+		ctrl *gomock.Controller
+
+		publicIPAddressesClient *mockclientazure.MockPublicIPAddressesClient
+		readRequestsCounter     *mockprometheus.MockCounter
+
+		now     time.Time
+		req     *http.Request
+		checker *azure.ConnectivityChecker
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		publicIPAddressesClient = mockclientazure.NewMockPublicIPAddressesClient(ctrl)
+		readRequestsCounter = mockprometheus.NewMockCounter(ctrl)
+
+		now = time.Now()
+		req = (&http.Request{}).WithContext(context.TODO())
+		checker = azure.NewConnectivityChecker(
+			&clientazure.Clients{PublicIPAddressesClient: publicIPAddressesClient},
+			resourceGroup,
+			readRequestsCounter,
+			utils.TimestamperFunc(func() metav1.Time { return metav1.NewTime(now) }),
+			cacheTTL,
+			azure.DefaultConnectivityCheckTimeout,
+		)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Check", func() {
+		It("should succeed if listing Azure PublicIPAddresses succeeds", func() {
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(newPublicIPAddressListPager(nil, nil))
+			readRequestsCounter.EXPECT().Inc()
+
+			Expect(checker.Check(req)).To(Succeed())
+		})
+
+		It("should fail if listing Azure PublicIPAddresses fails", func() {
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(newPublicIPAddressListPager(errors.New("test")))
+			readRequestsCounter.EXPECT().Inc()
+
+			Expect(checker.Check(req)).To(MatchError("could not list Azure PublicIPAddresses: test"))
+		})
+
+		It("should return the cached result if it is not older than the cache TTL", func() {
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(newPublicIPAddressListPager(errors.New("test")))
+			readRequestsCounter.EXPECT().Inc()
+
+			Expect(checker.Check(req)).To(MatchError("could not list Azure PublicIPAddresses: test"))
+			now = now.Add(cacheTTL - time.Second)
+			Expect(checker.Check(req)).To(MatchError("could not list Azure PublicIPAddresses: test"))
+		})
+
+		It("should perform a new check if the cached result is older than the cache TTL", func() {
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(newPublicIPAddressListPager(errors.New("test")))
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(newPublicIPAddressListPager(nil, nil))
+			readRequestsCounter.EXPECT().Inc().Times(2)
+
+			Expect(checker.Check(req)).To(MatchError("could not list Azure PublicIPAddresses: test"))
+			now = now.Add(cacheTTL)
+			Expect(checker.Check(req)).To(Succeed())
+		})
+	})
+})
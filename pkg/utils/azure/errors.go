@@ -0,0 +1,202 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+const (
+	// DefaultThrottledRetryAfter is the delay after which a throttled Azure request is retried
+	// if the response doesn't contain a Retry-After header.
+	DefaultThrottledRetryAfter = 1 * time.Minute
+	// MinThrottledRetryAfter is the minimum delay after which a throttled Azure request is retried,
+	// even if the Retry-After header of the response specifies a shorter delay, zero, or a date in the past.
+	MinThrottledRetryAfter = 10 * time.Second
+
+	// rateLimitRemainingHeaderPrefix is the prefix of the Azure Resource Manager headers containing the remaining requests,
+	// e.g. x-ms-ratelimit-remaining-subscription-reads.
+	rateLimitRemainingHeaderPrefix = "X-Ms-Ratelimit-Remaining-"
+	// retryAfterHeader is the header containing the delay after which a throttled request should be retried,
+	// either in seconds or as an HTTP date.
+	retryAfterHeader = "Retry-After"
+)
+
+// featureNotSupportedErrorCode is the error code of errors caused by features not supported by the Azure API profile.
+const featureNotSupportedErrorCode = "FeatureNotSupported"
+
+// correlationIDHeader is the Azure Resource Manager header containing the correlation request ID.
+const correlationIDHeader = "X-Ms-Correlation-Request-Id"
+
+// permanentErrorCodes are the Azure error codes of errors that will never go away if the request is retried.
+var permanentErrorCodes = sets.New(
+	"AuthorizationFailed",
+	"LinkedAuthorizationFailed",
+	"ScopeLocked",
+	"RequestDisallowedByPolicy",
+	"SubscriptionNotFound",
+	"InvalidSubscriptionId",
+	"ReadOnlyDisabledSubscription",
+	"MissingSubscriptionRegistration",
+)
+
+// ErrorClassification is the classification of an error returned by an Azure request.
+type ErrorClassification struct {
+	// Permanent is true if the request will never succeed if retried, e.g. because of missing permissions.
+	Permanent bool
+	// Code is the Azure error code, if known.
+	Code string
+	// CorrelationID is the Azure correlation request ID, if known.
+	CorrelationID string
+}
+
+// ClassifyError classifies the given error returned by an Azure request as permanent or transient,
+// and extracts the Azure error code and correlation request ID from it, if available.
+func ClassifyError(err error) ErrorClassification {
+	var classification ErrorClassification
+
+	var responseErr *azcore.ResponseError
+	if stderrors.As(err, &responseErr) {
+		classification.Code = responseErr.ErrorCode
+		if responseErr.RawResponse != nil {
+			classification.CorrelationID = responseErr.RawResponse.Header.Get(correlationIDHeader)
+		}
+		if responseErr.StatusCode == http.StatusForbidden {
+			classification.Permanent = true
+		}
+	}
+	if permanentErrorCodes.Has(classification.Code) {
+		classification.Permanent = true
+	}
+
+	var featureErr *azure.FeatureNotSupportedError
+	if stderrors.As(err, &featureErr) {
+		classification.Permanent = true
+		classification.Code = featureNotSupportedErrorCode
+	}
+
+	return classification
+}
+
+// ThrottledError is returned if an Azure request has been throttled.
+type ThrottledError struct {
+	// Cause is the error returned by the Azure request.
+	Cause error
+	// RetryAfter is the delay after which the request should be retried, as requested by the server.
+	RetryAfter time.Duration
+}
+
+// Error returns the error message of the cause.
+func (e *ThrottledError) Error() string {
+	return e.Cause.Error()
+}
+
+// Unwrap returns the cause.
+func (e *ThrottledError) Unwrap() error {
+	return e.Cause
+}
+
+// AsThrottledError returns the first ThrottledError in the chain of the given error, and true if one is found.
+func AsThrottledError(err error) (*ThrottledError, bool) {
+	var throttledErr *ThrottledError
+	if stderrors.As(err, &throttledErr) {
+		return throttledErr, true
+	}
+	return nil, false
+}
+
+// wrapError wraps the given error returned by an Azure request with the given message.
+// If the request has been throttled, the result is a ThrottledError.
+func wrapError(err error, message string) error {
+	wrappedErr := errors.Wrap(err, message)
+	if resp := getThrottledResponse(err); resp != nil {
+		return &ThrottledError{
+			Cause:      wrappedErr,
+			RetryAfter: max(getRetryAfter(resp, DefaultThrottledRetryAfter), MinThrottledRetryAfter),
+		}
+	}
+	return wrappedErr
+}
+
+func isAzureNotFoundError(err error) bool {
+	var responseErr *azcore.ResponseError
+	if stderrors.As(err, &responseErr) {
+		return responseErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// getThrottledResponse returns the response of the given error returned by an Azure request
+// if the request has been throttled, or nil otherwise.
+func getThrottledResponse(err error) *http.Response {
+	var responseErr *azcore.ResponseError
+	if !stderrors.As(err, &responseErr) || responseErr.RawResponse == nil {
+		return nil
+	}
+	if responseErr.RawResponse.StatusCode == http.StatusTooManyRequests || isRateLimitExhausted(responseErr.RawResponse.Header) {
+		return responseErr.RawResponse
+	}
+	return nil
+}
+
+// getRetryAfter returns the delay specified by the Retry-After header of the given response,
+// or the given default delay if the header is missing or invalid.
+func getRetryAfter(resp *http.Response, defaultDelay time.Duration) time.Duration {
+	value := resp.Header.Get(retryAfterHeader)
+	if len(value) == 0 {
+		return defaultDelay
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay
+		}
+		return 0
+	}
+	return defaultDelay
+}
+
+// isRateLimitExhausted returns true if any of the x-ms-ratelimit-remaining-* headers indicates that no requests remain.
+// The values of these headers are either numbers, or comma-separated lists of <policy>;<number> pairs.
+func isRateLimitExhausted(header http.Header) bool {
+	for key, values := range header {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(key), rateLimitRemainingHeaderPrefix) {
+			continue
+		}
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if i := strings.LastIndex(item, ";"); i >= 0 {
+					item = item[i+1:]
+				}
+				if remaining, err := strconv.Atoi(strings.TrimSpace(item)); err == nil && remaining <= 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
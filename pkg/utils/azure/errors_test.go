@@ -0,0 +1,66 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	clientazure "github.com/gardener/remedy-controller/pkg/client/azure"
+	"github.com/gardener/remedy-controller/pkg/utils/azure"
+)
+
+var _ = Describe("Errors", func() {
+	Describe("#ClassifyError", func() {
+		newRequestError := func(statusCode int, code string) error {
+			return newResponseError(statusCode, http.Header{"X-Ms-Correlation-Request-Id": []string{"correlation-id"}}, code)
+		}
+
+		It("should classify errors with a permanent error code as permanent", func() {
+			err := errors.Wrap(newRequestError(http.StatusConflict, "ScopeLocked"), "test")
+			Expect(azure.ClassifyError(err)).To(Equal(azure.ErrorClassification{
+				Permanent:     true,
+				Code:          "ScopeLocked",
+				CorrelationID: "correlation-id",
+			}))
+		})
+
+		It("should classify forbidden errors as permanent", func() {
+			Expect(azure.ClassifyError(newRequestError(http.StatusForbidden, "Unknown")).Permanent).To(BeTrue())
+		})
+
+		It("should classify other Azure errors as transient", func() {
+			Expect(azure.ClassifyError(newRequestError(http.StatusInternalServerError, "InternalServerError"))).To(Equal(azure.ErrorClassification{
+				Code:          "InternalServerError",
+				CorrelationID: "correlation-id",
+			}))
+		})
+
+		It("should classify errors caused by features not supported by the Azure API profile as permanent", func() {
+			err := errors.Wrap(&clientazure.FeatureNotSupportedError{Feature: clientazure.FeatureVMReapply, APIProfile: "2019-03-01-hybrid"}, "test")
+			Expect(azure.ClassifyError(err)).To(Equal(azure.ErrorClassification{
+				Permanent: true,
+				Code:      "FeatureNotSupported",
+			}))
+		})
+
+		It("should classify non-Azure errors as transient", func() {
+			Expect(azure.ClassifyError(errors.New("test"))).To(Equal(azure.ErrorClassification{}))
+		})
+	})
+})
@@ -34,9 +34,31 @@ var (
 			Help: "Number of Azure write requests",
 		},
 	)
+	// ReadRequestsWaitSecondsCounter is a global counter for the time spent waiting for the rate limiter before Azure read requests.
+	ReadRequestsWaitSecondsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "azure_read_requests_wait_seconds_total",
+			Help: "Time spent waiting for the rate limiter before Azure read requests in seconds",
+		},
+	)
+	// WriteRequestsWaitSecondsCounter is a global counter for the time spent waiting for the rate limiter before Azure write requests.
+	WriteRequestsWaitSecondsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "azure_write_requests_wait_seconds_total",
+			Help: "Time spent waiting for the rate limiter before Azure write requests in seconds",
+		},
+	)
+	// PermanentErrorsCounterVec is a global counter vector for Azure operations that failed with a permanent error.
+	PermanentErrorsCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "azure_permanent_errors_total",
+			Help: "Number of Azure operations that failed with a permanent error",
+		},
+		[]string{"operation", "code"},
+	)
 )
 
 func init() {
 	// Register metrics with the global Prometheus registry
-	metrics.Registry.MustRegister(ReadRequestsCounter, WriteRequestsCounter)
+	metrics.Registry.MustRegister(ReadRequestsCounter, WriteRequestsCounter, ReadRequestsWaitSecondsCounter, WriteRequestsWaitSecondsCounter, PermanentErrorsCounterVec)
 }
@@ -0,0 +1,73 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gardener/remedy-controller/pkg/client/azure"
+)
+
+// defaultPollFrequency is the delay between polls of a long-running operation
+// if the response doesn't contain a Retry-After header.
+const defaultPollFrequency = 30 * time.Second
+
+// pollUntilDone polls the given long-running operation until it is done or the given context is done.
+// The given waitRead function is called before each request, so that polls are rate limited as other read requests.
+func pollUntilDone(ctx context.Context, poller azure.Poller, waitRead func(context.Context) error) error {
+	for !poller.Done() {
+		if err := waitRead(ctx); err != nil {
+			return err
+		}
+		resp, err := poller.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		if poller.Done() {
+			break
+		}
+
+		delay := defaultPollFrequency
+		if resp != nil {
+			delay = getRetryAfter(resp, defaultPollFrequency)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	// Getting the result may require a final request to fetch the resource
+	if err := waitRead(ctx); err != nil {
+		return err
+	}
+	return poller.Result(ctx)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "could not wait for the Azure long-running operation")
+	}
+}
@@ -16,12 +16,9 @@ package azure
 
 import (
 	"context"
-	"net/http"
 	"slices"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
-	"github.com/Azure/go-autorest/autorest"
-	"github.com/pkg/errors"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/gardener/remedy-controller/pkg/client/azure"
@@ -30,69 +27,76 @@ import (
 // PublicIPAddressUtils provides utility methods for getting and cleaning Azure PublicIPAddress objects.
 type PublicIPAddressUtils interface {
 	// GetByName returns the PublicIPAddress with the given name, or nil if not found.
-	GetByName(ctx context.Context, name string) (*network.PublicIPAddress, error)
+	GetByName(ctx context.Context, name string) (*armnetwork.PublicIPAddress, error)
 	// GetByIP returns the PublicIPAddress with the given IP, or nil if not found.
-	GetByIP(ctx context.Context, ip string) (*network.PublicIPAddress, error)
+	GetByIP(ctx context.Context, ip string) (*armnetwork.PublicIPAddress, error)
 	// GetAll returns all PublicIPAddresses.
-	GetAll(ctx context.Context) ([]network.PublicIPAddress, error)
+	GetAll(ctx context.Context) ([]*armnetwork.PublicIPAddress, error)
 	// RemoveFromLoadBalancer removes all FrontendIPConfigurations, LoadBalancingRules, and Probes
-	// using the given PublicIPAddress IDs from the LoadBalancer.
+	// using the given PublicIPAddress IDs from the LoadBalancer. In dry-run mode, the LoadBalancer is neither read nor updated.
 	RemoveFromLoadBalancer(ctx context.Context, publicIPAddressIDs []string) error
-	// Delete deletes the PublicIPAddress with the given name.
+	// Delete deletes the PublicIPAddress with the given name. In dry-run mode, the PublicIPAddress is not deleted.
 	Delete(ctx context.Context, name string) error
 }
 
 // NewPublicIPAddressUtils creates a new instance of PublicIPAddressUtils.
 func NewPublicIPAddressUtils(
-	azureClients *azure.Clients,
+	azureClients azure.ClientsProvider,
 	resourceGroup string,
 	readRequestsCounter prometheus.Counter,
 	writeRequestsCounter prometheus.Counter,
+	rateLimiter RateLimiter,
+	dryRun bool,
 ) PublicIPAddressUtils {
 	return &publicIPAddressUtils{
 		azureClients:         azureClients,
 		resourceGroup:        resourceGroup,
 		readRequestsCounter:  readRequestsCounter,
 		writeRequestsCounter: writeRequestsCounter,
+		rateLimiter:          rateLimiter,
+		dryRun:               dryRun,
 	}
 }
 
 type publicIPAddressUtils struct {
-	azureClients         *azure.Clients
+	azureClients         azure.ClientsProvider
 	resourceGroup        string
 	readRequestsCounter  prometheus.Counter
 	writeRequestsCounter prometheus.Counter
+	rateLimiter          RateLimiter
+	dryRun               bool
 }
 
 // GetByName returns the PublicIPAddress with the given name, or nil if not found.
-func (p *publicIPAddressUtils) GetByName(ctx context.Context, name string) (*network.PublicIPAddress, error) {
-	p.readRequestsCounter.Inc()
-	azurePublicIP, err := p.azureClients.PublicIPAddressesClient.Get(ctx, p.resourceGroup, name, "")
+func (p *publicIPAddressUtils) GetByName(ctx context.Context, name string) (*armnetwork.PublicIPAddress, error) {
+	if err := p.waitRead(ctx); err != nil {
+		return nil, err
+	}
+	azurePublicIP, err := p.azureClients.Clients().PublicIPAddressesClient.Get(ctx, p.resourceGroup, name, nil)
 	if err != nil {
 		if isAzureNotFoundError(err) {
 			return nil, nil
 		}
-		return nil, errors.Wrap(err, "could not get Azure PublicIPAddress")
+		return nil, wrapError(err, "could not get Azure PublicIPAddress")
 	}
-	return &azurePublicIP, nil
+	return &azurePublicIP.PublicIPAddress, nil
 }
 
 // GetByIP returns the PublicIPAddress with the given IP, or nil if not found.
-func (p *publicIPAddressUtils) GetByIP(ctx context.Context, ip string) (*network.PublicIPAddress, error) {
-	p.readRequestsCounter.Inc()
-	azurePublicIPList, err := p.azureClients.PublicIPAddressesClient.List(ctx, p.resourceGroup)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not list Azure PublicIPAddresses")
-	}
-	for azurePublicIPList.NotDone() {
-		for _, azurePublicIP := range azurePublicIPList.Values() {
-			if azurePublicIP.IPAddress != nil && *azurePublicIP.IPAddress == ip {
-				return &azurePublicIP, nil
-			}
+func (p *publicIPAddressUtils) GetByIP(ctx context.Context, ip string) (*armnetwork.PublicIPAddress, error) {
+	pager := p.azureClients.Clients().PublicIPAddressesClient.NewListPager(p.resourceGroup, nil)
+	for pager.More() {
+		if err := p.waitRead(ctx); err != nil {
+			return nil, err
 		}
-		p.readRequestsCounter.Inc()
-		if err := azurePublicIPList.NextWithContext(ctx); err != nil {
-			return nil, errors.Wrap(err, "could not advance to the next page of Azure PublicIPAddresses")
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, wrapError(err, "could not list Azure PublicIPAddresses")
+		}
+		for _, azurePublicIP := range page.Value {
+			if azurePublicIP != nil && azurePublicIP.Properties != nil && azurePublicIP.Properties.IPAddress != nil && *azurePublicIP.Properties.IPAddress == ip {
+				return azurePublicIP, nil
+			}
 		}
 	}
 
@@ -100,19 +104,18 @@ func (p *publicIPAddressUtils) GetByIP(ctx context.Context, ip string) (*network
 }
 
 // GetAll returns all PublicIPAddresses.
-func (p *publicIPAddressUtils) GetAll(ctx context.Context) ([]network.PublicIPAddress, error) {
-	p.readRequestsCounter.Inc()
-	azurePublicIPList, err := p.azureClients.PublicIPAddressesClient.List(ctx, p.resourceGroup)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not list Azure PublicIPAddresses")
-	}
-	var azurePublicIPs []network.PublicIPAddress
-	for azurePublicIPList.NotDone() {
-		azurePublicIPs = append(azurePublicIPs, azurePublicIPList.Values()...)
-		p.readRequestsCounter.Inc()
-		if err := azurePublicIPList.NextWithContext(ctx); err != nil {
-			return nil, errors.Wrap(err, "could not advance to the next page of Azure PublicIPAddresses")
+func (p *publicIPAddressUtils) GetAll(ctx context.Context) ([]*armnetwork.PublicIPAddress, error) {
+	pager := p.azureClients.Clients().PublicIPAddressesClient.NewListPager(p.resourceGroup, nil)
+	var azurePublicIPs []*armnetwork.PublicIPAddress
+	for pager.More() {
+		if err := p.waitRead(ctx); err != nil {
+			return nil, err
 		}
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, wrapError(err, "could not list Azure PublicIPAddresses")
+		}
+		azurePublicIPs = append(azurePublicIPs, page.Value...)
 	}
 	return azurePublicIPs, nil
 }
@@ -120,26 +123,33 @@ func (p *publicIPAddressUtils) GetAll(ctx context.Context) ([]network.PublicIPAd
 // RemoveFromLoadBalancer removes all FrontendIPConfigurations, LoadBalancingRules, and Probes
 // using the given PublicIPAddress IDs from the LoadBalancer.
 func (p *publicIPAddressUtils) RemoveFromLoadBalancer(ctx context.Context, publicIPAddressIDs []string) error {
+	if p.dryRun {
+		return nil
+	}
+
 	// Get the Azure LoadBalancer
 	lbName := p.resourceGroup // TODO
-	p.readRequestsCounter.Inc()
-	lb, err := p.azureClients.LoadBalancersClient.Get(ctx, p.resourceGroup, lbName, "")
+	if err := p.waitRead(ctx); err != nil {
+		return err
+	}
+	lb, err := p.azureClients.Clients().LoadBalancersClient.Get(ctx, p.resourceGroup, lbName, nil)
 	if err != nil {
-		return errors.Wrap(err, "could not get Azure LoadBalancer")
+		return wrapError(err, "could not get Azure LoadBalancer")
 	}
 
 	// Update the FrontendIPConfigurations, LoadBalancerRules, and Probes on the Azure LoadBalancer
-	fcIDs := updateFrontendIPConfigurations(lb, publicIPAddressIDs)
-	ruleIDs := updateLoadBalancingRules(lb, fcIDs)
-	updateProbes(lb, ruleIDs)
-	p.writeRequestsCounter.Inc()
-	result, err := p.azureClients.LoadBalancersClient.CreateOrUpdate(ctx, p.resourceGroup, lbName, lb)
+	fcIDs := updateFrontendIPConfigurations(&lb.LoadBalancer, publicIPAddressIDs)
+	ruleIDs := updateLoadBalancingRules(&lb.LoadBalancer, fcIDs)
+	updateProbes(&lb.LoadBalancer, ruleIDs)
+	if err := p.waitWrite(ctx); err != nil {
+		return err
+	}
+	poller, err := p.azureClients.Clients().LoadBalancersClient.BeginCreateOrUpdate(ctx, p.resourceGroup, lbName, lb.LoadBalancer)
 	if err != nil {
-		return errors.Wrap(err, "could not update Azure LoadBalancer")
+		return wrapError(err, "could not update Azure LoadBalancer")
 	}
-	p.readRequestsCounter.Inc()
-	if err := result.WaitForCompletionRef(ctx, p.azureClients.LoadBalancersClient.Client()); err != nil {
-		return errors.Wrap(err, "could not wait for the Azure LoadBalancer update to complete")
+	if err := pollUntilDone(ctx, poller, p.waitRead); err != nil {
+		return wrapError(err, "could not wait for the Azure LoadBalancer update to complete")
 	}
 
 	return nil
@@ -147,78 +157,94 @@ func (p *publicIPAddressUtils) RemoveFromLoadBalancer(ctx context.Context, publi
 
 // Delete deletes the PublicIPAddress with the given name.
 func (p *publicIPAddressUtils) Delete(ctx context.Context, name string) error {
+	if p.dryRun {
+		return nil
+	}
+
 	// Delete the Azure PublicIPAddress
-	p.writeRequestsCounter.Inc()
-	result, err := p.azureClients.PublicIPAddressesClient.Delete(ctx, p.resourceGroup, name)
+	if err := p.waitWrite(ctx); err != nil {
+		return err
+	}
+	poller, err := p.azureClients.Clients().PublicIPAddressesClient.BeginDelete(ctx, p.resourceGroup, name)
 	if err != nil {
 		if isAzureNotFoundError(err) {
 			return nil
 		}
-		return errors.Wrap(err, "could not delete Azure PublicIPAddress")
+		return wrapError(err, "could not delete Azure PublicIPAddress")
 	}
-	p.readRequestsCounter.Inc()
-	if err := result.WaitForCompletionRef(ctx, p.azureClients.PublicIPAddressesClient.Client()); err != nil {
-		return errors.Wrap(err, "could not wait for the Azure PublicIPAddress deletion to complete")
+	if err := pollUntilDone(ctx, poller, p.waitRead); err != nil {
+		return wrapError(err, "could not wait for the Azure PublicIPAddress deletion to complete")
 	}
 
 	return nil
 }
 
-func updateFrontendIPConfigurations(lb network.LoadBalancer, publicIPAddressIDs []string) []string {
-	if lb.FrontendIPConfigurations == nil {
+func updateFrontendIPConfigurations(lb *armnetwork.LoadBalancer, publicIPAddressIDs []string) []string {
+	if lb.Properties == nil || lb.Properties.FrontendIPConfigurations == nil {
 		return nil
 	}
 	var fcIDs []string
-	var updated []network.FrontendIPConfiguration
-	for _, fc := range *lb.FrontendIPConfigurations {
-		if fc.ID != nil && fc.PublicIPAddress != nil && fc.PublicIPAddress.ID != nil && slices.Contains(publicIPAddressIDs, *fc.PublicIPAddress.ID) {
+	var updated []*armnetwork.FrontendIPConfiguration
+	for _, fc := range lb.Properties.FrontendIPConfigurations {
+		if fc.ID != nil && fc.Properties != nil && fc.Properties.PublicIPAddress != nil && fc.Properties.PublicIPAddress.ID != nil &&
+			slices.Contains(publicIPAddressIDs, *fc.Properties.PublicIPAddress.ID) {
 			fcIDs = append(fcIDs, *fc.ID)
 		} else {
 			updated = append(updated, fc)
 		}
 	}
-	*lb.FrontendIPConfigurations = updated
+	lb.Properties.FrontendIPConfigurations = updated
 	return fcIDs
 }
 
-func updateLoadBalancingRules(lb network.LoadBalancer, fcIDs []string) []string {
-	if lb.LoadBalancingRules == nil {
+func updateLoadBalancingRules(lb *armnetwork.LoadBalancer, fcIDs []string) []string {
+	if lb.Properties == nil || lb.Properties.LoadBalancingRules == nil {
 		return nil
 	}
 	var ruleIDs []string
-	var updated []network.LoadBalancingRule
-	for _, rule := range *lb.LoadBalancingRules {
-		if rule.ID != nil && rule.FrontendIPConfiguration != nil && rule.FrontendIPConfiguration.ID != nil && slices.Contains(fcIDs, *rule.FrontendIPConfiguration.ID) {
+	var updated []*armnetwork.LoadBalancingRule
+	for _, rule := range lb.Properties.LoadBalancingRules {
+		if rule.ID != nil && rule.Properties != nil && rule.Properties.FrontendIPConfiguration != nil && rule.Properties.FrontendIPConfiguration.ID != nil &&
+			slices.Contains(fcIDs, *rule.Properties.FrontendIPConfiguration.ID) {
 			ruleIDs = append(ruleIDs, *rule.ID)
 		} else {
 			updated = append(updated, rule)
 		}
 	}
-	*lb.LoadBalancingRules = updated
+	lb.Properties.LoadBalancingRules = updated
 	return ruleIDs
 }
 
-func updateProbes(lb network.LoadBalancer, ruleIDs []string) {
-	if lb.Probes == nil {
+func updateProbes(lb *armnetwork.LoadBalancer, ruleIDs []string) {
+	if lb.Properties == nil || lb.Properties.Probes == nil {
 		return
 	}
-	var updated []network.Probe
-	for _, probe := range *lb.Probes {
-		if probe.LoadBalancingRules == nil {
+	var updated []*armnetwork.Probe
+	for _, probe := range lb.Properties.Probes {
+		if probe.Properties == nil || probe.Properties.LoadBalancingRules == nil {
 			continue
 		}
-		for _, probeRule := range *probe.LoadBalancingRules {
+		for _, probeRule := range probe.Properties.LoadBalancingRules {
 			if probeRule.ID == nil || !slices.Contains(ruleIDs, *probeRule.ID) {
 				updated = append(updated, probe)
 			}
 		}
 	}
-	*lb.Probes = updated
+	lb.Properties.Probes = updated
+}
+
+func (p *publicIPAddressUtils) waitRead(ctx context.Context) error {
+	if err := p.rateLimiter.WaitRead(ctx); err != nil {
+		return err
+	}
+	p.readRequestsCounter.Inc()
+	return nil
 }
 
-func isAzureNotFoundError(err error) bool {
-	if e, ok := err.(autorest.DetailedError); ok {
-		return e.StatusCode == http.StatusNotFound
+func (p *publicIPAddressUtils) waitWrite(ctx context.Context) error {
+	if err := p.rateLimiter.WaitWrite(ctx); err != nil {
+		return err
 	}
-	return false
+	p.writeRequestsCounter.Inc()
+	return nil
 }
@@ -18,8 +18,8 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-11-01/network"
-	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -63,24 +63,26 @@ var _ = Describe("PublicIPAddressUtils", func() {
 
 		publicIPAddressesClient *mockclientazure.MockPublicIPAddressesClient
 		loadBalancersClient     *mockclientazure.MockLoadBalancersClient
-		future                  *mockclientazure.MockFuture
+		poller                  *mockclientazure.MockPoller
 		readRequestsCounter     *mockprometheus.MockCounter
 		writeRequestsCounter    *mockprometheus.MockCounter
+		clients                 *clientazure.Clients
+		rateLimiter             azure.RateLimiter
 
 		pubipUtils azure.PublicIPAddressUtils
 
-		publicIPAddress          network.PublicIPAddress
-		publicIPAddress2         network.PublicIPAddress
-		frontendIPConfiguration  network.FrontendIPConfiguration
-		frontendIPConfiguration2 network.FrontendIPConfiguration
-		loadBalancingRule        network.LoadBalancingRule
-		loadBalancingRule2       network.LoadBalancingRule
-		probe                    network.Probe
-		probe2                   network.Probe
+		publicIPAddress          *armnetwork.PublicIPAddress
+		publicIPAddress2         *armnetwork.PublicIPAddress
+		frontendIPConfiguration  *armnetwork.FrontendIPConfiguration
+		frontendIPConfiguration2 *armnetwork.FrontendIPConfiguration
+		loadBalancingRule        *armnetwork.LoadBalancingRule
+		loadBalancingRule2       *armnetwork.LoadBalancingRule
+		probe                    *armnetwork.Probe
+		probe2                   *armnetwork.Probe
 
-		newLoadBalancer func([]network.FrontendIPConfiguration, []network.LoadBalancingRule, []network.Probe) network.LoadBalancer
-
-		newPublicIPAddressListResultPage func([]network.PublicIPAddress, bool) network.PublicIPAddressListResultPage
+		newLoadBalancer         func([]*armnetwork.FrontendIPConfiguration, []*armnetwork.LoadBalancingRule, []*armnetwork.Probe) armnetwork.LoadBalancer
+		newLoadBalancerResponse func([]*armnetwork.FrontendIPConfiguration, []*armnetwork.LoadBalancingRule, []*armnetwork.Probe) armnetwork.LoadBalancersClientGetResponse
+		expectPoll              func(error)
 
 		notFoundError error
 	)
@@ -91,116 +93,116 @@ var _ = Describe("PublicIPAddressUtils", func() {
 
 		publicIPAddressesClient = mockclientazure.NewMockPublicIPAddressesClient(ctrl)
 		loadBalancersClient = mockclientazure.NewMockLoadBalancersClient(ctrl)
-		future = mockclientazure.NewMockFuture(ctrl)
+		poller = mockclientazure.NewMockPoller(ctrl)
 		readRequestsCounter = mockprometheus.NewMockCounter(ctrl)
 		writeRequestsCounter = mockprometheus.NewMockCounter(ctrl)
-		clients := &clientazure.Clients{
+		clients = &clientazure.Clients{
 			PublicIPAddressesClient: publicIPAddressesClient,
 			LoadBalancersClient:     loadBalancersClient,
 		}
+		rateLimiter = azure.NewRateLimiter(nil, readRequestsCounter, writeRequestsCounter)
 
-		pubipUtils = azure.NewPublicIPAddressUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter)
+		pubipUtils = azure.NewPublicIPAddressUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, false)
 
-		publicIPAddress = network.PublicIPAddress{
+		publicIPAddress = &armnetwork.PublicIPAddress{
 			ID:   ptr.To(publicIPAddressID),
 			Name: ptr.To(publicIPAddressName),
-			PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			Properties: &armnetwork.PublicIPAddressPropertiesFormat{
 				IPAddress: ptr.To(ip),
 			},
 		}
-		publicIPAddress2 = network.PublicIPAddress{
+		publicIPAddress2 = &armnetwork.PublicIPAddress{
 			ID:   ptr.To(publicIPAddressID2),
 			Name: ptr.To(publicIPAddressName2),
-			PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			Properties: &armnetwork.PublicIPAddressPropertiesFormat{
 				IPAddress: ptr.To(ip2),
 			},
 		}
-		frontendIPConfiguration = network.FrontendIPConfiguration{
+		frontendIPConfiguration = &armnetwork.FrontendIPConfiguration{
 			ID:   ptr.To(frontendIPConfigurationID),
 			Name: ptr.To(frontendIPConfigurationName),
-			FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
-				LoadBalancingRules: &[]network.SubResource{
+			Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{
+				LoadBalancingRules: []*armnetwork.SubResource{
 					{ID: ptr.To(loadBalancingRuleID)},
 				},
-				PublicIPAddress: &network.PublicIPAddress{
+				PublicIPAddress: &armnetwork.PublicIPAddress{
 					ID: ptr.To(publicIPAddressID),
 				},
 			},
 		}
-		frontendIPConfiguration2 = network.FrontendIPConfiguration{
+		frontendIPConfiguration2 = &armnetwork.FrontendIPConfiguration{
 			ID:   ptr.To(frontendIPConfigurationID2),
 			Name: ptr.To(frontendIPConfigurationName2),
-			FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
-				LoadBalancingRules: &[]network.SubResource{
+			Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{
+				LoadBalancingRules: []*armnetwork.SubResource{
 					{ID: ptr.To(loadBalancingRuleID2)},
 				},
-				PublicIPAddress: &network.PublicIPAddress{
+				PublicIPAddress: &armnetwork.PublicIPAddress{
 					ID: ptr.To(publicIPAddressID2),
 				},
 			},
 		}
-		loadBalancingRule = network.LoadBalancingRule{
+		loadBalancingRule = &armnetwork.LoadBalancingRule{
 			ID:   ptr.To(loadBalancingRuleID),
 			Name: ptr.To(loadBalancingRuleName),
-			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-				FrontendIPConfiguration: &network.SubResource{ID: ptr.To(frontendIPConfigurationID)},
+			Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+				FrontendIPConfiguration: &armnetwork.SubResource{ID: ptr.To(frontendIPConfigurationID)},
 			},
 		}
-		loadBalancingRule2 = network.LoadBalancingRule{
+		loadBalancingRule2 = &armnetwork.LoadBalancingRule{
 			ID:   ptr.To(loadBalancingRuleID2),
 			Name: ptr.To(loadBalancingRuleName2),
-			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-				FrontendIPConfiguration: &network.SubResource{ID: ptr.To(frontendIPConfigurationID2)},
+			Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+				FrontendIPConfiguration: &armnetwork.SubResource{ID: ptr.To(frontendIPConfigurationID2)},
 			},
 		}
-		probe = network.Probe{
+		probe = &armnetwork.Probe{
 			ID:   ptr.To(probeID),
 			Name: ptr.To(probeName),
-			ProbePropertiesFormat: &network.ProbePropertiesFormat{
-				LoadBalancingRules: &[]network.SubResource{
+			Properties: &armnetwork.ProbePropertiesFormat{
+				LoadBalancingRules: []*armnetwork.SubResource{
 					{ID: ptr.To(loadBalancingRuleID)},
 				},
 			},
 		}
-		probe2 = network.Probe{
+		probe2 = &armnetwork.Probe{
 			ID:   ptr.To(probeID2),
 			Name: ptr.To(probeName2),
-			ProbePropertiesFormat: &network.ProbePropertiesFormat{
-				LoadBalancingRules: &[]network.SubResource{
+			Properties: &armnetwork.ProbePropertiesFormat{
+				LoadBalancingRules: []*armnetwork.SubResource{
 					{ID: ptr.To(loadBalancingRuleID2)},
 				},
 			},
 		}
 
-		newLoadBalancer = func(frontendIPConfigurations []network.FrontendIPConfiguration, loadBalancingRules []network.LoadBalancingRule, probes []network.Probe) network.LoadBalancer {
-			return network.LoadBalancer{
+		newLoadBalancer = func(frontendIPConfigurations []*armnetwork.FrontendIPConfiguration, loadBalancingRules []*armnetwork.LoadBalancingRule, probes []*armnetwork.Probe) armnetwork.LoadBalancer {
+			return armnetwork.LoadBalancer{
 				ID:   ptr.To(loadBalancerID),
 				Name: ptr.To(loadBalancerName),
-				LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
-					FrontendIPConfigurations: &frontendIPConfigurations,
-					LoadBalancingRules:       &loadBalancingRules,
-					Probes:                   &probes,
+				Properties: &armnetwork.LoadBalancerPropertiesFormat{
+					FrontendIPConfigurations: frontendIPConfigurations,
+					LoadBalancingRules:       loadBalancingRules,
+					Probes:                   probes,
 				},
 			}
 		}
 
-		newPublicIPAddressListResultPage = func(publicIPAddresses []network.PublicIPAddress, fail bool) network.PublicIPAddressListResultPage {
-			page := network.NewPublicIPAddressListResultPage(network.PublicIPAddressListResult{}, func(_ context.Context, res network.PublicIPAddressListResult) (network.PublicIPAddressListResult, error) {
-				if res.Value == nil {
-					return network.PublicIPAddressListResult{
-						Value: &publicIPAddresses,
-					}, nil
-				}
-				if fail {
-					return network.PublicIPAddressListResult{}, errors.New("test")
-				}
-				return network.PublicIPAddressListResult{}, nil
-			})
-			Expect(page.NextWithContext(ctx)).To(Succeed())
-			return page
+		newLoadBalancerResponse = func(frontendIPConfigurations []*armnetwork.FrontendIPConfiguration, loadBalancingRules []*armnetwork.LoadBalancingRule, probes []*armnetwork.Probe) armnetwork.LoadBalancersClientGetResponse {
+			return armnetwork.LoadBalancersClientGetResponse{LoadBalancer: newLoadBalancer(frontendIPConfigurations, loadBalancingRules, probes)}
+		}
+		expectPoll = func(resultErr error) {
+			resp := &http.Response{Header: http.Header{"Retry-After": []string{"0"}}}
+			gomock.InOrder(
+				poller.EXPECT().Done().Return(false),
+				poller.EXPECT().Poll(ctx).Return(resp, nil),
+				poller.EXPECT().Done().Return(false).Times(2),
+				poller.EXPECT().Poll(ctx).Return(resp, nil),
+				poller.EXPECT().Done().Return(true),
+				poller.EXPECT().Result(ctx).Return(resultErr),
+			)
 		}
 
-		notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "")
+		notFoundError = &azcore.ResponseError{StatusCode: http.StatusNotFound}
 	})
 
 	AfterEach(func() {
@@ -209,16 +211,16 @@ var _ = Describe("PublicIPAddressUtils", func() {
 
 	Describe("#GetByName", func() {
 		It("should return the Azure PublicIPAddress if it is found", func() {
-			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, "").Return(publicIPAddress, nil)
+			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, nil).Return(armnetwork.PublicIPAddressesClientGetResponse{PublicIPAddress: *publicIPAddress}, nil)
 			readRequestsCounter.EXPECT().Inc()
 
 			result, err := pubipUtils.GetByName(ctx, publicIPAddressName)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(&publicIPAddress))
+			Expect(result).To(Equal(publicIPAddress))
 		})
 
 		It("should return nil if the Azure PublicIPAddress is not found", func() {
-			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, "").Return(network.PublicIPAddress{}, notFoundError)
+			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, nil).Return(armnetwork.PublicIPAddressesClientGetResponse{}, notFoundError)
 			readRequestsCounter.EXPECT().Inc()
 
 			result, err := pubipUtils.GetByName(ctx, publicIPAddressName)
@@ -227,7 +229,7 @@ var _ = Describe("PublicIPAddressUtils", func() {
 		})
 
 		It("should fail if getting the Azure PublicIPAddress fails", func() {
-			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, "").Return(network.PublicIPAddress{}, errors.New("test"))
+			publicIPAddressesClient.EXPECT().Get(ctx, resourceGroup, publicIPAddressName, nil).Return(armnetwork.PublicIPAddressesClientGetResponse{}, errors.New("test"))
 			readRequestsCounter.EXPECT().Inc()
 
 			_, err := pubipUtils.GetByName(ctx, publicIPAddressName)
@@ -237,95 +239,105 @@ var _ = Describe("PublicIPAddressUtils", func() {
 
 	Describe("#GetByIP", func() {
 		It("should return the Azure PublicIPAddress if it is found", func() {
-			page := newPublicIPAddressListResultPage([]network.PublicIPAddress{publicIPAddress, publicIPAddress2}, false)
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(page, nil)
+			pager := newPublicIPAddressListPager(nil, []*armnetwork.PublicIPAddress{publicIPAddress, publicIPAddress2})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc()
 
 			result, err := pubipUtils.GetByIP(ctx, ip)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(&publicIPAddress))
+			Expect(result).To(Equal(publicIPAddress))
 		})
 
-		It("should return nil if the Azure PublicIPAddress is not found", func() {
-			page := newPublicIPAddressListResultPage([]network.PublicIPAddress{publicIPAddress2}, false)
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(page, nil)
+		It("should return the Azure PublicIPAddress if it is found on a later page", func() {
+			pager := newPublicIPAddressListPager(nil, []*armnetwork.PublicIPAddress{publicIPAddress2}, []*armnetwork.PublicIPAddress{publicIPAddress})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc().Times(2)
 
+			result, err := pubipUtils.GetByIP(ctx, ip)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(publicIPAddress))
+		})
+
+		It("should return nil if the Azure PublicIPAddress is not found", func() {
+			pager := newPublicIPAddressListPager(nil, []*armnetwork.PublicIPAddress{publicIPAddress2})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
+			readRequestsCounter.EXPECT().Inc()
+
 			result, err := pubipUtils.GetByIP(ctx, ip)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeNil())
 		})
 
 		It("should fail if listing Azure PublicIPAddresses fails", func() {
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(network.PublicIPAddressListResultPage{}, errors.New("test"))
+			pager := newPublicIPAddressListPager(errors.New("test"))
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc()
 
 			_, err := pubipUtils.GetByIP(ctx, ip)
 			Expect(err).To(MatchError("could not list Azure PublicIPAddresses: test"))
 		})
 
-		It("should fail if advancing to the next page of Azure PublicIPAddresses fails", func() {
-			page := newPublicIPAddressListResultPage([]network.PublicIPAddress{publicIPAddress2}, true)
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(page, nil)
+		It("should fail if getting the next page of Azure PublicIPAddresses fails", func() {
+			pager := newPublicIPAddressListPager(errors.New("test"), []*armnetwork.PublicIPAddress{publicIPAddress2})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc().Times(2)
 
 			_, err := pubipUtils.GetByIP(ctx, ip)
-			Expect(err).To(MatchError("could not advance to the next page of Azure PublicIPAddresses: test"))
+			Expect(err).To(MatchError("could not list Azure PublicIPAddresses: test"))
 		})
 	})
 
 	Describe("#GetAll", func() {
 		It("should return all Azure PublicIPAddresses", func() {
-			azurePublicIPAddresses := []network.PublicIPAddress{publicIPAddress, publicIPAddress2}
-			page := newPublicIPAddressListResultPage(azurePublicIPAddresses, false)
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(page, nil)
+			pager := newPublicIPAddressListPager(nil, []*armnetwork.PublicIPAddress{publicIPAddress}, []*armnetwork.PublicIPAddress{publicIPAddress2})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc().Times(2)
 
 			result, err := pubipUtils.GetAll(ctx)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(azurePublicIPAddresses))
+			Expect(result).To(Equal([]*armnetwork.PublicIPAddress{publicIPAddress, publicIPAddress2}))
 		})
 
 		It("should fail if listing Azure PublicIPAddresses fails", func() {
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(network.PublicIPAddressListResultPage{}, errors.New("test"))
+			pager := newPublicIPAddressListPager(errors.New("test"))
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc()
 
 			_, err := pubipUtils.GetAll(ctx)
 			Expect(err).To(MatchError("could not list Azure PublicIPAddresses: test"))
 		})
 
-		It("should fail if advancing to the next page of Azure PublicIPAddresses fails", func() {
-			page := newPublicIPAddressListResultPage([]network.PublicIPAddress{publicIPAddress}, true)
-			publicIPAddressesClient.EXPECT().List(ctx, resourceGroup).Return(page, nil)
+		It("should fail if getting the next page of Azure PublicIPAddresses fails", func() {
+			pager := newPublicIPAddressListPager(errors.New("test"), []*armnetwork.PublicIPAddress{publicIPAddress})
+			publicIPAddressesClient.EXPECT().NewListPager(resourceGroup, nil).Return(pager)
 			readRequestsCounter.EXPECT().Inc().Times(2)
 
 			_, err := pubipUtils.GetAll(ctx)
-			Expect(err).To(MatchError("could not advance to the next page of Azure PublicIPAddresses: test"))
+			Expect(err).To(MatchError("could not list Azure PublicIPAddresses: test"))
 		})
 	})
 
 	Describe("#RemoveFromLoadBalancer", func() {
 		It("should remove all obsolete resources from the Azure LoadBalancer", func() {
-			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, "").Return(newLoadBalancer(
-				[]network.FrontendIPConfiguration{frontendIPConfiguration, frontendIPConfiguration2},
-				[]network.LoadBalancingRule{loadBalancingRule, loadBalancingRule2},
-				[]network.Probe{probe, probe2},
+			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, nil).Return(newLoadBalancerResponse(
+				[]*armnetwork.FrontendIPConfiguration{frontendIPConfiguration, frontendIPConfiguration2},
+				[]*armnetwork.LoadBalancingRule{loadBalancingRule, loadBalancingRule2},
+				[]*armnetwork.Probe{probe, probe2},
 			), nil)
-			loadBalancersClient.EXPECT().CreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(
-				[]network.FrontendIPConfiguration{frontendIPConfiguration2},
-				[]network.LoadBalancingRule{loadBalancingRule2},
-				[]network.Probe{probe2},
-			)).Return(future, nil)
-			loadBalancersClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(nil)
-			readRequestsCounter.EXPECT().Inc().Times(2)
+			loadBalancersClient.EXPECT().BeginCreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(
+				[]*armnetwork.FrontendIPConfiguration{frontendIPConfiguration2},
+				[]*armnetwork.LoadBalancingRule{loadBalancingRule2},
+				[]*armnetwork.Probe{probe2},
+			)).Return(poller, nil)
+			expectPoll(nil)
+			readRequestsCounter.EXPECT().Inc().Times(4)
 			writeRequestsCounter.EXPECT().Inc()
 
 			Expect(pubipUtils.RemoveFromLoadBalancer(ctx, []string{publicIPAddressID})).To(Succeed())
 		})
 
 		It("should fail if getting the Azure LoadBalancer fails", func() {
-			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, "").Return(network.LoadBalancer{}, errors.New("test"))
+			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, nil).Return(armnetwork.LoadBalancersClientGetResponse{}, errors.New("test"))
 			readRequestsCounter.EXPECT().Inc()
 
 			err := pubipUtils.RemoveFromLoadBalancer(ctx, []string{publicIPAddressID})
@@ -333,8 +345,8 @@ var _ = Describe("PublicIPAddressUtils", func() {
 		})
 
 		It("should fail if updating the Azure LoadBalancer fails", func() {
-			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, "").Return(newLoadBalancer(nil, nil, nil), nil)
-			loadBalancersClient.EXPECT().CreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(nil, nil, nil)).Return(future, errors.New("test"))
+			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, nil).Return(newLoadBalancerResponse(nil, nil, nil), nil)
+			loadBalancersClient.EXPECT().BeginCreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(nil, nil, nil)).Return(nil, errors.New("test"))
 			readRequestsCounter.EXPECT().Inc()
 			writeRequestsCounter.EXPECT().Inc()
 
@@ -343,38 +355,42 @@ var _ = Describe("PublicIPAddressUtils", func() {
 		})
 
 		It("should fail if waiting for the Azure LoadBalancer update to complete fails", func() {
-			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, "").Return(newLoadBalancer(nil, nil, nil), nil)
-			loadBalancersClient.EXPECT().CreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(nil, nil, nil)).Return(future, nil)
-			loadBalancersClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(errors.New("test"))
-			readRequestsCounter.EXPECT().Inc().Times(2)
+			loadBalancersClient.EXPECT().Get(ctx, resourceGroup, loadBalancerName, nil).Return(newLoadBalancerResponse(nil, nil, nil), nil)
+			loadBalancersClient.EXPECT().BeginCreateOrUpdate(ctx, resourceGroup, loadBalancerName, newLoadBalancer(nil, nil, nil)).Return(poller, nil)
+			expectPoll(errors.New("test"))
+			readRequestsCounter.EXPECT().Inc().Times(4)
 			writeRequestsCounter.EXPECT().Inc()
 
 			err := pubipUtils.RemoveFromLoadBalancer(ctx, []string{publicIPAddressID})
 			Expect(err).To(MatchError("could not wait for the Azure LoadBalancer update to complete: test"))
 		})
+
+		It("should neither get nor update the Azure LoadBalancer in dry-run mode", func() {
+			pubipUtils = azure.NewPublicIPAddressUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, true)
+
+			Expect(pubipUtils.RemoveFromLoadBalancer(ctx, []string{publicIPAddressID})).To(Succeed())
+		})
 	})
 
 	Describe("#Delete", func() {
 		It("should delete the Azure PublicIPAddress if it is found", func() {
-			publicIPAddressesClient.EXPECT().Delete(ctx, resourceGroup, publicIPAddressName).Return(future, nil)
-			publicIPAddressesClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(nil)
-			readRequestsCounter.EXPECT().Inc()
+			publicIPAddressesClient.EXPECT().BeginDelete(ctx, resourceGroup, publicIPAddressName).Return(poller, nil)
+			expectPoll(nil)
+			readRequestsCounter.EXPECT().Inc().Times(3)
 			writeRequestsCounter.EXPECT().Inc()
 
 			Expect(pubipUtils.Delete(ctx, publicIPAddressName)).To(Succeed())
 		})
 
 		It("should not fail if the Azure PublicIPAddress is not found", func() {
-			publicIPAddressesClient.EXPECT().Delete(ctx, resourceGroup, publicIPAddressName).Return(future, notFoundError)
+			publicIPAddressesClient.EXPECT().BeginDelete(ctx, resourceGroup, publicIPAddressName).Return(nil, notFoundError)
 			writeRequestsCounter.EXPECT().Inc()
 
 			Expect(pubipUtils.Delete(ctx, publicIPAddressName)).To(Succeed())
 		})
 
 		It("should fail if deleting the Azure PublicIPAddress fails", func() {
-			publicIPAddressesClient.EXPECT().Delete(ctx, resourceGroup, publicIPAddressName).Return(future, errors.New("test"))
+			publicIPAddressesClient.EXPECT().BeginDelete(ctx, resourceGroup, publicIPAddressName).Return(nil, errors.New("test"))
 			writeRequestsCounter.EXPECT().Inc()
 
 			err := pubipUtils.Delete(ctx, publicIPAddressName)
@@ -382,14 +398,32 @@ var _ = Describe("PublicIPAddressUtils", func() {
 		})
 
 		It("should fail if waiting for the Azure PublicIPAddress deletion to complete fails", func() {
-			publicIPAddressesClient.EXPECT().Delete(ctx, resourceGroup, publicIPAddressName).Return(future, nil)
-			publicIPAddressesClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(errors.New("test"))
+			publicIPAddressesClient.EXPECT().BeginDelete(ctx, resourceGroup, publicIPAddressName).Return(poller, nil)
+			expectPoll(errors.New("test"))
+			readRequestsCounter.EXPECT().Inc().Times(3)
+			writeRequestsCounter.EXPECT().Inc()
+
+			err := pubipUtils.Delete(ctx, publicIPAddressName)
+			Expect(err).To(MatchError("could not wait for the Azure PublicIPAddress deletion to complete: test"))
+		})
+
+		It("should fail if polling the Azure PublicIPAddress deletion fails", func() {
+			publicIPAddressesClient.EXPECT().BeginDelete(ctx, resourceGroup, publicIPAddressName).Return(poller, nil)
+			gomock.InOrder(
+				poller.EXPECT().Done().Return(false),
+				poller.EXPECT().Poll(ctx).Return(nil, errors.New("test")),
+			)
 			readRequestsCounter.EXPECT().Inc()
 			writeRequestsCounter.EXPECT().Inc()
 
 			err := pubipUtils.Delete(ctx, publicIPAddressName)
 			Expect(err).To(MatchError("could not wait for the Azure PublicIPAddress deletion to complete: test"))
 		})
+
+		It("should not delete the Azure PublicIPAddress in dry-run mode", func() {
+			pubipUtils = azure.NewPublicIPAddressUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, true)
+
+			Expect(pubipUtils.Delete(ctx, publicIPAddressName)).To(Succeed())
+		})
 	})
 })
@@ -0,0 +1,96 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+// RateLimiter limits the rate of Azure API requests, using separate token buckets for read and write requests.
+type RateLimiter interface {
+	// WaitRead blocks until an Azure read request is allowed, or the given context is done.
+	WaitRead(ctx context.Context) error
+	// WaitWrite blocks until an Azure write request is allowed, or the given context is done.
+	WaitWrite(ctx context.Context) error
+}
+
+// NewRateLimiter creates a new instance of RateLimiter with the given configuration.
+// If the configuration is nil, or the QPS for a request type is zero, requests of that type are not rate limited.
+// The time spent waiting is added to the given counters, in seconds.
+func NewRateLimiter(
+	cfg *config.AzureRateLimiterConfiguration,
+	readWaitSecondsCounter prometheus.Counter,
+	writeWaitSecondsCounter prometheus.Counter,
+) RateLimiter {
+	if cfg == nil {
+		cfg = &config.AzureRateLimiterConfiguration{}
+	}
+	return &rateLimiter{
+		readLimiter:             newLimiter(cfg.ReadQPS, cfg.ReadBurst),
+		writeLimiter:            newLimiter(cfg.WriteQPS, cfg.WriteBurst),
+		readWaitSecondsCounter:  readWaitSecondsCounter,
+		writeWaitSecondsCounter: writeWaitSecondsCounter,
+	}
+}
+
+type rateLimiter struct {
+	readLimiter             *rate.Limiter
+	writeLimiter            *rate.Limiter
+	readWaitSecondsCounter  prometheus.Counter
+	writeWaitSecondsCounter prometheus.Counter
+}
+
+// WaitRead blocks until an Azure read request is allowed, or the given context is done.
+func (r *rateLimiter) WaitRead(ctx context.Context) error {
+	return wait(ctx, r.readLimiter, r.readWaitSecondsCounter)
+}
+
+// WaitWrite blocks until an Azure write request is allowed, or the given context is done.
+func (r *rateLimiter) WaitWrite(ctx context.Context) error {
+	return wait(ctx, r.writeLimiter, r.writeWaitSecondsCounter)
+}
+
+func newLimiter(qps float64, burst int) *rate.Limiter {
+	if qps <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(qps), max(burst, 1))
+}
+
+func wait(ctx context.Context, limiter *rate.Limiter, waitSecondsCounter prometheus.Counter) error {
+	reservation := limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	waitSecondsCounter.Add(delay.Seconds())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return errors.Wrap(ctx.Err(), "could not wait for Azure rate limiter")
+	}
+}
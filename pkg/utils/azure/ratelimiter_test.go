@@ -0,0 +1,99 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	mockprometheus "github.com/gardener/remedy-controller/pkg/mock/prometheus"
+	"github.com/gardener/remedy-controller/pkg/utils/azure"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		ctrl *gomock.Controller
+		ctx  context.Context
+
+		readWaitSecondsCounter  *mockprometheus.MockCounter
+		writeWaitSecondsCounter *mockprometheus.MockCounter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.TODO()
+
+		readWaitSecondsCounter = mockprometheus.NewMockCounter(ctrl)
+		writeWaitSecondsCounter = mockprometheus.NewMockCounter(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should not limit requests if there is no configuration", func() {
+		rateLimiter := azure.NewRateLimiter(nil, readWaitSecondsCounter, writeWaitSecondsCounter)
+
+		for i := 0; i < 100; i++ {
+			Expect(rateLimiter.WaitRead(ctx)).To(Succeed())
+			Expect(rateLimiter.WaitWrite(ctx)).To(Succeed())
+		}
+	})
+
+	It("should allow requests up to the burst without waiting", func() {
+		rateLimiter := azure.NewRateLimiter(&config.AzureRateLimiterConfiguration{
+			ReadQPS:    0.01,
+			ReadBurst:  3,
+			WriteQPS:   0.01,
+			WriteBurst: 1,
+		}, readWaitSecondsCounter, writeWaitSecondsCounter)
+
+		for i := 0; i < 3; i++ {
+			Expect(rateLimiter.WaitRead(ctx)).To(Succeed())
+		}
+		Expect(rateLimiter.WaitWrite(ctx)).To(Succeed())
+	})
+
+	It("should wait and record the time spent waiting if the burst is exceeded", func() {
+		rateLimiter := azure.NewRateLimiter(&config.AzureRateLimiterConfiguration{
+			ReadQPS:   100,
+			ReadBurst: 1,
+		}, readWaitSecondsCounter, writeWaitSecondsCounter)
+		readWaitSecondsCounter.EXPECT().Add(gomock.Any()).Do(func(seconds float64) {
+			Expect(seconds).To(BeNumerically(">", 0))
+			Expect(seconds).To(BeNumerically("<=", 0.01))
+		})
+
+		Expect(rateLimiter.WaitRead(ctx)).To(Succeed())
+		Expect(rateLimiter.WaitRead(ctx)).To(Succeed())
+	})
+
+	It("should fail if the context is done while waiting", func() {
+		rateLimiter := azure.NewRateLimiter(&config.AzureRateLimiterConfiguration{
+			WriteQPS:   0.01,
+			WriteBurst: 1,
+		}, readWaitSecondsCounter, writeWaitSecondsCounter)
+		writeWaitSecondsCounter.EXPECT().Add(gomock.Any())
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		Expect(rateLimiter.WaitWrite(cancelCtx)).To(Succeed())
+		Expect(rateLimiter.WaitWrite(cancelCtx)).To(MatchError("could not wait for Azure rate limiter: context canceled"))
+	})
+})
@@ -17,7 +17,8 @@ package azure
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -27,57 +28,93 @@ import (
 // VirtualMachineUtils provides utility methods for getting and reapplying Azure VirtualMachine objects.
 type VirtualMachineUtils interface {
 	// Get returns the VirtualMachine with the given name, or nil if not found.
-	Get(ctx context.Context, name string) (*compute.VirtualMachine, error)
-	// Reapply reapplies the state of the VirtualMachine with the given name.
+	Get(ctx context.Context, name string) (*armcompute.VirtualMachine, error)
+	// Reapply reapplies the state of the VirtualMachine with the given name. In dry-run mode, the VirtualMachine is not reapplied.
+	// If reapplying is not supported by the Azure API profile, an error is returned even in dry-run mode.
 	Reapply(ctx context.Context, name string) error
 }
 
 // NewVirtualMachineUtils creates a new instance of VirtualMachineUtils.
 func NewVirtualMachineUtils(
-	azureClients *azure.Clients,
+	azureClients azure.ClientsProvider,
 	resourceGroup string,
 	readRequestsCounter prometheus.Counter,
 	writeRequestsCounter prometheus.Counter,
+	rateLimiter RateLimiter,
+	dryRun bool,
 ) VirtualMachineUtils {
 	return &virtualMachineUtils{
 		azureClients:         azureClients,
 		resourceGroup:        resourceGroup,
 		readRequestsCounter:  readRequestsCounter,
 		writeRequestsCounter: writeRequestsCounter,
+		rateLimiter:          rateLimiter,
+		dryRun:               dryRun,
 	}
 }
 
 type virtualMachineUtils struct {
-	azureClients         *azure.Clients
+	azureClients         azure.ClientsProvider
 	resourceGroup        string
 	readRequestsCounter  prometheus.Counter
 	writeRequestsCounter prometheus.Counter
+	rateLimiter          RateLimiter
+	dryRun               bool
 }
 
 // Get returns the VirtualMachine with the given name, or nil if not found.
-func (p *virtualMachineUtils) Get(ctx context.Context, name string) (*compute.VirtualMachine, error) {
-	p.readRequestsCounter.Inc()
-	azurePublicIP, err := p.azureClients.VirtualMachinesClient.Get(ctx, p.resourceGroup, name, compute.InstanceView)
+func (p *virtualMachineUtils) Get(ctx context.Context, name string) (*armcompute.VirtualMachine, error) {
+	if err := p.waitRead(ctx); err != nil {
+		return nil, err
+	}
+	azureVM, err := p.azureClients.Clients().VirtualMachinesClient.Get(ctx, p.resourceGroup, name, &armcompute.VirtualMachinesClientGetOptions{
+		Expand: to.Ptr(armcompute.InstanceViewTypesInstanceView),
+	})
 	if err != nil {
 		if isAzureNotFoundError(err) {
 			return nil, nil
 		}
-		return nil, errors.Wrap(err, "could not get Azure VirtualMachine")
+		return nil, wrapError(err, "could not get Azure VirtualMachine")
 	}
-	return &azurePublicIP, nil
+	return &azureVM.VirtualMachine, nil
 }
 
 // Reapply reapplies the state of the VirtualMachine with the given name.
 func (p *virtualMachineUtils) Reapply(ctx context.Context, name string) error {
-	p.writeRequestsCounter.Inc()
-	result, err := p.azureClients.VirtualMachinesClient.Reapply(ctx, p.resourceGroup, name)
+	clients := p.azureClients.Clients()
+	if !clients.Supports(azure.FeatureVMReapply) {
+		return errors.Wrap(&azure.FeatureNotSupportedError{Feature: azure.FeatureVMReapply, APIProfile: clients.APIProfile.Name}, "could not reapply Azure VirtualMachine")
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	if err := p.waitWrite(ctx); err != nil {
+		return err
+	}
+	poller, err := clients.VirtualMachinesClient.BeginReapply(ctx, p.resourceGroup, name)
 	if err != nil {
-		return errors.Wrap(err, "could not reapply Azure VirtualMachine")
+		return wrapError(err, "could not reapply Azure VirtualMachine")
 	}
-	p.readRequestsCounter.Inc()
-	if err := result.WaitForCompletionRef(ctx, p.azureClients.VirtualMachinesClient.Client()); err != nil {
-		return errors.Wrap(err, "could not wait for the Azure VirtualMachine reapply to complete")
+	if err := pollUntilDone(ctx, poller, p.waitRead); err != nil {
+		return wrapError(err, "could not wait for the Azure VirtualMachine reapply to complete")
+	}
+
+	return nil
+}
+
+func (p *virtualMachineUtils) waitRead(ctx context.Context) error {
+	if err := p.rateLimiter.WaitRead(ctx); err != nil {
+		return err
 	}
+	p.readRequestsCounter.Inc()
+	return nil
+}
 
+func (p *virtualMachineUtils) waitWrite(ctx context.Context) error {
+	if err := p.rateLimiter.WaitWrite(ctx); err != nil {
+		return err
+	}
+	p.writeRequestsCounter.Inc()
 	return nil
 }
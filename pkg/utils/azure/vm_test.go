@@ -17,9 +17,10 @@ package azure_test
 import (
 	"context"
 	"net/http"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
-	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -44,13 +45,16 @@ var _ = Describe("VirtualMachineUtils", func() {
 		ctx  context.Context
 
 		vmClient             *mockclientazure.MockVirtualMachinesClient
-		future               *mockclientazure.MockFuture
+		poller               *mockclientazure.MockPoller
 		readRequestsCounter  *mockprometheus.MockCounter
 		writeRequestsCounter *mockprometheus.MockCounter
+		clients              *clientazure.Clients
+		rateLimiter          azure.RateLimiter
 
 		vmUtils azure.VirtualMachineUtils
 
-		virtualMachine compute.VirtualMachine
+		virtualMachine armcompute.VirtualMachine
+		getOptions     *armcompute.VirtualMachinesClientGetOptions
 
 		notFoundError error
 	)
@@ -60,22 +64,24 @@ var _ = Describe("VirtualMachineUtils", func() {
 		ctx = context.TODO()
 
 		vmClient = mockclientazure.NewMockVirtualMachinesClient(ctrl)
-		future = mockclientazure.NewMockFuture(ctrl)
+		poller = mockclientazure.NewMockPoller(ctrl)
 		readRequestsCounter = mockprometheus.NewMockCounter(ctrl)
 		writeRequestsCounter = mockprometheus.NewMockCounter(ctrl)
-		clients := &clientazure.Clients{
+		clients = &clientazure.Clients{
 			VirtualMachinesClient: vmClient,
 		}
+		rateLimiter = azure.NewRateLimiter(nil, readRequestsCounter, writeRequestsCounter)
 
-		vmUtils = azure.NewVirtualMachineUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter)
+		vmUtils = azure.NewVirtualMachineUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, false)
 
-		virtualMachine = compute.VirtualMachine{
-			ID:                       ptr.To(virtualMachineID),
-			Name:                     ptr.To(virtualMachineName),
-			VirtualMachineProperties: &compute.VirtualMachineProperties{},
+		virtualMachine = armcompute.VirtualMachine{
+			ID:         ptr.To(virtualMachineID),
+			Name:       ptr.To(virtualMachineName),
+			Properties: &armcompute.VirtualMachineProperties{},
 		}
+		getOptions = &armcompute.VirtualMachinesClientGetOptions{Expand: ptr.To(armcompute.InstanceViewTypesInstanceView)}
 
-		notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "")
+		notFoundError = &azcore.ResponseError{StatusCode: http.StatusNotFound}
 	})
 
 	AfterEach(func() {
@@ -84,7 +90,7 @@ var _ = Describe("VirtualMachineUtils", func() {
 
 	Describe("#Get", func() {
 		It("should return the Azure VirtualMachine if it is found", func() {
-			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, compute.InstanceView).Return(virtualMachine, nil)
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{VirtualMachine: virtualMachine}, nil)
 			readRequestsCounter.EXPECT().Inc()
 
 			result, err := vmUtils.Get(ctx, virtualMachineName)
@@ -93,7 +99,7 @@ var _ = Describe("VirtualMachineUtils", func() {
 		})
 
 		It("should return nil if the Azure VirtualMachine is not found", func() {
-			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, compute.InstanceView).Return(compute.VirtualMachine{}, notFoundError)
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, notFoundError)
 			readRequestsCounter.EXPECT().Inc()
 
 			result, err := vmUtils.Get(ctx, virtualMachineName)
@@ -101,20 +107,90 @@ var _ = Describe("VirtualMachineUtils", func() {
 			Expect(result).To(BeNil())
 		})
 
+		It("should use the current Azure clients of the provider", func() {
+			otherVMClient := mockclientazure.NewMockVirtualMachinesClient(ctrl)
+			clientsProvider := mockclientazure.NewMockClientsProvider(ctrl)
+			clientsProvider.EXPECT().Clients().Return(clients)
+			clientsProvider.EXPECT().Clients().Return(&clientazure.Clients{VirtualMachinesClient: otherVMClient})
+			vmUtils = azure.NewVirtualMachineUtils(clientsProvider, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, false)
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{VirtualMachine: virtualMachine}, nil)
+			otherVMClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{VirtualMachine: virtualMachine}, nil)
+			readRequestsCounter.EXPECT().Inc().Times(2)
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = vmUtils.Get(ctx, virtualMachineName)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("should fail if getting the Azure VirtualMachine fails", func() {
-			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, compute.InstanceView).Return(compute.VirtualMachine{}, errors.New("test"))
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, errors.New("test"))
 			readRequestsCounter.EXPECT().Inc()
 
 			_, err := vmUtils.Get(ctx, virtualMachineName)
 			Expect(err).To(MatchError("could not get Azure VirtualMachine: test"))
 		})
+
+		It("should return a ThrottledError if getting the Azure VirtualMachine is throttled", func() {
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, newResponseError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"30"}}, "TooManyRequests"))
+			readRequestsCounter.EXPECT().Inc()
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			throttledErr, ok := azure.AsThrottledError(err)
+			Expect(ok).To(BeTrue())
+			Expect(throttledErr.RetryAfter).To(Equal(30 * time.Second))
+		})
+
+		It("should return a ThrottledError without Retry-After header if no requests remain", func() {
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, newResponseError(http.StatusInternalServerError, http.Header{"X-Ms-Ratelimit-Remaining-Resource": []string{"Microsoft.Compute/GetVM3Min;0,Microsoft.Compute/GetVM30Min;2000"}}, "InternalServerError"))
+			readRequestsCounter.EXPECT().Inc()
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			throttledErr, ok := azure.AsThrottledError(err)
+			Expect(ok).To(BeTrue())
+			Expect(throttledErr.RetryAfter).To(Equal(azure.DefaultThrottledRetryAfter))
+		})
+
+		It("should return a ThrottledError with the delay until the date of the Retry-After header", func() {
+			retryAfter := time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat)
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, newResponseError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{retryAfter}}, "TooManyRequests"))
+			readRequestsCounter.EXPECT().Inc()
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			throttledErr, ok := azure.AsThrottledError(err)
+			Expect(ok).To(BeTrue())
+			Expect(throttledErr.RetryAfter).To(BeNumerically("~", 2*time.Minute, 2*time.Second))
+		})
+
+		It("should return a ThrottledError with the minimum delay if the Retry-After header is zero", func() {
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, newResponseError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}}, "TooManyRequests"))
+			readRequestsCounter.EXPECT().Inc()
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			throttledErr, ok := azure.AsThrottledError(err)
+			Expect(ok).To(BeTrue())
+			Expect(throttledErr.RetryAfter).To(Equal(azure.MinThrottledRetryAfter))
+		})
+
+		It("should return a ThrottledError with the minimum delay if the date of the Retry-After header is in the past", func() {
+			retryAfter := time.Now().Add(-2 * time.Minute).UTC().Format(http.TimeFormat)
+			vmClient.EXPECT().Get(ctx, resourceGroup, virtualMachineName, getOptions).Return(armcompute.VirtualMachinesClientGetResponse{}, newResponseError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{retryAfter}}, "TooManyRequests"))
+			readRequestsCounter.EXPECT().Inc()
+
+			_, err := vmUtils.Get(ctx, virtualMachineName)
+			throttledErr, ok := azure.AsThrottledError(err)
+			Expect(ok).To(BeTrue())
+			Expect(throttledErr.RetryAfter).To(Equal(azure.MinThrottledRetryAfter))
+		})
 	})
 
 	Describe("#Reapply", func() {
 		It("should reapply the Azure VirtualMachine if it is found", func() {
-			vmClient.EXPECT().Reapply(ctx, resourceGroup, virtualMachineName).Return(future, nil)
-			vmClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(nil)
+			vmClient.EXPECT().BeginReapply(ctx, resourceGroup, virtualMachineName).Return(poller, nil)
+			gomock.InOrder(
+				poller.EXPECT().Done().Return(true),
+				poller.EXPECT().Result(ctx).Return(nil),
+			)
 			readRequestsCounter.EXPECT().Inc()
 			writeRequestsCounter.EXPECT().Inc()
 
@@ -122,7 +198,7 @@ var _ = Describe("VirtualMachineUtils", func() {
 		})
 
 		It("should fail if reapplying the Azure VirtualMachine fails", func() {
-			vmClient.EXPECT().Reapply(ctx, resourceGroup, virtualMachineName).Return(future, errors.New("test"))
+			vmClient.EXPECT().BeginReapply(ctx, resourceGroup, virtualMachineName).Return(nil, errors.New("test"))
 			writeRequestsCounter.EXPECT().Inc()
 
 			err := vmUtils.Reapply(ctx, virtualMachineName)
@@ -130,14 +206,30 @@ var _ = Describe("VirtualMachineUtils", func() {
 		})
 
 		It("should fail if waiting for the Azure VirtualMachine reapply to complete fails", func() {
-			vmClient.EXPECT().Reapply(ctx, resourceGroup, virtualMachineName).Return(future, nil)
-			vmClient.EXPECT().Client().Return(autorest.Client{})
-			future.EXPECT().WaitForCompletionRef(ctx, autorest.Client{}).Return(errors.New("test"))
+			vmClient.EXPECT().BeginReapply(ctx, resourceGroup, virtualMachineName).Return(poller, nil)
+			gomock.InOrder(
+				poller.EXPECT().Done().Return(true),
+				poller.EXPECT().Result(ctx).Return(errors.New("test")),
+			)
 			readRequestsCounter.EXPECT().Inc()
 			writeRequestsCounter.EXPECT().Inc()
 
 			err := vmUtils.Reapply(ctx, virtualMachineName)
 			Expect(err).To(MatchError("could not wait for the Azure VirtualMachine reapply to complete: test"))
 		})
+
+		It("should not reapply the Azure VirtualMachine in dry-run mode", func() {
+			vmUtils = azure.NewVirtualMachineUtils(clients, resourceGroup, readRequestsCounter, writeRequestsCounter, rateLimiter, true)
+
+			Expect(vmUtils.Reapply(ctx, virtualMachineName)).To(Succeed())
+		})
+
+		It("should fail with a permanent error if reapplying is not supported by the Azure API profile", func() {
+			clients.APIProfile, _ = clientazure.GetAPIProfile(clientazure.Hybrid20190301APIProfileName)
+
+			err := vmUtils.Reapply(ctx, virtualMachineName)
+			Expect(err).To(MatchError("could not reapply Azure VirtualMachine: feature VMReapply is not supported by Azure API profile 2019-03-01-hybrid"))
+			Expect(azure.ClassifyError(err)).To(Equal(azure.ErrorClassification{Permanent: true, Code: "FeatureNotSupported"}))
+		})
 	})
 })
@@ -0,0 +1,134 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+)
+
+// MaintenanceWindow determines when destructive actions of a remedy may be performed.
+// A nil MaintenanceWindow is always open.
+type MaintenanceWindow struct {
+	location   *time.Location
+	timeRanges []maintenanceTimeRange
+}
+
+type maintenanceTimeRange struct {
+	weekdays map[time.Weekday]bool
+	begin    time.Duration
+	end      time.Duration
+}
+
+// NewMaintenanceWindow creates a new MaintenanceWindow from the given configuration.
+// If the configuration is nil or doesn't specify any time ranges, it returns nil.
+func NewMaintenanceWindow(cfg *config.MaintenanceWindowConfiguration) (*MaintenanceWindow, error) {
+	if cfg == nil || len(cfg.TimeRanges) == 0 {
+		return nil, nil
+	}
+	location := time.UTC
+	if cfg.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.TimeZone); err != nil {
+			return nil, errors.Wrapf(err, "could not load time zone %s", cfg.TimeZone)
+		}
+	}
+	w := &MaintenanceWindow{location: location}
+	for _, tr := range cfg.TimeRanges {
+		weekdays, err := parseWeekdays(tr.Weekdays)
+		if err != nil {
+			return nil, err
+		}
+		begin, err := parseTimeOfDay(tr.Begin)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(tr.End)
+		if err != nil {
+			return nil, err
+		}
+		w.timeRanges = append(w.timeRanges, maintenanceTimeRange{weekdays: weekdays, begin: begin, end: end})
+	}
+	return w, nil
+}
+
+// Until returns 0 if the given time is within the maintenance window,
+// or the duration until the next start of the maintenance window otherwise.
+func (w *MaintenanceWindow) Until(t time.Time) time.Duration {
+	if w == nil {
+		return 0
+	}
+	t = t.In(w.location)
+	var next time.Time
+	// Check the time ranges beginning on the previous day, since they may end on the current day,
+	// up to a week ahead, since each time range begins at least once a week
+	for i := -1; i <= 7; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, w.location)
+		for _, tr := range w.timeRanges {
+			if len(tr.weekdays) > 0 && !tr.weekdays[day.Weekday()] {
+				continue
+			}
+			begin := atTimeOfDay(day, tr.begin)
+			end := atTimeOfDay(day, tr.end)
+			if !end.After(begin) {
+				end = atTimeOfDay(day.AddDate(0, 0, 1), tr.end)
+			}
+			if !t.Before(begin) && t.Before(end) {
+				return 0
+			}
+			if begin.After(t) && (next.IsZero() || begin.Before(next)) {
+				next = begin
+			}
+		}
+	}
+	return next.Sub(t)
+}
+
+func atTimeOfDay(day time.Time, d time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(d/time.Hour), int(d%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse time of day %s, expected format HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWeekdays(names []string) (map[time.Weekday]bool, error) {
+	weekdays := make(map[time.Weekday]bool, len(names))
+	for _, name := range names {
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return nil, errors.Errorf("invalid weekday %s", name)
+		}
+		weekdays[weekday] = true
+	}
+	return weekdays, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(name, d.String()) || strings.EqualFold(name, d.String()[:3]) {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/remedy-controller/pkg/apis/config"
+	. "github.com/gardener/remedy-controller/pkg/utils"
+)
+
+var _ = Describe("MaintenanceWindow", func() {
+	var (
+		// Saturday, 2021-01-02 10:00 UTC
+		saturday = time.Date(2021, time.January, 2, 10, 0, 0, 0, time.UTC)
+	)
+
+	Describe("#NewMaintenanceWindow", func() {
+		It("should return nil if there is no configuration", func() {
+			w, err := NewMaintenanceWindow(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w).To(BeNil())
+		})
+
+		It("should fail if the time zone is invalid", func() {
+			_, err := NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{
+				TimeZone:   "Foo/Bar",
+				TimeRanges: []config.MaintenanceTimeRange{{Begin: "22:00", End: "06:00"}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if a weekday is invalid", func() {
+			_, err := NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{Weekdays: []string{"Caturday"}, Begin: "22:00", End: "06:00"}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if a time of day is invalid", func() {
+			_, err := NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{
+				TimeRanges: []config.MaintenanceTimeRange{{Begin: "10pm", End: "06:00"}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#Until", func() {
+		newMaintenanceWindow := func(timeZone string, timeRanges ...config.MaintenanceTimeRange) *MaintenanceWindow {
+			w, err := NewMaintenanceWindow(&config.MaintenanceWindowConfiguration{TimeZone: timeZone, TimeRanges: timeRanges})
+			Expect(err).NotTo(HaveOccurred())
+			return w
+		}
+
+		It("should always be open if nil", func() {
+			var w *MaintenanceWindow
+			Expect(w.Until(saturday)).To(Equal(time.Duration(0)))
+		})
+
+		It("should return 0 within a time range", func() {
+			w := newMaintenanceWindow("", config.MaintenanceTimeRange{Begin: "09:00", End: "11:00"})
+			Expect(w.Until(saturday)).To(Equal(time.Duration(0)))
+		})
+
+		It("should return the duration until the next begin of a time range on the same day", func() {
+			w := newMaintenanceWindow("", config.MaintenanceTimeRange{Begin: "22:00", End: "06:00"})
+			Expect(w.Until(saturday)).To(Equal(12 * time.Hour))
+		})
+
+		It("should return 0 within a time range that began on the previous day", func() {
+			w := newMaintenanceWindow("", config.MaintenanceTimeRange{Weekdays: []string{"Friday"}, Begin: "22:00", End: "11:00"})
+			Expect(w.Until(saturday)).To(Equal(time.Duration(0)))
+		})
+
+		It("should return the duration until the next begin of a time range on a later weekday", func() {
+			w := newMaintenanceWindow("",
+				config.MaintenanceTimeRange{Weekdays: []string{"Mon", "wednesday"}, Begin: "01:00", End: "05:00"},
+				config.MaintenanceTimeRange{Weekdays: []string{"Saturday"}, Begin: "08:00", End: "09:00"},
+			)
+			Expect(w.Until(saturday)).To(Equal(39 * time.Hour))
+		})
+
+		It("should take the time zone into account", func() {
+			w := newMaintenanceWindow("Europe/Berlin", config.MaintenanceTimeRange{Begin: "12:00", End: "13:00"})
+			Expect(w.Until(saturday)).To(Equal(1 * time.Hour))
+		})
+	})
+})
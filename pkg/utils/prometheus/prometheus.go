@@ -21,3 +21,8 @@ type GaugeVec interface {
 	WithLabelValues(lvs ...string) prometheus.Gauge
 	DeleteLabelValues(lvs ...string) bool
 }
+
+// CounterVec is an interface that contains the relevant methods of prometheus.CounterVec.
+type CounterVec interface {
+	WithLabelValues(lvs ...string) prometheus.Counter
+}